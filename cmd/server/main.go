@@ -1,16 +1,26 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"laundry-go/internal/config"
 	"laundry-go/internal/database"
+	"laundry-go/internal/events"
 	"laundry-go/internal/handlers"
 	"laundry-go/internal/middleware"
 	"laundry-go/internal/models"
+	"laundry-go/internal/notification"
 	"laundry-go/internal/repository"
 	"laundry-go/internal/service"
+	"laundry-go/internal/storage"
+	"laundry-go/internal/worker"
 	"log"
+	"net/http"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,6 +47,13 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// The partial unique indexes and search triggers the services rely on
+	// come from migrations/, not AutoMigrate. Check for them first, so
+	// AutoMigrate never creates tables the SQL migrations then trip over.
+	if err := database.CheckSchema(db); err != nil {
+		log.Fatalf("%v; apply migrations/*.sql in order (see README)", err)
+	}
+
 	// Auto migrate
 	err = db.AutoMigrate(
 		&models.User{},
@@ -44,7 +61,43 @@ func main() {
 		&models.Service{},
 		&models.Order{},
 		&models.OrderService{},
+		&models.OrderStatusHistory{},
 		&models.Review{},
+		&models.Promotion{},
+		&models.PromotionRedemption{},
+		&models.LoyaltyLedgerEntry{},
+		&models.WalletAccount{},
+		&models.WalletTransaction{},
+		&models.WalletEntry{},
+		&models.SubscriptionPackage{},
+		&models.Subscription{},
+		&models.SubscriptionUsage{},
+		&models.NotificationPreference{},
+		&models.Notification{},
+		&models.Job{},
+		&models.OutboxEvent{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.Delivery{},
+		&models.CourierLocation{},
+		&models.CourierLocationPing{},
+		&models.RoutePlan{},
+		&models.RoutePlanStop{},
+		&models.OrderProof{},
+		&models.LaundryImage{},
+		&models.GarmentItem{},
+		&models.GarmentScan{},
+		&models.OrderAdjustment{},
+		&models.OrderAdjustmentLine{},
+		&models.Claim{},
+		&models.ClaimItem{},
+		&models.ClaimPhoto{},
+		&models.ClaimMessage{},
+		&models.OrderMessage{},
+		&models.OrderChatRead{},
+		&models.FavoriteLaundry{},
+		&models.RecurringOrder{},
+		&models.RecurringOrderLine{},
 	)
 	if err != nil {
 		// Check if error is just "relation already exists" - this is OK
@@ -64,22 +117,116 @@ func main() {
 	serviceRepo := repository.NewServiceRepository(db)
 	orderRepo := repository.NewOrderRepository(db)
 	orderServiceRepo := repository.NewOrderServiceRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	loyaltyRepo := repository.NewLoyaltyRepository(db)
+	walletRepo := repository.NewWalletRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	deliveryRepo := repository.NewDeliveryRepository(db)
+	courierLocationRepo := repository.NewCourierLocationRepository(db)
+	routePlanRepo := repository.NewRoutePlanRepository(db)
+	orderProofRepo := repository.NewOrderProofRepository(db)
+	laundryImageRepo := repository.NewLaundryImageRepository(db)
+	garmentRepo := repository.NewGarmentRepository(db)
+	orderAdjustmentRepo := repository.NewOrderAdjustmentRepository(db)
+	claimRepo := repository.NewClaimRepository(db)
+	orderMessageRepo := repository.NewOrderMessageRepository(db)
+	favoriteRepo := repository.NewFavoriteRepository(db)
+	recurringOrderRepo := repository.NewRecurringOrderRepository(db)
+	transactor := repository.NewTransactor(db)
+
+	// Background work stops when the server is asked to shut down
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize the order event bus
+	bus := events.NewBus(ctx, cfg.Events, db, database.DSN(cfg))
+
+	// Notification channels fall back to the log sender when not configured
+	senders, err := notification.NewSenders(cfg.Notify)
+	if err != nil {
+		log.Fatalf("Failed to set up notifications: %v", err)
+	}
+
+	blobStore, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to set up file storage: %v", err)
+	}
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, cfg)
-	laundryService := service.NewLaundryService(laundryRepo, serviceRepo, userRepo)
-	orderService := service.NewOrderService(orderRepo, orderServiceRepo, serviceRepo, laundryRepo)
+	laundryService := service.NewLaundryService(laundryRepo, serviceRepo, userRepo, laundryImageRepo)
+	orderService := service.NewOrderService(orderRepo, orderServiceRepo, serviceRepo, laundryRepo, userRepo, promotionRepo, loyaltyRepo, walletRepo, subscriptionRepo, notificationRepo, outboxRepo, deliveryRepo, courierLocationRepo, garmentRepo, orderAdjustmentRepo, claimRepo, transactor, cfg.Loyalty, cfg.Order)
+	promotionService := service.NewPromotionService(promotionRepo, laundryRepo)
+	loyaltyService := service.NewLoyaltyService(loyaltyRepo, cfg.Loyalty)
+	walletService := service.NewWalletService(walletRepo, userRepo, transactor)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, laundryRepo, walletRepo, userRepo, transactor)
+	notificationService := service.NewNotificationService(notificationRepo, transactor, senders)
+	outboxService := service.NewOutboxService(outboxRepo, jobRepo, transactor)
+	deliveryService := service.NewDeliveryService(deliveryRepo, orderRepo, userRepo, outboxRepo, transactor, orderService)
+	webhookService := service.NewWebhookService(webhookRepo, laundryRepo, jobRepo, transactor, cfg.Webhook)
+	trackingService := service.NewTrackingService(deliveryRepo, courierLocationRepo, orderRepo, transactor, bus, cfg.Order)
+	proofService := service.NewProofService(orderProofRepo, orderRepo, deliveryRepo, transactor, blobStore, cfg.Storage.MaxUploadSize)
+	laundryImageService := service.NewLaundryImageService(laundryImageRepo, laundryRepo, transactor, blobStore, cfg.Storage.MaxUploadSize)
+	adjustmentService := service.NewAdjustmentService(orderAdjustmentRepo, orderRepo, orderServiceRepo, promotionRepo, subscriptionRepo, walletRepo, userRepo, notificationRepo, outboxRepo, transactor, cfg.Order)
+	claimService := service.NewClaimService(claimRepo, orderRepo, laundryRepo, garmentRepo, walletRepo, loyaltyRepo, userRepo, notificationRepo, outboxRepo, transactor, blobStore, cfg.Order, cfg.Storage.MaxUploadSize)
+	chatService := service.NewChatService(orderMessageRepo, orderRepo, deliveryRepo, userRepo, blobStore, bus, cfg.Storage.MaxUploadSize)
+	favoriteService := service.NewFavoriteService(favoriteRepo, laundryRepo, serviceRepo, laundryImageRepo, userRepo)
+	recurringOrderService := service.NewRecurringOrderService(recurringOrderRepo, orderRepo, laundryRepo, serviceRepo, userRepo, notificationRepo, orderService, transactor, cfg.Order)
+	garmentService := service.NewGarmentService(garmentRepo, orderRepo, laundryRepo, transactor)
+	routePlanService := service.NewRoutePlanService(routePlanRepo, orderRepo, deliveryRepo, laundryRepo, userRepo, outboxRepo, transactor, cfg.Order)
+	jobService := service.NewJobService(jobRepo)
+
+	// Run the background worker in-process unless it runs as cmd/worker
+	if cfg.Worker.Enabled {
+		w := worker.New(jobRepo, transactor, cfg.Worker.Concurrency, cfg.Worker.PollInterval)
+		worker.RegisterDefaults(w, bus, worker.Services{
+			Outbox:       outboxService,
+			Notification: notificationService,
+			Order:        orderService,
+			Webhook:      webhookService,
+			Tracking:     trackingService,
+			Recurring:    recurringOrderService,
+			Subscription: subscriptionService,
+		}, cfg)
+		go w.Run(ctx)
+	}
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	laundryHandler := handlers.NewLaundryHandler(laundryService)
 	orderHandler := handlers.NewOrderHandler(orderService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+	loyaltyHandler := handlers.NewLoyaltyHandler(loyaltyService)
+	walletHandler := handlers.NewWalletHandler(walletService)
+	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService)
+	eventsHandler := handlers.NewEventsHandler(bus, orderService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	jobHandler := handlers.NewJobHandler(jobService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	deliveryHandler := handlers.NewDeliveryHandler(deliveryService)
+	trackingHandler := handlers.NewTrackingHandler(bus, trackingService)
+	routePlanHandler := handlers.NewRoutePlanHandler(routePlanService)
+	proofHandler := handlers.NewProofHandler(proofService, cfg.Storage.MaxUploadSize)
+	laundryImageHandler := handlers.NewLaundryImageHandler(laundryImageService, cfg.Storage.MaxUploadSize)
+	garmentHandler := handlers.NewGarmentHandler(garmentService)
+	adjustmentHandler := handlers.NewAdjustmentHandler(adjustmentService)
+	claimHandler := handlers.NewClaimHandler(claimService, cfg.Storage.MaxUploadSize)
+	chatHandler := handlers.NewChatHandler(bus, chatService, cfg.Storage.MaxUploadSize)
+	favoriteHandler := handlers.NewFavoriteHandler(favoriteService)
+	recurringOrderHandler := handlers.NewRecurringOrderHandler(recurringOrderService)
+	boardHandler := handlers.NewBoardHandler(bus, orderService, cfg.CORS.AllowedOrigins)
 
 	// Setup router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(middleware.LoggerMiddleware(), gin.Recovery())
 
 	// CORS middleware
 	router.Use(middleware.CORSMiddleware(cfg))
+	router.Use(middleware.LocaleMiddleware())
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
@@ -95,7 +242,9 @@ func main() {
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
 			auth.GET("/me", middleware.AuthMiddleware(cfg), authHandler.GetMe)
+			auth.GET("/me/loyalty", middleware.AuthMiddleware(cfg), loyaltyHandler.GetSummary)
 			auth.PATCH("/update-location", middleware.AuthMiddleware(cfg), authHandler.UpdateLocation)
+			auth.PATCH("/update-locale", middleware.AuthMiddleware(cfg), authHandler.UpdateLocale)
 		}
 
 		// Laundry routes
@@ -103,8 +252,30 @@ func main() {
 		{
 			laundries.GET("", laundryHandler.GetAll)
 			laundries.GET("/:id", laundryHandler.GetByID)
+			laundries.GET("/:id/packages", subscriptionHandler.GetPackages)
+			laundries.GET("/:id/images/:imageId/:variant", laundryImageHandler.Serve)
+			laundries.POST("/:id/images", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), laundryImageHandler.Upload)
+			laundries.PUT("/:id/images/order", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), laundryImageHandler.Reorder)
+			laundries.DELETE("/:id/images/:imageId", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), laundryImageHandler.Delete)
+			laundries.POST("/:id/packages", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), subscriptionHandler.CreatePackage)
+			laundries.GET("/:id/webhooks", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), webhookHandler.GetByLaundry)
+			laundries.POST("/:id/webhooks", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), webhookHandler.Create)
+			laundries.GET("/:id/route-plans", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), routePlanHandler.GetByLaundry)
+			laundries.POST("/:id/route-plans", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), routePlanHandler.Create)
+			laundries.GET("/:id/claims", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), claimHandler.GetByLaundry)
+			laundries.POST("/:id/favorite", middleware.AuthMiddleware(cfg), favoriteHandler.Add)
+			laundries.DELETE("/:id/favorite", middleware.AuthMiddleware(cfg), favoriteHandler.Remove)
 		}
 
+		// Current user's saved laundries
+		api.GET("/me/favorites", middleware.AuthMiddleware(cfg), favoriteHandler.GetMine)
+
+		// Order event streams (SSE; token may be passed as ?access_token=)
+		api.GET("/orders/events", middleware.StreamAuthMiddleware(cfg), eventsHandler.UserEvents)
+		api.GET("/orders/:id/events", middleware.StreamAuthMiddleware(cfg), eventsHandler.OrderEvents)
+		api.GET("/orders/:id/tracking/stream", middleware.StreamAuthMiddleware(cfg), trackingHandler.Stream)
+		api.GET("/orders/:id/messages/stream", middleware.StreamAuthMiddleware(cfg), chatHandler.Stream)
+
 		// Order routes (protected)
 		orders := api.Group("/orders")
 		orders.Use(middleware.AuthMiddleware(cfg))
@@ -112,15 +283,154 @@ func main() {
 			orders.POST("", orderHandler.Create)
 			orders.GET("", orderHandler.GetAll)
 			orders.GET("/:id", orderHandler.GetByID)
+			orders.GET("/:id/history", orderHandler.GetHistory)
+			orders.POST("/:id/reorder", orderHandler.Reorder)
 			orders.PATCH("/:id/cancel", orderHandler.Cancel)
 			orders.PATCH("/:id/status", middleware.RequireRole("laundry_owner"), orderHandler.UpdateStatus)
+			orders.PATCH("/:id/payment", middleware.RequireRole("laundry_owner"), orderHandler.MarkPaid)
+			orders.GET("/:id/deliveries", deliveryHandler.GetByOrder)
+			orders.POST("/:id/deliveries", middleware.RequireRole("laundry_owner"), deliveryHandler.Assign)
+			orders.GET("/:id/tracking", trackingHandler.GetTracking)
+			orders.POST("/:id/proofs", proofHandler.Upload)
+			orders.GET("/:id/proofs", proofHandler.GetByOrder)
+			orders.GET("/:id/proofs/:proofId/file", proofHandler.Download)
+			orders.GET("/:id/adjustments", adjustmentHandler.GetByOrder)
+			orders.POST("/:id/adjustments", middleware.RequireRole("laundry_owner"), adjustmentHandler.Create)
+			orders.POST("/:id/adjustments/:adjustmentId/accept", adjustmentHandler.Accept)
+			orders.POST("/:id/adjustments/:adjustmentId/reject", adjustmentHandler.Reject)
+			orders.GET("/:id/garments", garmentHandler.GetByOrder)
+			orders.POST("/:id/garments", middleware.RequireRole("laundry_owner"), garmentHandler.Create)
+			orders.PATCH("/:id/garments/:garmentId", middleware.RequireRole("laundry_owner"), garmentHandler.Update)
+			orders.DELETE("/:id/garments/:garmentId", middleware.RequireRole("laundry_owner"), garmentHandler.Delete)
+			orders.GET("/:id/garments/:garmentId/label", middleware.RequireRole("laundry_owner"), garmentHandler.Label)
+			orders.GET("/:id/claims", claimHandler.GetByOrder)
+			orders.POST("/:id/claims", claimHandler.Create)
+			orders.GET("/:id/messages", chatHandler.GetMessages)
+			orders.POST("/:id/messages", chatHandler.Send)
+			orders.POST("/:id/messages/read", chatHandler.MarkRead)
+			orders.GET("/:id/messages/:messageId/attachment", chatHandler.DownloadAttachment)
+		}
+
+		// Recurring order routes
+		recurring := api.Group("/recurring-orders")
+		recurring.Use(middleware.AuthMiddleware(cfg))
+		{
+			recurring.POST("", recurringOrderHandler.Create)
+			recurring.GET("", recurringOrderHandler.GetMine)
+			recurring.GET("/:id", recurringOrderHandler.GetByID)
+			recurring.PATCH("/:id/pause", recurringOrderHandler.Pause)
+			recurring.PATCH("/:id/resume", recurringOrderHandler.Resume)
+			recurring.PATCH("/:id/skip-next", recurringOrderHandler.SkipNext)
+			recurring.PATCH("/:id/cancel", recurringOrderHandler.Cancel)
+		}
+
+		// Courier routes
+		courier := api.Group("/courier")
+		courier.Use(middleware.AuthMiddleware(cfg), middleware.RequireRole("courier"))
+		{
+			courier.GET("/deliveries", deliveryHandler.GetMine)
+			courier.PATCH("/deliveries/:id/status", deliveryHandler.UpdateStatus)
+			courier.POST("/location", trackingHandler.RecordLocation)
+			courier.GET("/route-plans", routePlanHandler.GetMine)
+		}
+
+		// Claim routes (the customer, the laundry owner or an admin)
+		claims := api.Group("/claims")
+		claims.Use(middleware.AuthMiddleware(cfg))
+		{
+			claims.GET("", claimHandler.GetMine)
+			claims.GET("/:id", claimHandler.GetByID)
+			claims.POST("/:id/photos", claimHandler.UploadPhotos)
+			claims.GET("/:id/photos/:photoId/file", claimHandler.DownloadPhoto)
+			claims.POST("/:id/messages", claimHandler.AddMessage)
+			claims.POST("/:id/resolve", middleware.RequireRole("laundry_owner", "admin"), claimHandler.Resolve)
+			claims.POST("/:id/escalate", claimHandler.Escalate)
+			claims.POST("/:id/close", claimHandler.Close)
+		}
+
+		// Garment scanning (laundry owners)
+		api.POST("/garments/scan", middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), garmentHandler.Scan)
+
+		// Route plans (the laundry owner or a courier with a run in the plan)
+		api.GET("/route-plans/:id", middleware.AuthMiddleware(cfg), routePlanHandler.GetByID)
+
+		// Owner live order board (WebSocket; token may be passed as ?access_token=)
+		api.GET("/owner/board", middleware.StreamAuthMiddleware(cfg), middleware.RequireRole("laundry_owner"), boardHandler.Connect)
+
+		// Wallet routes (protected)
+		wallet := api.Group("/wallet")
+		wallet.Use(middleware.AuthMiddleware(cfg))
+		{
+			wallet.GET("", walletHandler.GetBalances)
+			wallet.GET("/transactions", walletHandler.GetHistory)
+			wallet.POST("/topups", middleware.RequireRole("admin"), walletHandler.TopUp)
+			wallet.POST("/adjustments", middleware.RequireRole("admin"), walletHandler.Adjust)
+		}
+
+		// Subscription routes (protected)
+		subscriptions := api.Group("/subscriptions")
+		subscriptions.Use(middleware.AuthMiddleware(cfg))
+		{
+			subscriptions.POST("", subscriptionHandler.Subscribe)
+			subscriptions.GET("", subscriptionHandler.GetMine)
+			subscriptions.GET("/:id/usage", subscriptionHandler.GetUsage)
+			subscriptions.POST("/:id/renew", subscriptionHandler.Renew)
+			subscriptions.PATCH("/:id/cancel", subscriptionHandler.Cancel)
+		}
+
+		// Notification routes (protected)
+		notifications := api.Group("/notifications")
+		notifications.Use(middleware.AuthMiddleware(cfg))
+		{
+			notifications.GET("", notificationHandler.GetAll)
+			notifications.GET("/preferences", notificationHandler.GetPreferences)
+			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		// Webhook routes (laundry owners)
+		webhooks := api.Group("/webhooks")
+		webhooks.Use(middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner"))
+		{
+			webhooks.PATCH("/:id", webhookHandler.Update)
+			webhooks.DELETE("/:id", webhookHandler.Delete)
+			webhooks.POST("/:id/ping", webhookHandler.Ping)
+			webhooks.GET("/:id/deliveries", webhookHandler.GetDeliveries)
+			webhooks.POST("/:id/deliveries/:deliveryId/replay", webhookHandler.Replay)
+		}
+
+		// Admin routes
+		admin := api.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(cfg), middleware.RequireRole("admin"))
+		{
+			admin.GET("/jobs", jobHandler.GetAll)
+			admin.POST("/jobs/:id/retry", jobHandler.Retry)
+			admin.GET("/claims", claimHandler.GetAll)
+		}
+
+		// Promotion routes (laundry owners and admins)
+		promotions := api.Group("/promotions")
+		promotions.Use(middleware.AuthMiddleware(cfg), middleware.RequireRole("laundry_owner", "admin"))
+		{
+			promotions.POST("", promotionHandler.Create)
+			promotions.GET("", promotionHandler.GetAll)
+			promotions.PATCH("/:id/deactivate", promotionHandler.Deactivate)
 		}
 	}
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Server.Port)
+	server := &http.Server{Addr: addr, Handler: router}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown: %v", err)
+		}
+	}()
+
 	log.Printf("Server starting on %s", addr)
-	if err := router.Run(addr); err != nil {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
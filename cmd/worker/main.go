@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"laundry-go/internal/config"
+	"laundry-go/internal/database"
+	"laundry-go/internal/events"
+	"laundry-go/internal/notification"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/service"
+	"laundry-go/internal/worker"
+	"log"
+	"os/signal"
+	"syscall"
+)
+
+// The worker runs background jobs on its own, for deployments that set
+// WORKER_ENABLED=false on the API servers. Tables are migrated by the API
+// server; the worker refuses to start without migrations/ applied. Events
+// it publishes reach API servers' SSE and WebSocket clients through
+// EVENT_BUS=postgres, so the memory bus is refused.
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.NewPostgresDB(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := database.CheckSchema(db); err != nil {
+		log.Fatalf("%v; apply migrations/*.sql in order (see README)", err)
+	}
+
+	if cfg.Events.Bus != "postgres" {
+		log.Fatal("EVENT_BUS must be postgres when running cmd/worker: events would not reach API server streams")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	bus := events.NewBus(ctx, cfg.Events, db, database.DSN(cfg))
+
+	senders, err := notification.NewSenders(cfg.Notify)
+	if err != nil {
+		log.Fatalf("Failed to set up notifications: %v", err)
+	}
+
+	// Initialize repositories
+	userRepo := repository.NewUserRepository(db)
+	laundryRepo := repository.NewLaundryRepository(db)
+	serviceRepo := repository.NewServiceRepository(db)
+	orderRepo := repository.NewOrderRepository(db)
+	orderServiceRepo := repository.NewOrderServiceRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	loyaltyRepo := repository.NewLoyaltyRepository(db)
+	walletRepo := repository.NewWalletRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	deliveryRepo := repository.NewDeliveryRepository(db)
+	courierLocationRepo := repository.NewCourierLocationRepository(db)
+	garmentRepo := repository.NewGarmentRepository(db)
+	orderAdjustmentRepo := repository.NewOrderAdjustmentRepository(db)
+	claimRepo := repository.NewClaimRepository(db)
+	recurringOrderRepo := repository.NewRecurringOrderRepository(db)
+	transactor := repository.NewTransactor(db)
+
+	// Initialize services
+	orderService := service.NewOrderService(orderRepo, orderServiceRepo, serviceRepo, laundryRepo, userRepo, promotionRepo, loyaltyRepo, walletRepo, subscriptionRepo, notificationRepo, outboxRepo, deliveryRepo, courierLocationRepo, garmentRepo, orderAdjustmentRepo, claimRepo, transactor, cfg.Loyalty, cfg.Order)
+	notificationService := service.NewNotificationService(notificationRepo, transactor, senders)
+	outboxService := service.NewOutboxService(outboxRepo, jobRepo, transactor)
+	webhookService := service.NewWebhookService(webhookRepo, laundryRepo, jobRepo, transactor, cfg.Webhook)
+	trackingService := service.NewTrackingService(deliveryRepo, courierLocationRepo, orderRepo, transactor, bus, cfg.Order)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, laundryRepo, walletRepo, userRepo, transactor)
+	recurringOrderService := service.NewRecurringOrderService(recurringOrderRepo, orderRepo, laundryRepo, serviceRepo, userRepo, notificationRepo, orderService, transactor, cfg.Order)
+
+	w := worker.New(jobRepo, transactor, cfg.Worker.Concurrency, cfg.Worker.PollInterval)
+	worker.RegisterDefaults(w, bus, worker.Services{
+		Outbox:       outboxService,
+		Notification: notificationService,
+		Order:        orderService,
+		Webhook:      webhookService,
+		Tracking:     trackingService,
+		Recurring:    recurringOrderService,
+		Subscription: subscriptionService,
+	}, cfg)
+	w.Run(ctx)
+	log.Println("Worker stopped")
+}
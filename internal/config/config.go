@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"laundry-go/internal/models"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,13 @@ type Config struct {
 	Database DatabaseConfig
 	JWT      JWTConfig
 	CORS     CORSConfig
+	Loyalty  LoyaltyConfig
+	Order    OrderConfig
+	Events   EventsConfig
+	Notify   NotificationConfig
+	Worker   WorkerConfig
+	Webhook  WebhookConfig
+	Storage  StorageConfig
 }
 
 type ServerConfig struct {
@@ -39,6 +48,88 @@ type CORSConfig struct {
 	AllowedOrigins []string
 }
 
+// LoyaltyConfig holds the platform-wide loyalty program settings.
+// Laundries may override PointsPerThousand individually.
+type LoyaltyConfig struct {
+	PointsPerThousand float64       // points earned per 1.000 of order total
+	PointValue        models.Money  // value of one point when redeemed
+	Expiry            time.Duration // how long earned points stay redeemable
+}
+
+// OrderConfig holds order lifecycle settings. Laundries may override
+// ConfirmWindow individually.
+type OrderConfig struct {
+	ConfirmWindow   time.Duration // how long a laundry has to confirm a new order
+	ExpiryInterval  time.Duration // how often overdue pending orders are cancelled
+	CourierSpeedKmh float64       // average courier speed used for live ETAs
+	// WeightTolerancePercent is how much a weight adjustment may raise the
+	// total before the customer has to accept it
+	WeightTolerancePercent float64
+	ClaimWindow            time.Duration // how long after delivery customers can open a claim
+	// Recurring orders are placed RecurringLeadTime before each pickup,
+	// checked every RecurringInterval. Their weekday and pickup time are
+	// read in Timezone.
+	RecurringLeadTime time.Duration
+	RecurringInterval time.Duration
+	Timezone          *time.Location
+}
+
+// EventsConfig selects the order event bus. "memory" keeps events inside
+// one process; "postgres" fans them out to every instance via LISTEN/NOTIFY.
+type EventsConfig struct {
+	Bus       string
+	ListenURL string // direct (non-pooled) connection for LISTEN; defaults to the main DSN
+}
+
+// NotificationConfig holds channel credentials. A channel without
+// credentials is written to LogFile (stdout when empty) instead of sent.
+type NotificationConfig struct {
+	LogFile          string
+	DispatchInterval time.Duration
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	TwilioSMSFrom    string
+	TwilioWhatsApp   string
+	PushURL          string
+	PushKey          string
+}
+
+// WorkerConfig controls the background job worker. When Enabled the API
+// server runs the worker in-process; cmd/worker runs it on its own.
+type WorkerConfig struct {
+	Enabled      bool
+	Concurrency  int
+	PollInterval time.Duration
+}
+
+// WebhookConfig controls delivery to laundry webhook endpoints.
+// AllowPrivate permits plain http and loopback/private addresses so
+// deliveries can be tried against a local receiver; keep it off in
+// production.
+type WebhookConfig struct {
+	Timeout      time.Duration
+	AllowPrivate bool
+}
+
+// StorageConfig selects where uploaded files are kept. "local" writes them
+// under LocalDir; "s3" uses an S3-compatible bucket such as MinIO.
+type StorageConfig struct {
+	Driver        string
+	LocalDir      string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3PathStyle   bool  // endpoint/bucket/key instead of bucket.endpoint/key
+	MaxUploadSize int64 // bytes per file
+}
+
 func Load() (*Config, error) {
 	// Load .env file if exists (optional)
 	_ = godotenv.Load()
@@ -50,6 +141,106 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT_EXPIRY format: %w", err)
 	}
 
+	// Parse loyalty settings
+	pointsPerThousand, err := strconv.ParseFloat(getEnv("LOYALTY_POINTS_PER_THOUSAND", "1"), 64)
+	if err != nil || pointsPerThousand < 0 {
+		return nil, fmt.Errorf("invalid LOYALTY_POINTS_PER_THOUSAND value")
+	}
+	pointValue, err := models.ParseMoney(getEnv("LOYALTY_POINT_VALUE", "10"))
+	if err != nil || pointValue <= 0 {
+		return nil, fmt.Errorf("invalid LOYALTY_POINT_VALUE value")
+	}
+	loyaltyExpiry, err := time.ParseDuration(getEnv("LOYALTY_POINTS_EXPIRY", "8760h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOYALTY_POINTS_EXPIRY format: %w", err)
+	}
+
+	confirmWindow, err := time.ParseDuration(getEnv("ORDER_CONFIRM_WINDOW", "2h"))
+	if err != nil || confirmWindow <= 0 {
+		return nil, fmt.Errorf("invalid ORDER_CONFIRM_WINDOW format")
+	}
+	expiryInterval, err := time.ParseDuration(getEnv("ORDER_EXPIRY_INTERVAL", "1m"))
+	if err != nil || expiryInterval <= 0 {
+		return nil, fmt.Errorf("invalid ORDER_EXPIRY_INTERVAL format")
+	}
+
+	courierSpeed, err := strconv.ParseFloat(getEnv("COURIER_AVG_SPEED_KMH", "20"), 64)
+	if err != nil || courierSpeed <= 0 {
+		return nil, fmt.Errorf("invalid COURIER_AVG_SPEED_KMH value")
+	}
+
+	weightTolerance, err := strconv.ParseFloat(getEnv("ORDER_WEIGHT_TOLERANCE_PERCENT", "10"), 64)
+	if err != nil || weightTolerance < 0 {
+		return nil, fmt.Errorf("invalid ORDER_WEIGHT_TOLERANCE_PERCENT value")
+	}
+
+	claimWindow, err := time.ParseDuration(getEnv("CLAIM_WINDOW", "168h"))
+	if err != nil || claimWindow <= 0 {
+		return nil, fmt.Errorf("invalid CLAIM_WINDOW format")
+	}
+
+	recurringLeadTime, err := time.ParseDuration(getEnv("RECURRING_ORDER_LEAD_TIME", "24h"))
+	if err != nil || recurringLeadTime < 0 {
+		return nil, fmt.Errorf("invalid RECURRING_ORDER_LEAD_TIME format")
+	}
+	recurringInterval, err := time.ParseDuration(getEnv("RECURRING_ORDER_INTERVAL", "15m"))
+	if err != nil || recurringInterval <= 0 {
+		return nil, fmt.Errorf("invalid RECURRING_ORDER_INTERVAL format")
+	}
+	timezone, err := time.LoadLocation(getEnv("ORDER_TIMEZONE", "Asia/Jakarta"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ORDER_TIMEZONE value: %w", err)
+	}
+
+	eventBus := getEnv("EVENT_BUS", "postgres")
+	if eventBus != "memory" && eventBus != "postgres" {
+		return nil, fmt.Errorf("invalid EVENT_BUS value: must be memory or postgres")
+	}
+
+	dispatchInterval, err := time.ParseDuration(getEnv("NOTIFY_DISPATCH_INTERVAL", "5s"))
+	if err != nil || dispatchInterval <= 0 {
+		return nil, fmt.Errorf("invalid NOTIFY_DISPATCH_INTERVAL format")
+	}
+
+	workerEnabled, err := strconv.ParseBool(getEnv("WORKER_ENABLED", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WORKER_ENABLED value")
+	}
+	// Events published by cmd/worker never reach an in-process bus
+	if !workerEnabled && eventBus == "memory" {
+		return nil, fmt.Errorf("EVENT_BUS=memory needs WORKER_ENABLED=true: use EVENT_BUS=postgres with cmd/worker")
+	}
+	workerConcurrency, err := strconv.Atoi(getEnv("WORKER_CONCURRENCY", "4"))
+	if err != nil || workerConcurrency < 1 {
+		return nil, fmt.Errorf("invalid WORKER_CONCURRENCY value")
+	}
+	workerPollInterval, err := time.ParseDuration(getEnv("WORKER_POLL_INTERVAL", "1s"))
+	if err != nil || workerPollInterval <= 0 {
+		return nil, fmt.Errorf("invalid WORKER_POLL_INTERVAL format")
+	}
+
+	webhookTimeout, err := time.ParseDuration(getEnv("WEBHOOK_TIMEOUT", "10s"))
+	if err != nil || webhookTimeout <= 0 {
+		return nil, fmt.Errorf("invalid WEBHOOK_TIMEOUT format")
+	}
+	webhookAllowPrivate, err := strconv.ParseBool(getEnv("WEBHOOK_ALLOW_PRIVATE", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WEBHOOK_ALLOW_PRIVATE value")
+	}
+
+	storageDriver := getEnv("STORAGE_DRIVER", "local")
+	if storageDriver != "local" && storageDriver != "s3" {
+		return nil, fmt.Errorf("invalid STORAGE_DRIVER value: must be local or s3")
+	}
+	s3PathStyle, err := strconv.ParseBool(getEnv("S3_PATH_STYLE", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3_PATH_STYLE value")
+	}
+	maxUploadMB, err := strconv.Atoi(getEnv("STORAGE_MAX_UPLOAD_MB", "10"))
+	if err != nil || maxUploadMB < 1 {
+		return nil, fmt.Errorf("invalid STORAGE_MAX_UPLOAD_MB value")
+	}
+
 	// Parse CORS origins
 	allowedOriginsStr := getEnv("ALLOWED_ORIGINS", "http://localhost:3000")
 	allowedOrigins := []string{}
@@ -82,6 +273,60 @@ func Load() (*Config, error) {
 		CORS: CORSConfig{
 			AllowedOrigins: allowedOrigins,
 		},
+		Loyalty: LoyaltyConfig{
+			PointsPerThousand: pointsPerThousand,
+			PointValue:        pointValue,
+			Expiry:            loyaltyExpiry,
+		},
+		Order: OrderConfig{
+			ConfirmWindow:          confirmWindow,
+			ExpiryInterval:         expiryInterval,
+			CourierSpeedKmh:        courierSpeed,
+			WeightTolerancePercent: weightTolerance,
+			ClaimWindow:            claimWindow,
+			RecurringLeadTime:      recurringLeadTime,
+			RecurringInterval:      recurringInterval,
+			Timezone:               timezone,
+		},
+		Events: EventsConfig{
+			Bus:       eventBus,
+			ListenURL: os.Getenv("EVENT_BUS_LISTEN_URL"),
+		},
+		Notify: NotificationConfig{
+			LogFile:          os.Getenv("NOTIFY_LOG_FILE"),
+			DispatchInterval: dispatchInterval,
+			SMTPHost:         os.Getenv("SMTP_HOST"),
+			SMTPPort:         getEnv("SMTP_PORT", "587"),
+			SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+			SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+			SMTPFrom:         os.Getenv("SMTP_FROM"),
+			TwilioAccountSID: os.Getenv("TWILIO_ACCOUNT_SID"),
+			TwilioAuthToken:  os.Getenv("TWILIO_AUTH_TOKEN"),
+			TwilioSMSFrom:    os.Getenv("TWILIO_SMS_FROM"),
+			TwilioWhatsApp:   os.Getenv("TWILIO_WHATSAPP_FROM"),
+			PushURL:          os.Getenv("PUSH_GATEWAY_URL"),
+			PushKey:          os.Getenv("PUSH_GATEWAY_KEY"),
+		},
+		Worker: WorkerConfig{
+			Enabled:      workerEnabled,
+			Concurrency:  workerConcurrency,
+			PollInterval: workerPollInterval,
+		},
+		Webhook: WebhookConfig{
+			Timeout:      webhookTimeout,
+			AllowPrivate: webhookAllowPrivate,
+		},
+		Storage: StorageConfig{
+			Driver:        storageDriver,
+			LocalDir:      getEnv("STORAGE_LOCAL_DIR", "uploads"),
+			S3Endpoint:    os.Getenv("S3_ENDPOINT"),
+			S3Region:      getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:      os.Getenv("S3_BUCKET"),
+			S3AccessKey:   os.Getenv("S3_ACCESS_KEY"),
+			S3SecretKey:   os.Getenv("S3_SECRET_KEY"),
+			S3PathStyle:   s3PathStyle,
+			MaxUploadSize: int64(maxUploadMB) << 20,
+		},
 	}
 
 	return config, nil
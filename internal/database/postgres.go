@@ -12,25 +12,7 @@ import (
 )
 
 func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
-	var dsn string
-
-	// Check if DATABASE_URL is provided (for Supabase, Heroku, etc.)
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL != "" {
-		// Use DATABASE_URL directly
-		dsn = databaseURL
-	} else {
-		// Build DSN from individual config values
-		dsn = fmt.Sprintf(
-			"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-			cfg.Database.Host,
-			cfg.Database.User,
-			cfg.Database.Password,
-			cfg.Database.Name,
-			cfg.Database.Port,
-			cfg.Database.SSLMode,
-		)
-	}
+	dsn := DSN(cfg)
 
 	var logLevel logger.LogLevel
 	if cfg.Server.Env == "production" {
@@ -55,3 +37,21 @@ func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+// DSN returns the connection string, preferring DATABASE_URL when set.
+func DSN(cfg *config.Config) string {
+	// Check if DATABASE_URL is provided (for Supabase, Heroku, etc.)
+	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
+		return databaseURL
+	}
+
+	// Build DSN from individual config values
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		cfg.Database.Host,
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Name,
+		cfg.Database.Port,
+		cfg.Database.SSLMode,
+	)
+}
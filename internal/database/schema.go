@@ -0,0 +1,53 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// sqlOnlyIndexes are created by migrations/ and not by AutoMigrate. The
+// services rely on them to reject duplicates under concurrency.
+var sqlOnlyIndexes = []struct {
+	name      string
+	migration string
+}{
+	{"idx_order_proofs_signature", "014_order_proofs.sql"},
+	{"idx_order_adjustments_pending", "017_order_adjustments.sql"},
+	{"idx_claims_active", "018_claims.sql"},
+	{"idx_orders_recurrence", "021_recurring_orders.sql"},
+	{"idx_laundries_search_vector", "022_laundry_search.sql"},
+}
+
+// CheckSchema returns an error naming the migrations still to be applied
+// when objects that only the SQL files in migrations/ create are missing.
+func CheckSchema(db *gorm.DB) error {
+	var missing []string
+
+	for _, index := range sqlOnlyIndexes {
+		var count int64
+		err := db.Raw("SELECT COUNT(*) FROM pg_indexes WHERE schemaname = current_schema() AND indexname = ?", index.name).
+			Scan(&count).Error
+		if err != nil {
+			return fmt.Errorf("failed to check index %s: %w", index.name, err)
+		}
+		if count == 0 {
+			missing = append(missing, fmt.Sprintf("%s (index %s)", index.migration, index.name))
+		}
+	}
+
+	var triggers int64
+	err := db.Raw("SELECT COUNT(*) FROM pg_trigger WHERE tgname = 'trg_laundries_search'").Scan(&triggers).Error
+	if err != nil {
+		return fmt.Errorf("failed to check trigger trg_laundries_search: %w", err)
+	}
+	if triggers == 0 {
+		missing = append(missing, "022_laundry_search.sql (trigger trg_laundries_search)")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("database migrations not applied: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types published by the order flow.
+const (
+	OrderCreated       = "order.created"
+	OrderStatusChanged = "order.status_changed"
+	OrderCancelled     = "order.cancelled"
+	OrderETAChanged    = "order.eta_changed"
+	PaymentSucceeded   = "payment.succeeded"
+	PaymentRefunded    = "payment.refunded"
+
+	OrderAdjustmentRequested = "order.adjustment_requested"
+	OrderAdjustmentRejected  = "order.adjustment_rejected"
+	OrderAdjusted            = "order.adjusted"
+
+	ClaimOpened        = "claim.opened"
+	ClaimStatusChanged = "claim.status_changed"
+
+	DeliveryStatusChanged = "delivery.status_changed"
+	CourierLocation       = "courier.location" // published straight to the bus, not through the outbox
+
+	// Chat messages are stored before they are published, so these go
+	// straight to the bus as well.
+	ChatMessage = "chat.message"
+	ChatRead    = "chat.read"
+)
+
+// Event is a change to an order. UserID is the customer and LaundryID the
+// laundry, so subscribers can filter for either side of the order.
+type Event struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	OrderID    uuid.UUID              `json:"order_id"`
+	UserID     uuid.UUID              `json:"user_id"`
+	LaundryID  uuid.UUID              `json:"laundry_id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+// Filter selects the events a subscriber receives.
+type Filter func(Event) bool
+
+// Bus fans order events out to subscribers. Publish must not block on slow
+// subscribers.
+type Bus interface {
+	Publish(event Event) error
+	Subscribe(filter Filter) *Subscription
+}
+
+// Subscription is a live feed of events from a Bus. Close it when the
+// consumer goes away.
+type Subscription struct {
+	events chan Event
+	close  func()
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// NewEvent fills in the ID and timestamp of an event.
+func NewEvent(eventType string, orderID, userID, laundryID uuid.UUID, data map[string]interface{}) Event {
+	return Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		OrderID:    orderID,
+		UserID:     userID,
+		LaundryID:  laundryID,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+}
+
+// ForUser matches events on the customer's own orders.
+func ForUser(userID uuid.UUID) Filter {
+	return func(e Event) bool {
+		return e.UserID == userID
+	}
+}
+
+// ForOrder matches events on one order.
+func ForOrder(orderID uuid.UUID) Filter {
+	return func(e Event) bool {
+		return e.OrderID == orderID
+	}
+}
+
+// ForLaundries matches events on orders placed at any of the laundries.
+func ForLaundries(laundryIDs []uuid.UUID) Filter {
+	set := make(map[uuid.UUID]struct{}, len(laundryIDs))
+	for _, id := range laundryIDs {
+		set[id] = struct{}{}
+	}
+	return func(e Event) bool {
+		_, ok := set[e.LaundryID]
+		return ok
+	}
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"log"
+	"sync"
+)
+
+// subscriberBuffer is how many undelivered events a subscriber may lag
+// behind before further events to it are dropped.
+const subscriberBuffer = 64
+
+// MemoryBus delivers events to subscribers in this process only.
+type MemoryBus struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]Filter
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{subscribers: make(map[*Subscription]Filter)}
+}
+
+func (b *MemoryBus) Publish(event Event) error {
+	b.dispatch(event)
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{events: make(chan Event, subscriberBuffer)}
+
+	var once sync.Once
+	sub.close = func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, sub)
+			close(sub.events)
+			b.mu.Unlock()
+		})
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = filter
+	b.mu.Unlock()
+	return sub
+}
+
+// dispatch hands the event to every matching subscriber without blocking.
+// A subscriber whose buffer is full misses the event; clients re-read the
+// order on reconnect.
+func (b *MemoryBus) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub, filter := range b.subscribers {
+		if filter != nil && !filter(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("events: dropping %s for slow subscriber", event.Type)
+		}
+	}
+}
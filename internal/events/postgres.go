@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	// notifyChannel is the Postgres channel order events are broadcast on.
+	notifyChannel = "order_events"
+	// maxNotifyPayload keeps notifications under Postgres' 8000 byte limit.
+	maxNotifyPayload = 7900
+)
+
+// PostgresBus broadcasts events with NOTIFY and delivers what it hears on
+// LISTEN to local subscribers, so every server instance sees every event.
+// LISTEN needs a session, so dsn must not point at a transaction-pooling
+// pgbouncer.
+type PostgresBus struct {
+	db    *gorm.DB
+	dsn   string
+	local *MemoryBus
+}
+
+func NewPostgresBus(db *gorm.DB, dsn string) *PostgresBus {
+	return &PostgresBus{
+		db:    db,
+		dsn:   dsn,
+		local: NewMemoryBus(),
+	}
+}
+
+// Publish sends the event to all instances, including this one. An event
+// too large for a notification, such as a long chat message, is sent
+// without its data and marked truncated; subscribers reload what changed.
+func (b *PostgresBus) Publish(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		event.Data = map[string]interface{}{"truncated": true}
+		if payload, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+	return b.db.Exec("SELECT pg_notify(?, ?)", notifyChannel, string(payload)).Error
+}
+
+func (b *PostgresBus) Subscribe(filter Filter) *Subscription {
+	return b.local.Subscribe(filter)
+}
+
+// Listen receives notifications until ctx is cancelled, reconnecting with
+// backoff if the connection drops. Run it in its own goroutine.
+func (b *PostgresBus) Listen(ctx context.Context) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("events: listener stopped: %v; reconnecting in %s", err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (b *PostgresBus) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("events: invalid notification payload: %v", err)
+			continue
+		}
+		b.local.dispatch(event)
+	}
+}
@@ -0,0 +1,24 @@
+package events
+
+import (
+	"context"
+	"laundry-go/internal/config"
+
+	"gorm.io/gorm"
+)
+
+// NewBus builds the bus selected by EVENT_BUS. The Postgres bus starts
+// listening straight away on dsn, or on cfg.ListenURL when set, until ctx
+// is cancelled.
+func NewBus(ctx context.Context, cfg config.EventsConfig, db *gorm.DB, dsn string) Bus {
+	if cfg.Bus != "postgres" {
+		return NewMemoryBus()
+	}
+
+	if cfg.ListenURL != "" {
+		dsn = cfg.ListenURL
+	}
+	bus := NewPostgresBus(db, dsn)
+	go bus.Listen(ctx)
+	return bus
+}
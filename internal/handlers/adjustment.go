@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdjustmentHandler struct {
+	adjustmentService service.AdjustmentService
+}
+
+func NewAdjustmentHandler(adjustmentService service.AdjustmentService) *AdjustmentHandler {
+	return &AdjustmentHandler{adjustmentService: adjustmentService}
+}
+
+// Create handles POST /api/v1/orders/:id/adjustments
+func (h *AdjustmentHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.CreateAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.adjustmentService.Create(userID.(string), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Order adjusted successfully", response)
+}
+
+// GetByOrder handles GET /api/v1/orders/:id/adjustments
+func (h *AdjustmentHandler) GetByOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.adjustmentService.GetByOrder(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Accept handles POST /api/v1/orders/:id/adjustments/:adjustmentId/accept
+func (h *AdjustmentHandler) Accept(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.adjustmentService.Accept(userID.(string), c.Param("id"), c.Param("adjustmentId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Adjustment accepted", response)
+}
+
+// Reject handles POST /api/v1/orders/:id/adjustments/:adjustmentId/reject
+func (h *AdjustmentHandler) Reject(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	// The reason is optional, so an empty body is fine
+	var req service.RejectAdjustmentRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	response, err := h.adjustmentService.Reject(userID.(string), c.Param("id"), c.Param("adjustmentId"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Adjustment rejected", response)
+}
@@ -80,6 +80,7 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 		"latitude":  user.Latitude,
 		"longitude": user.Longitude,
 		"role":      user.Role,
+		"locale":    user.Locale,
 	})
 }
 
@@ -114,3 +115,31 @@ func (h *AuthHandler) UpdateLocation(c *gin.Context) {
 	})
 }
 
+// UpdateLocale handles PATCH /api/v1/auth/update-locale
+func (h *AuthHandler) UpdateLocale(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req struct {
+		Locale string `json:"locale"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userIDStr := userID.(string)
+	user, err := h.authService.UpdateLocale(userIDStr, req.Locale)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Locale updated successfully", gin.H{
+		"id":     user.ID.String(),
+		"locale": user.Locale,
+	})
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"laundry-go/internal/events"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	boardPingInterval = 30 * time.Second
+	boardPongWait     = 60 * time.Second
+	boardWriteWait    = 10 * time.Second
+	boardMaxMessage   = 4096
+
+	// boardRefreshInterval is how often the owner's laundries are reloaded,
+	// so a laundry added while the board is open starts showing up.
+	boardRefreshInterval = 5 * time.Minute
+)
+
+// boardEventTypes are the events forwarded to the board. Chat and courier
+// location have their own streams and are left out.
+var boardEventTypes = map[string]bool{
+	events.OrderCreated:             true,
+	events.OrderStatusChanged:       true,
+	events.OrderCancelled:           true,
+	events.OrderETAChanged:          true,
+	events.PaymentSucceeded:         true,
+	events.PaymentRefunded:          true,
+	events.OrderAdjustmentRequested: true,
+	events.OrderAdjustmentRejected:  true,
+	events.OrderAdjusted:            true,
+	events.ClaimOpened:              true,
+	events.ClaimStatusChanged:       true,
+	events.DeliveryStatusChanged:    true,
+}
+
+// boardLaundries is the set of laundries a board connection follows. It is
+// replaced whenever a new snapshot is taken.
+type boardLaundries struct {
+	mu  sync.RWMutex
+	ids map[uuid.UUID]struct{}
+}
+
+// set replaces the followed laundries and reports whether they changed.
+func (l *boardLaundries) set(laundryIDs []string) (bool, error) {
+	ids := make(map[uuid.UUID]struct{}, len(laundryIDs))
+	for _, raw := range laundryIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return false, err
+		}
+		ids[id] = struct{}{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	changed := len(ids) != len(l.ids)
+	for id := range ids {
+		if _, ok := l.ids[id]; !ok {
+			changed = true
+		}
+	}
+	l.ids = ids
+	return changed, nil
+}
+
+// filter matches board events on orders at the followed laundries.
+func (l *boardLaundries) filter(e events.Event) bool {
+	if !boardEventTypes[e.Type] {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.ids[e.LaundryID]
+	return ok
+}
+
+// BoardMessage is the envelope for every message on the live order board.
+//
+// Server to client: "snapshot" (open orders, sent on connect, on "resync" and
+// when the owner's laundries change), "event" (an order event), "ack" (a command succeeded, with the updated
+// order), "error" and "pong".
+//
+// Client to server: "update_status" (order_id, status), "resync" and "ping".
+// request_id is echoed back on the matching ack or error.
+type BoardMessage struct {
+	Type      string                      `json:"type"`
+	RequestID string                      `json:"request_id,omitempty"`
+	OrderID   string                      `json:"order_id,omitempty"`
+	Status    string                      `json:"status,omitempty"`
+	Board     *service.OrderBoardResponse `json:"board,omitempty"`
+	Event     *events.Event               `json:"event,omitempty"`
+	Order     *service.OrderResponse      `json:"order,omitempty"`
+	Error     string                      `json:"error,omitempty"`
+}
+
+type BoardHandler struct {
+	bus          events.Bus
+	orderService service.OrderService
+	upgrader     websocket.Upgrader
+}
+
+func NewBoardHandler(bus events.Bus, orderService service.OrderService, allowedOrigins []string) *BoardHandler {
+	return &BoardHandler{
+		bus:          bus,
+		orderService: orderService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" {
+					return true
+				}
+				for _, allowed := range allowedOrigins {
+					if allowed == "*" || allowed == origin {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+}
+
+// Connect handles GET /api/v1/owner/board
+// The JWT is checked before the upgrade. Clients should reconnect with
+// backoff when the socket drops; every new connection starts with a fresh
+// snapshot, so nothing is lost while disconnected.
+func (h *BoardHandler) Connect(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+	ownerID := userID.(string)
+	locale := c.GetString("locale")
+
+	board, err := h.orderService.GetBoard(ownerID, locale)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	laundries := &boardLaundries{}
+	if _, err := laundries.set(board.LaundryIDs); err != nil {
+		log.Printf("board: invalid laundry ID for owner %s: %v", ownerID, err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "failed to load order board")
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written an HTTP error
+		return
+	}
+	defer conn.Close()
+
+	sub := h.bus.Subscribe(laundries.filter)
+	defer sub.Close()
+
+	outgoing := make(chan BoardMessage, 16)
+	outgoing <- BoardMessage{Type: "snapshot", Board: board}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go h.readCommands(conn, ownerID, locale, laundries, outgoing, done, stopped)
+
+	ping := time.NewTicker(boardPingInterval)
+	defer ping.Stop()
+	refresh := time.NewTicker(boardRefreshInterval)
+	defer refresh.Stop()
+
+	for {
+		var message BoardMessage
+		select {
+		case <-done:
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			message = BoardMessage{Type: "event", Event: &event}
+		case message = <-outgoing:
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(boardWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+			continue
+		case <-refresh.C:
+			board, err := h.orderService.GetBoard(ownerID, locale)
+			if err != nil {
+				log.Printf("board: refresh failed for owner %s: %v", ownerID, err)
+				continue
+			}
+			changed, err := laundries.set(board.LaundryIDs)
+			if err != nil {
+				log.Printf("board: invalid laundry ID for owner %s: %v", ownerID, err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			// Orders at a newly added laundry are not on the client's board yet
+			message = BoardMessage{Type: "snapshot", Board: board}
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(boardWriteWait))
+		if err := conn.WriteJSON(message); err != nil {
+			return
+		}
+	}
+}
+
+// readCommands runs status changes sent by the client through the same rules
+// as PATCH /orders/:id/status. A resync also reloads the followed laundries.
+// It closes done when the client goes away and gives up on replies once the
+// writer has stopped.
+func (h *BoardHandler) readCommands(conn *websocket.Conn, ownerID, locale string, laundries *boardLaundries, outgoing chan<- BoardMessage, done chan<- struct{}, stopped <-chan struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(boardMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(boardPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(boardPongWait))
+	})
+
+	for {
+		var command BoardMessage
+		if err := conn.ReadJSON(&command); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("board: read error: %v", err)
+			}
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(boardPongWait))
+
+		var reply BoardMessage
+		switch command.Type {
+		case "update_status":
+			order, err := h.orderService.UpdateStatus(ownerID, command.OrderID, command.Status, locale)
+			if err != nil {
+				reply = BoardMessage{Type: "error", RequestID: command.RequestID, Error: err.Error()}
+			} else {
+				reply = BoardMessage{Type: "ack", RequestID: command.RequestID, Order: order}
+			}
+		case "resync":
+			board, err := h.orderService.GetBoard(ownerID, locale)
+			if err == nil {
+				_, err = laundries.set(board.LaundryIDs)
+			}
+			if err != nil {
+				reply = BoardMessage{Type: "error", RequestID: command.RequestID, Error: err.Error()}
+			} else {
+				reply = BoardMessage{Type: "snapshot", RequestID: command.RequestID, Board: board}
+			}
+		case "ping":
+			reply = BoardMessage{Type: "pong", RequestID: command.RequestID}
+		default:
+			reply = BoardMessage{Type: "error", RequestID: command.RequestID, Error: "unknown command"}
+		}
+
+		select {
+		case outgoing <- reply:
+		case <-stopped:
+			return
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"laundry-go/internal/events"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ChatHandler struct {
+	bus           events.Bus
+	chatService   service.ChatService
+	maxUploadSize int64
+}
+
+func NewChatHandler(bus events.Bus, chatService service.ChatService, maxUploadSize int64) *ChatHandler {
+	return &ChatHandler{bus: bus, chatService: chatService, maxUploadSize: maxUploadSize}
+}
+
+// GetMessages handles GET /api/v1/orders/:id/messages
+func (h *ChatHandler) GetMessages(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	response, err := h.chatService.GetMessages(userID.(string), c.Param("id"), c.Query("before"), limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Send handles POST /api/v1/orders/:id/messages
+// The body is either JSON ({"body": "..."}) or multipart/form-data with a
+// body field and an optional file.
+func (h *ChatHandler) Send(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.SendChatMessageRequest
+	if c.ContentType() == "multipart/form-data" {
+		// Leave room for the body field and multipart framing.
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+1<<20)
+		form, err := c.MultipartForm()
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "Upload is too large")
+				return
+			}
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid multipart form")
+			return
+		}
+
+		req.Body = c.PostForm("body")
+		if headers := form.File["file"]; len(headers) > 0 {
+			if len(headers) > 1 {
+				utils.ErrorResponse(c, http.StatusBadRequest, "Too many files")
+				return
+			}
+			data, err := readUpload(headers[0], h.maxUploadSize)
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			req.Attachment = data
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.chatService.Send(c.Request.Context(), userID.(string), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Message sent", response)
+}
+
+// MarkRead handles POST /api/v1/orders/:id/messages/read
+func (h *ChatHandler) MarkRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.chatService.MarkRead(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Chat marked as read", response)
+}
+
+// DownloadAttachment handles GET /api/v1/orders/:id/messages/:messageId/attachment
+func (h *ChatHandler) DownloadAttachment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	message, reader, err := h.chatService.OpenAttachment(c.Request.Context(), userID.(string), c.Param("id"), c.Param("messageId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, message.AttachmentSize, message.AttachmentContentType, reader, map[string]string{
+		"Cache-Control":          "private, max-age=3600",
+		"X-Content-Type-Options": "nosniff",
+		"Content-Disposition":    "inline; filename=\"" + message.ID.String() + "\"",
+	})
+}
+
+// Stream handles GET /api/v1/orders/:id/messages/stream
+// The stream opens with a chat.snapshot of the latest messages, then relays
+// chat.message and chat.read events for the order.
+func (h *ChatHandler) Stream(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	chat, err := h.chatService.GetMessages(userID.(string), c.Param("id"), "", 0)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	orderID, _ := uuid.Parse(chat.OrderID)
+	sub := h.bus.Subscribe(func(event events.Event) bool {
+		return event.OrderID == orderID &&
+			(event.Type == events.ChatMessage || event.Type == events.ChatRead)
+	})
+	defer sub.Close()
+
+	startStream(c)
+	writeSSE(c.Writer, "", "chat.snapshot", chat)
+	streamEvents(c, sub)
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"errors"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClaimHandler struct {
+	claimService  service.ClaimService
+	maxUploadSize int64
+}
+
+func NewClaimHandler(claimService service.ClaimService, maxUploadSize int64) *ClaimHandler {
+	return &ClaimHandler{claimService: claimService, maxUploadSize: maxUploadSize}
+}
+
+// Create handles POST /api/v1/orders/:id/claims
+func (h *ClaimHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.CreateClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.claimService.Create(userID.(string), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Claim opened successfully", response)
+}
+
+// GetByOrder handles GET /api/v1/orders/:id/claims
+func (h *ClaimHandler) GetByOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.claimService.GetByOrder(userID.(string), c.GetString("user_role"), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetMine handles GET /api/v1/claims
+func (h *ClaimHandler) GetMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := h.claimService.GetMine(userID.(string), c.Query("status"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetByLaundry handles GET /api/v1/laundries/:id/claims
+func (h *ClaimHandler) GetByLaundry(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := h.claimService.GetByLaundry(userID.(string), c.Param("id"), c.Query("status"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetAll handles GET /api/v1/admin/claims
+func (h *ClaimHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	escalated := c.Query("escalated") == "true"
+
+	response, err := h.claimService.GetAll(c.Query("status"), escalated, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetByID handles GET /api/v1/claims/:id
+func (h *ClaimHandler) GetByID(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.claimService.GetByID(userID.(string), c.GetString("user_role"), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// UploadPhotos handles POST /api/v1/claims/:id/photos (multipart/form-data
+// with one or more files)
+func (h *ClaimHandler) UploadPhotos(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	// Leave room for the multipart framing.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize*service.MaxClaimPhotos+1<<20)
+	form, err := c.MultipartForm()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "Upload is too large")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	headers := append(form.File["files"], form.File["file"]...)
+	if len(headers) > service.MaxClaimPhotos {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Too many files")
+		return
+	}
+
+	files := make([][]byte, 0, len(headers))
+	for _, header := range headers {
+		data, err := readUpload(header, h.maxUploadSize)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		files = append(files, data)
+	}
+
+	response, err := h.claimService.UploadPhotos(c.Request.Context(), userID.(string), c.Param("id"), files)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Photos uploaded successfully", response)
+}
+
+// DownloadPhoto handles GET /api/v1/claims/:id/photos/:photoId/file
+func (h *ClaimHandler) DownloadPhoto(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	photo, reader, err := h.claimService.OpenPhoto(c.Request.Context(), userID.(string), c.GetString("user_role"), c.Param("id"), c.Param("photoId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, photo.Size, photo.ContentType, reader, map[string]string{
+		"Cache-Control":          "private, max-age=3600",
+		"X-Content-Type-Options": "nosniff",
+		"Content-Disposition":    "inline; filename=\"" + photo.ID.String() + "\"",
+	})
+}
+
+// AddMessage handles POST /api/v1/claims/:id/messages
+func (h *ClaimHandler) AddMessage(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.ClaimMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.claimService.AddMessage(userID.(string), c.GetString("user_role"), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Message posted", response)
+}
+
+// Resolve handles POST /api/v1/claims/:id/resolve
+func (h *ClaimHandler) Resolve(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.ResolveClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.claimService.Resolve(userID.(string), c.GetString("user_role"), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Claim resolved", response)
+}
+
+// Escalate handles POST /api/v1/claims/:id/escalate
+func (h *ClaimHandler) Escalate(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.EscalateClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.claimService.Escalate(userID.(string), c.GetString("user_role"), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Claim escalated", response)
+}
+
+// Close handles POST /api/v1/claims/:id/close
+func (h *ClaimHandler) Close(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.claimService.Close(userID.(string), c.GetString("user_role"), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Claim closed", response)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeliveryHandler struct {
+	deliveryService service.DeliveryService
+}
+
+func NewDeliveryHandler(deliveryService service.DeliveryService) *DeliveryHandler {
+	return &DeliveryHandler{deliveryService: deliveryService}
+}
+
+// Assign handles POST /api/v1/orders/:id/deliveries
+func (h *DeliveryHandler) Assign(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.AssignDeliveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.deliveryService.Assign(userID.(string), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Courier assigned successfully", response)
+}
+
+// GetByOrder handles GET /api/v1/orders/:id/deliveries
+func (h *DeliveryHandler) GetByOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.deliveryService.GetByOrder(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetMine handles GET /api/v1/courier/deliveries
+func (h *DeliveryHandler) GetMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	status := c.Query("status")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := h.deliveryService.GetMine(userID.(string), status, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// UpdateStatus handles PATCH /api/v1/courier/deliveries/:id/status
+func (h *DeliveryHandler) UpdateStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.UpdateDeliveryStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.deliveryService.UpdateStatus(userID.(string), c.Param("id"), req.Status)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Delivery status updated successfully", response)
+}
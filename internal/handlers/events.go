@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"laundry-go/internal/events"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// sseHeartbeat keeps idle streams open through proxies that time out
+// silent connections.
+const sseHeartbeat = 25 * time.Second
+
+type EventsHandler struct {
+	bus          events.Bus
+	orderService service.OrderService
+}
+
+func NewEventsHandler(bus events.Bus, orderService service.OrderService) *EventsHandler {
+	return &EventsHandler{bus: bus, orderService: orderService}
+}
+
+// OrderEvents handles GET /api/v1/orders/:id/events
+// The stream opens with a snapshot of the order so a reconnecting client
+// never misses a change.
+func (h *EventsHandler) OrderEvents(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	order, err := h.orderService.GetByID(userID.(string), c.Param("id"), c.GetString("locale"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	orderID, _ := uuid.Parse(order.ID)
+	sub := h.bus.Subscribe(events.ForOrder(orderID))
+	defer sub.Close()
+
+	startStream(c)
+	writeSSE(c.Writer, "", "order.snapshot", order)
+	streamEvents(c, sub)
+}
+
+// UserEvents handles GET /api/v1/orders/events
+// It streams events for all of the current user's orders.
+func (h *EventsHandler) UserEvents(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	sub := h.bus.Subscribe(events.ForUser(userUUID))
+	defer sub.Close()
+
+	startStream(c)
+	streamEvents(c, sub)
+}
+
+func startStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+// streamEvents relays events until the client disconnects or the token the
+// stream was opened with expires. On expiry it sends token.expired so the
+// client reconnects with a fresh token.
+func streamEvents(c *gin.Context, sub *events.Subscription) {
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	var expired <-chan time.Time
+	if expiresAt, ok := c.Get("token_expires_at"); ok {
+		timer := time.NewTimer(time.Until(expiresAt.(time.Time)))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-expired:
+			writeSSE(w, "", "token.expired", gin.H{"message": "token expired"})
+			return false
+		case event, ok := <-sub.Events():
+			if !ok {
+				return false
+			}
+			writeSSE(w, event.ID, event.Type, event)
+			return true
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			return true
+		}
+	})
+}
+
+func writeSSE(w io.Writer, id, eventType string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, payload)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FavoriteHandler struct {
+	favoriteService service.FavoriteService
+}
+
+func NewFavoriteHandler(favoriteService service.FavoriteService) *FavoriteHandler {
+	return &FavoriteHandler{favoriteService: favoriteService}
+}
+
+// Add handles POST /api/v1/laundries/:id/favorite
+func (h *FavoriteHandler) Add(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	if err := h.favoriteService.Add(userID.(string), c.Param("id")); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Laundry added to favorites", nil)
+}
+
+// Remove handles DELETE /api/v1/laundries/:id/favorite
+func (h *FavoriteHandler) Remove(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	if err := h.favoriteService.Remove(userID.(string), c.Param("id")); err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Laundry removed from favorites", nil)
+}
+
+// GetMine handles GET /api/v1/me/favorites
+func (h *FavoriteHandler) GetMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	response, err := h.favoriteService.GetMine(userID.(string), c.GetString("locale"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GarmentHandler struct {
+	garmentService service.GarmentService
+}
+
+func NewGarmentHandler(garmentService service.GarmentService) *GarmentHandler {
+	return &GarmentHandler{garmentService: garmentService}
+}
+
+// Create handles POST /api/v1/orders/:id/garments
+func (h *GarmentHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.CreateGarmentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.garmentService.Create(userID.(string), c.Param("id"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Items tagged successfully", response)
+}
+
+// GetByOrder handles GET /api/v1/orders/:id/garments
+func (h *GarmentHandler) GetByOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.garmentService.GetByOrder(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Update handles PATCH /api/v1/orders/:id/garments/:garmentId
+func (h *GarmentHandler) Update(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.UpdateGarmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.garmentService.Update(userID.(string), c.Param("id"), c.Param("garmentId"), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Item updated successfully", response)
+}
+
+// Delete handles DELETE /api/v1/orders/:id/garments/:garmentId
+func (h *GarmentHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	if err := h.garmentService.Delete(userID.(string), c.Param("id"), c.Param("garmentId")); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Item deleted successfully", nil)
+}
+
+// Label handles GET /api/v1/orders/:id/garments/:garmentId/label
+func (h *GarmentHandler) Label(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	item, label, err := h.garmentService.Label(userID.(string), c.Param("id"), c.Param("garmentId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=\""+item.TagCode+".png\"")
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, "image/png", label)
+}
+
+// Scan handles POST /api/v1/garments/scan
+func (h *GarmentHandler) Scan(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.ScanGarmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.garmentService.Scan(userID.(string), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Scan recorded successfully", response)
+}
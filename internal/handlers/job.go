@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobService service.JobService
+}
+
+func NewJobHandler(jobService service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// GetAll handles GET /api/v1/admin/jobs
+func (h *JobHandler) GetAll(c *gin.Context) {
+	status := c.Query("status")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := h.jobService.GetAll(status, page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Retry handles POST /api/v1/admin/jobs/:id/retry
+func (h *JobHandler) Retry(c *gin.Context) {
+	response, err := h.jobService.Retry(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Job queued for retry", response)
+}
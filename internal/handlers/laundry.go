@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"laundry-go/internal/models"
 	"laundry-go/internal/service"
 	"laundry-go/internal/utils"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,6 +39,36 @@ func (h *LaundryHandler) GetAll(c *gin.Context) {
 		isOpen = &val
 	}
 
+	filter := service.LaundryListFilter{
+		Search:    search,
+		IsOpen:    isOpen,
+		PriceUnit: c.Query("unit"),
+		Delivery:  c.Query("delivery") == "true",
+	}
+
+	// category may be repeated or comma-separated
+	for _, value := range c.QueryArray("category") {
+		for _, category := range strings.Split(value, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				filter.Categories = append(filter.Categories, category)
+			}
+		}
+	}
+
+	var ok bool
+	if filter.MinPrice, ok = queryMoney(c, "min_price"); !ok {
+		return
+	}
+	if filter.MaxPrice, ok = queryMoney(c, "max_price"); !ok {
+		return
+	}
+	if filter.MinRating, ok = queryFloat(c, "min_rating"); !ok {
+		return
+	}
+	if filter.MaxDistanceKm, ok = queryFloat(c, "max_distance"); !ok {
+		return
+	}
+
 	var lat, lng *float64
 	if latStr != "" && lngStr != "" {
 		if latVal, err := strconv.ParseFloat(latStr, 64); err == nil {
@@ -55,8 +88,13 @@ func (h *LaundryHandler) GetAll(c *gin.Context) {
 
 	_ = sortBy // Will be handled by service based on lat/lng availability
 
-	response, err := h.laundryService.GetAll(search, isOpen, lat, lng, userID, page, limit)
+	response, err := h.laundryService.GetAll(filter, lat, lng, userID, c.GetString("locale"), page, limit)
 	if err != nil {
+		var invalid *service.InvalidFilterError
+		if errors.As(err, &invalid) {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -81,7 +119,7 @@ func (h *LaundryHandler) GetByID(c *gin.Context) {
 		}
 	}
 
-	response, err := h.laundryService.GetByID(id, lat, lng)
+	response, err := h.laundryService.GetByID(id, lat, lng, c.GetString("locale"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
 		return
@@ -90,3 +128,32 @@ func (h *LaundryHandler) GetByID(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "", response)
 }
 
+// queryMoney reads an optional money query parameter, responding with an
+// error when it is invalid.
+func queryMoney(c *gin.Context, name string) (*models.Money, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	money, err := models.ParseMoney(value)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+name)
+		return nil, false
+	}
+	return &money, true
+}
+
+// queryFloat reads an optional number query parameter, responding with an
+// error when it is invalid.
+func queryFloat(c *gin.Context, name string) (*float64, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid "+name)
+		return nil, false
+	}
+	return &number, true
+}
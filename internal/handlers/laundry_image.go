@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LaundryImageHandler struct {
+	imageService  service.LaundryImageService
+	maxUploadSize int64
+}
+
+func NewLaundryImageHandler(imageService service.LaundryImageService, maxUploadSize int64) *LaundryImageHandler {
+	return &LaundryImageHandler{imageService: imageService, maxUploadSize: maxUploadSize}
+}
+
+// Upload handles POST /api/v1/laundries/:id/images (multipart/form-data
+// with kind and file)
+func (h *LaundryImageHandler) Upload(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "Upload is too large")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "file is required")
+		return
+	}
+
+	data, err := readUpload(header, h.maxUploadSize)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.imageService.Upload(c.Request.Context(), userID.(string), c.Param("id"), c.PostForm("kind"), data)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Image uploaded successfully", response)
+}
+
+// Reorder handles PUT /api/v1/laundries/:id/images/order
+func (h *LaundryImageHandler) Reorder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.ReorderLaundryImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.imageService.Reorder(userID.(string), c.Param("id"), req.ImageIDs)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Gallery reordered successfully", response)
+}
+
+// Delete handles DELETE /api/v1/laundries/:id/images/:imageId
+func (h *LaundryImageHandler) Delete(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	if err := h.imageService.Delete(c.Request.Context(), userID.(string), c.Param("id"), c.Param("imageId")); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Image deleted successfully", nil)
+}
+
+// Serve handles GET /api/v1/laundries/:id/images/:imageId/:variant
+func (h *LaundryImageHandler) Serve(c *gin.Context) {
+	contentType, reader, err := h.imageService.Open(c.Request.Context(), c.Param("id"), c.Param("imageId"), c.Param("variant"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	// Image IDs are never reused, so variants can be cached for good.
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -32,7 +32,7 @@ func (h *OrderHandler) Create(c *gin.Context) {
 	}
 
 	userIDStr := userID.(string)
-	response, err := h.orderService.Create(userIDStr, req)
+	response, err := h.orderService.Create(userIDStr, req, c.GetString("locale"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
@@ -57,7 +57,7 @@ func (h *OrderHandler) GetAll(c *gin.Context) {
 	limit, _ := strconv.Atoi(limitStr)
 
 	userIDStr := userID.(string)
-	response, err := h.orderService.GetByUserID(userIDStr, status, page, limit)
+	response, err := h.orderService.GetByUserID(userIDStr, status, page, limit, c.GetString("locale"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -77,7 +77,25 @@ func (h *OrderHandler) GetByID(c *gin.Context) {
 	orderID := c.Param("id")
 
 	userIDStr := userID.(string)
-	response, err := h.orderService.GetByID(userIDStr, orderID)
+	response, err := h.orderService.GetByID(userIDStr, orderID, c.GetString("locale"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetHistory handles GET /api/v1/orders/:id/history
+func (h *OrderHandler) GetHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	userIDStr := userID.(string)
+	response, err := h.orderService.GetHistory(userIDStr, c.Param("id"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
 		return
@@ -96,8 +114,17 @@ func (h *OrderHandler) Cancel(c *gin.Context) {
 
 	orderID := c.Param("id")
 
+	// The body is optional; without it the refund goes back to the original payment method
+	var req service.CancelOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
 	userIDStr := userID.(string)
-	response, err := h.orderService.CancelOrder(userIDStr, orderID)
+	response, err := h.orderService.CancelOrder(userIDStr, orderID, req, c.GetString("locale"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
@@ -106,6 +133,32 @@ func (h *OrderHandler) Cancel(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Order cancelled successfully", response)
 }
 
+// Reorder handles POST /api/v1/orders/:id/reorder
+func (h *OrderHandler) Reorder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	// The body is optional; without it the previous order's details are reused
+	var req service.ReorderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	response, err := h.orderService.Reorder(userID.(string), c.Param("id"), req, c.GetString("locale"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Order created successfully", response)
+}
+
 // UpdateStatus handles PATCH /api/v1/orders/:id/status
 func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -125,7 +178,7 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 	}
 
 	userIDStr := userID.(string)
-	response, err := h.orderService.UpdateStatus(userIDStr, orderID, req.Status)
+	response, err := h.orderService.UpdateStatus(userIDStr, orderID, req.Status, c.GetString("locale"))
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
@@ -134,3 +187,22 @@ func (h *OrderHandler) UpdateStatus(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Order status updated", response)
 }
 
+// MarkPaid handles PATCH /api/v1/orders/:id/payment
+func (h *OrderHandler) MarkPaid(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	orderID := c.Param("id")
+
+	userIDStr := userID.(string)
+	response, err := h.orderService.MarkPaid(userIDStr, orderID, c.GetString("locale"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Order marked as paid", response)
+}
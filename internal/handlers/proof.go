@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProofHandler struct {
+	proofService  service.ProofService
+	maxUploadSize int64
+}
+
+func NewProofHandler(proofService service.ProofService, maxUploadSize int64) *ProofHandler {
+	return &ProofHandler{proofService: proofService, maxUploadSize: maxUploadSize}
+}
+
+// Upload handles POST /api/v1/orders/:id/proofs (multipart/form-data with
+// leg, kind and one or more files)
+func (h *ProofHandler) Upload(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	// Leave room for the form fields and multipart framing.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize*service.MaxProofFiles+1<<20)
+	form, err := c.MultipartForm()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.ErrorResponse(c, http.StatusRequestEntityTooLarge, "Upload is too large")
+			return
+		}
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	headers := append(form.File["files"], form.File["file"]...)
+	if len(headers) > service.MaxProofFiles {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Too many files")
+		return
+	}
+
+	files := make([][]byte, 0, len(headers))
+	for _, header := range headers {
+		data, err := readUpload(header, h.maxUploadSize)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		files = append(files, data)
+	}
+
+	response, err := h.proofService.Upload(c.Request.Context(), userID.(string), c.Param("id"), service.UploadProofRequest{
+		Leg:   c.PostForm("leg"),
+		Kind:  c.PostForm("kind"),
+		Files: files,
+	})
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Proof uploaded successfully", response)
+}
+
+// GetByOrder handles GET /api/v1/orders/:id/proofs
+func (h *ProofHandler) GetByOrder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.proofService.GetByOrder(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Download handles GET /api/v1/orders/:id/proofs/:proofId/file
+func (h *ProofHandler) Download(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	proof, reader, err := h.proofService.Open(c.Request.Context(), userID.(string), c.Param("id"), c.Param("proofId"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, proof.Size, proof.ContentType, reader, map[string]string{
+		"Cache-Control":          "private, max-age=3600",
+		"X-Content-Type-Options": "nosniff",
+		"Content-Disposition":    "inline; filename=\"" + proof.ID.String() + "\"",
+	})
+}
+
+// readUpload reads one uploaded file, refusing files over maxSize bytes.
+func readUpload(header *multipart.FileHeader, maxSize int64) ([]byte, error) {
+	if header.Size > maxSize {
+		return nil, errors.New("file is too large")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, errors.New("failed to read file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, errors.New("failed to read file")
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errors.New("file is too large")
+	}
+	return data, nil
+}
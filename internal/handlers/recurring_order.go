@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecurringOrderHandler struct {
+	recurringOrderService service.RecurringOrderService
+}
+
+func NewRecurringOrderHandler(recurringOrderService service.RecurringOrderService) *RecurringOrderHandler {
+	return &RecurringOrderHandler{recurringOrderService: recurringOrderService}
+}
+
+// Create handles POST /api/v1/recurring-orders
+func (h *RecurringOrderHandler) Create(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.CreateRecurringOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := h.recurringOrderService.Create(userID.(string), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Recurring order created", response)
+}
+
+// GetMine handles GET /api/v1/recurring-orders
+func (h *RecurringOrderHandler) GetMine(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := h.recurringOrderService.GetMine(userID.(string), c.Query("status"), page, limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// GetByID handles GET /api/v1/recurring-orders/:id
+func (h *RecurringOrderHandler) GetByID(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.recurringOrderService.GetByID(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Pause handles PATCH /api/v1/recurring-orders/:id/pause
+func (h *RecurringOrderHandler) Pause(c *gin.Context) {
+	h.change(c, h.recurringOrderService.Pause, "Recurring order paused")
+}
+
+// Resume handles PATCH /api/v1/recurring-orders/:id/resume
+func (h *RecurringOrderHandler) Resume(c *gin.Context) {
+	h.change(c, h.recurringOrderService.Resume, "Recurring order resumed")
+}
+
+// SkipNext handles PATCH /api/v1/recurring-orders/:id/skip-next
+func (h *RecurringOrderHandler) SkipNext(c *gin.Context) {
+	h.change(c, h.recurringOrderService.SkipNext, "Next pickup skipped")
+}
+
+// Cancel handles PATCH /api/v1/recurring-orders/:id/cancel
+func (h *RecurringOrderHandler) Cancel(c *gin.Context) {
+	h.change(c, h.recurringOrderService.Cancel, "Recurring order cancelled")
+}
+
+func (h *RecurringOrderHandler) change(c *gin.Context, fn func(userID, recurringOrderID string) (*service.RecurringOrderResponse, error), message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := fn(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, message, response)
+}
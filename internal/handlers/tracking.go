@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"laundry-go/internal/events"
+	"laundry-go/internal/service"
+	"laundry-go/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrackingHandler struct {
+	bus             events.Bus
+	trackingService service.TrackingService
+}
+
+func NewTrackingHandler(bus events.Bus, trackingService service.TrackingService) *TrackingHandler {
+	return &TrackingHandler{bus: bus, trackingService: trackingService}
+}
+
+// RecordLocation handles POST /api/v1/courier/location
+func (h *TrackingHandler) RecordLocation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	var req service.LocationPingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.trackingService.RecordLocation(userID.(string), req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Location recorded", nil)
+}
+
+// GetTracking handles GET /api/v1/orders/:id/tracking
+func (h *TrackingHandler) GetTracking(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	response, err := h.trackingService.GetTracking(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", response)
+}
+
+// Stream handles GET /api/v1/orders/:id/tracking/stream
+// The stream opens with a tracking.snapshot, then relays courier.location
+// and delivery.status_changed events for the order.
+func (h *TrackingHandler) Stream(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User ID not found")
+		return
+	}
+
+	tracking, err := h.trackingService.GetTracking(userID.(string), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+
+	orderID, _ := uuid.Parse(tracking.OrderID)
+	sub := h.bus.Subscribe(func(event events.Event) bool {
+		return event.OrderID == orderID &&
+			(event.Type == events.CourierLocation || event.Type == events.DeliveryStatusChanged)
+	})
+	defer sub.Close()
+
+	startStream(c)
+	writeSSE(c.Writer, "", "tracking.snapshot", tracking)
+	streamEvents(c, sub)
+}
@@ -0,0 +1,79 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"rsc.io/qr"
+)
+
+const (
+	labelModuleSize = 6  // pixels per QR module
+	labelQuietZone  = 4  // modules of white border around the code
+	labelLineHeight = 16 // pixels per text line
+	labelPadding    = 8
+)
+
+// QRLabel renders a printable PNG label: a QR code of content with the
+// text lines under it. Text is drawn in a fixed ASCII font; other runes
+// print as '?' and lines too long for the label are cut.
+func QRLabel(content string, lines []string) ([]byte, error) {
+	code, err := qr.Encode(content, qr.M)
+	if err != nil {
+		return nil, err
+	}
+
+	qrSide := (code.Size + 2*labelQuietZone) * labelModuleSize
+	width := qrSide
+	height := qrSide + len(lines)*labelLineHeight + labelPadding
+
+	canvas := image.NewGray(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if !code.Black(x, y) {
+				continue
+			}
+			left := (x + labelQuietZone) * labelModuleSize
+			top := (y + labelQuietZone) * labelModuleSize
+			draw.Draw(canvas, image.Rect(left, top, left+labelModuleSize, top+labelModuleSize), image.Black, image.Point{}, draw.Src)
+		}
+	}
+
+	drawer := &font.Drawer{Dst: canvas, Src: image.NewUniform(color.Black), Face: basicfont.Face7x13}
+	maxChars := (width - 2*labelPadding) / basicfont.Face7x13.Advance
+	for i, line := range lines {
+		text := asciiOnly(line)
+		if len(text) > maxChars {
+			text = text[:maxChars]
+		}
+		advance := drawer.MeasureString(text).Round()
+		drawer.Dot = fixed.P((width-advance)/2, qrSide+(i+1)*labelLineHeight-4)
+		drawer.DrawString(text)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func asciiOnly(s string) string {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r >= ' ' && r <= '~' {
+			out = append(out, byte(r))
+		} else {
+			out = append(out, '?')
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"laundry-go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware stores the locale negotiated from Accept-Language in the
+// context under "locale". It is left empty when no supported language is
+// requested so services can fall back to the user's saved locale.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("locale", utils.NegotiateLocale(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Claim statuses. An order cannot be completed while it has an open or
+// under_review claim.
+const (
+	ClaimStatusOpen        = "open"
+	ClaimStatusUnderReview = "under_review"
+	ClaimStatusResolved    = "resolved"
+	ClaimStatusClosed      = "closed"
+)
+
+// What a claim is about.
+const (
+	ClaimTypeDamage = "damage"
+	ClaimTypeLost   = "lost"
+	ClaimTypeOther  = "other"
+)
+
+// How a claim was resolved.
+const (
+	ClaimResolutionRefund   = "refund"
+	ClaimResolutionRework   = "rework"
+	ClaimResolutionRejected = "rejected"
+)
+
+// Claim is a customer's report of damaged or lost items after delivery.
+type Claim struct {
+	ID               uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID          uuid.UUID      `gorm:"type:uuid;not null;index" json:"order_id"`
+	UserID           uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	LaundryID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	Type             string         `gorm:"type:varchar(20);not null" json:"type"`
+	Description      string         `gorm:"type:text;not null" json:"description"`
+	Status           string         `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	Resolution       string         `gorm:"type:varchar(20)" json:"resolution,omitempty"`
+	ResolutionNote   string         `gorm:"type:text" json:"resolution_note,omitempty"`
+	RefundedAmount   Money          `gorm:"type:decimal(12,2);not null;default:0" json:"refunded_amount"`
+	ResolvedBy       *uuid.UUID     `gorm:"type:uuid" json:"resolved_by,omitempty"`
+	ResolvedAt       *time.Time     `json:"resolved_at,omitempty"`
+	EscalatedAt      *time.Time     `gorm:"index" json:"escalated_at,omitempty"` // set once an admin was asked to step in
+	EscalationReason string         `gorm:"type:text" json:"escalation_reason,omitempty"`
+	ClosedAt         *time.Time     `json:"closed_at,omitempty"`
+	Items            []ClaimItem    `gorm:"foreignKey:ClaimID" json:"items,omitempty"`
+	Photos           []ClaimPhoto   `gorm:"foreignKey:ClaimID" json:"photos,omitempty"`
+	Messages         []ClaimMessage `gorm:"foreignKey:ClaimID" json:"messages,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+func (c *Claim) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// Unresolved reports whether the claim still waits for a resolution.
+func (c *Claim) Unresolved() bool {
+	return c.Status == ClaimStatusOpen || c.Status == ClaimStatusUnderReview
+}
+
+// ClaimItem is one piece the claim is about, optionally linked to a tagged
+// garment of the order.
+type ClaimItem struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ClaimID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"claim_id"`
+	GarmentItemID *uuid.UUID `gorm:"type:uuid" json:"garment_item_id,omitempty"`
+	Description   string     `gorm:"type:text;not null" json:"description"`
+	ClaimedAmount Money      `gorm:"type:decimal(12,2);not null;default:0" json:"claimed_amount"`
+}
+
+func (i *ClaimItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// ClaimPhoto is evidence uploaded by the customer. The file lives in the
+// blob store under StorageKey.
+type ClaimPhoto struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ClaimID     uuid.UUID `gorm:"type:uuid;not null;index" json:"claim_id"`
+	StorageKey  string    `gorm:"type:varchar(255);not null" json:"-"`
+	ContentType string    `gorm:"type:varchar(50);not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	UploadedBy  uuid.UUID `gorm:"type:uuid;not null" json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (p *ClaimPhoto) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// ClaimMessage is one entry in the conversation on a claim, including the
+// system notes written on status changes.
+type ClaimMessage struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ClaimID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"claim_id"`
+	AuthorID   *uuid.UUID `gorm:"type:uuid" json:"author_id,omitempty"` // nil for system notes
+	AuthorRole string     `gorm:"type:varchar(20);not null" json:"author_role"`
+	Body       string     `gorm:"type:text;not null" json:"body"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (m *ClaimMessage) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CourierLocation is a courier's latest reported position.
+type CourierLocation struct {
+	CourierID  uuid.UUID `gorm:"type:uuid;primary_key" json:"courier_id"`
+	Latitude   float64   `gorm:"type:decimal(10,8);not null" json:"latitude"`
+	Longitude  float64   `gorm:"type:decimal(11,8);not null" json:"longitude"`
+	Accuracy   *float64  `gorm:"type:decimal(8,2)" json:"accuracy,omitempty"` // metres
+	Heading    *float64  `gorm:"type:decimal(5,2)" json:"heading,omitempty"`  // degrees from north
+	RecordedAt time.Time `gorm:"not null" json:"recorded_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CourierLocationPing is one point of a courier's recent trail. Old pings
+// are pruned by the worker.
+type CourierLocationPing struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CourierID  uuid.UUID `gorm:"type:uuid;not null;index:idx_courier_location_pings_courier_time" json:"courier_id"`
+	Latitude   float64   `gorm:"type:decimal(10,8);not null" json:"latitude"`
+	Longitude  float64   `gorm:"type:decimal(11,8);not null" json:"longitude"`
+	RecordedAt time.Time `gorm:"not null;index:idx_courier_location_pings_courier_time" json:"recorded_at"`
+}
+
+func (p *CourierLocationPing) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
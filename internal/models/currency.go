@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultCurrency is used for laundries and orders without an explicit currency.
+const DefaultCurrency = "IDR"
+
+// Currency describes how amounts in an ISO 4217 currency are displayed.
+type Currency struct {
+	Code     string
+	Symbol   string
+	Decimals int
+}
+
+var currencies = map[string]Currency{
+	"IDR": {Code: "IDR", Symbol: "Rp", Decimals: 0},
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2},
+	"SGD": {Code: "SGD", Symbol: "S$", Decimals: 2},
+	"MYR": {Code: "MYR", Symbol: "RM", Decimals: 2},
+	"EUR": {Code: "EUR", Symbol: "€", Decimals: 2},
+}
+
+// LookupCurrency returns the display settings for an ISO 4217 code.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// IsSupportedCurrency reports whether code is a currency the API can format.
+func IsSupportedCurrency(code string) bool {
+	_, ok := LookupCurrency(code)
+	return ok
+}
+
+// Format renders m in the given currency for a locale such as "id-ID" or "en-US".
+//
+// Indonesian locales use the local symbol with "." thousands and "," decimals
+// ("Rp 15.000"); every other locale uses the ISO code with "," thousands and
+// "." decimals ("IDR 15,000"). Currencies without minor units only show the
+// fraction when it is non-zero.
+func (m Money) Format(currencyCode, locale string) string {
+	cur, ok := LookupCurrency(currencyCode)
+	if !ok {
+		cur = Currency{Code: strings.ToUpper(currencyCode), Symbol: strings.ToUpper(currencyCode), Decimals: 2}
+	}
+
+	prefix, thousandsSep, decimalSep := cur.Code, ",", "."
+	if isIndonesianLocale(locale) {
+		prefix, thousandsSep, decimalSep = cur.Symbol, ".", ","
+	}
+
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	s := sign + prefix + " " + groupThousands(v/moneyScale, thousandsSep)
+	if frac := v % moneyScale; cur.Decimals > 0 || frac != 0 {
+		s += fmt.Sprintf("%s%02d", decimalSep, frac)
+	}
+	return s
+}
+
+func isIndonesianLocale(locale string) bool {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang == "id" || lang == "in"
+}
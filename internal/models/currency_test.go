@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		amount   Money
+		currency string
+		locale   string
+		want     string
+	}{
+		{amount: NewMoney(15000), currency: "IDR", locale: "id-ID", want: "Rp 15.000"},
+		{amount: 733350, currency: "IDR", locale: "id-ID", want: "Rp 7.333,50"},
+		{amount: NewMoney(15000), currency: "IDR", locale: "en-US", want: "IDR 15,000"},
+		{amount: NewMoney(1234567), currency: "idr", locale: "in", want: "Rp 1.234.567"},
+		{amount: 1999, currency: "USD", locale: "en-US", want: "USD 19.99"},
+		{amount: NewMoney(5), currency: "USD", locale: "en", want: "USD 5.00"},
+		{amount: NewMoney(5), currency: "SGD", locale: "id_ID", want: "S$ 5,00"},
+		{amount: -NewMoney(1500), currency: "IDR", locale: "id-ID", want: "-Rp 1.500"},
+		{amount: NewMoney(100), currency: "XYZ", locale: "en-US", want: "XYZ 100.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Format(tt.currency, tt.locale); got != tt.want {
+			t.Errorf("%s.Format(%q, %q) = %q, want %q", tt.amount, tt.currency, tt.locale, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Order legs a courier can be assigned to.
+const (
+	DeliveryLegPickup  = "pickup"  // customer to laundry
+	DeliveryLegDropoff = "dropoff" // laundry to customer
+)
+
+const (
+	DeliveryStatusAssigned  = "assigned"
+	DeliveryStatusEnRoute   = "en_route"
+	DeliveryStatusArrived   = "arrived"
+	DeliveryStatusDone      = "done"
+	DeliveryStatusCancelled = "cancelled" // the order was cancelled
+)
+
+// Delivery is one leg of an order assigned to a courier. An order has at
+// most one delivery per leg; reassigning changes its courier.
+type Delivery struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_deliveries_order_leg" json:"order_id"`
+	Order       Order      `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	LaundryID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	CourierID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"courier_id"`
+	Courier     User       `gorm:"foreignKey:CourierID" json:"courier,omitempty"`
+	Leg         string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_deliveries_order_leg" json:"leg"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'assigned'" json:"status"`
+	Notes       string     `gorm:"type:text" json:"notes,omitempty"`
+	AssignedAt  time.Time  `gorm:"not null" json:"assigned_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	ArrivedAt   *time.Time `json:"arrived_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (d *Delivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FavoriteLaundry is a laundry a customer saved for quick reordering.
+type FavoriteLaundry struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	LaundryID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"laundry_id"`
+	Laundry   Laundry   `gorm:"foreignKey:LaundryID" json:"laundry,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
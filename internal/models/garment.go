@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Processing stages a garment is scanned at.
+const (
+	GarmentStageReceived = "received"
+	GarmentStageWashing  = "washing"
+	GarmentStageDrying   = "drying"
+	GarmentStageIroning  = "ironing"
+	GarmentStageReady    = "ready"
+	GarmentStageReturned = "returned" // handed back to the customer
+)
+
+// Damage flags recorded when a garment is received.
+const (
+	GarmentDamageStain         = "stain"
+	GarmentDamageTear          = "tear"
+	GarmentDamageMissingButton = "missing_button"
+	GarmentDamageFaded         = "faded"
+	GarmentDamageShrunk        = "shrunk"
+	GarmentDamageOther         = "other"
+)
+
+// GarmentItem is one tagged piece of clothing in an order. TagCode is
+// printed on its QR label and scanned at every stage.
+type GarmentItem struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID        uuid.UUID     `gorm:"type:uuid;not null;index" json:"order_id"`
+	LaundryID      uuid.UUID     `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	OrderServiceID *uuid.UUID    `gorm:"type:uuid" json:"order_service_id,omitempty"` // the order line it is billed under
+	TagCode        string        `gorm:"type:varchar(20);not null;uniqueIndex" json:"tag_code"`
+	Type           string        `gorm:"type:varchar(50);not null" json:"type"`
+	Colour         string        `gorm:"type:varchar(50)" json:"colour,omitempty"`
+	Brand          string        `gorm:"type:varchar(100)" json:"brand,omitempty"`
+	Notes          string        `gorm:"type:text" json:"notes,omitempty"`
+	DamageFlags    string        `gorm:"type:text" json:"damage_flags"` // comma-separated
+	Stage          string        `gorm:"type:varchar(20);not null;default:'received'" json:"stage"`
+	Scans          []GarmentScan `gorm:"foreignKey:ItemID" json:"scans,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func (g *GarmentItem) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// GarmentScan records a garment reaching a stage.
+type GarmentScan struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ItemID    uuid.UUID `gorm:"type:uuid;not null;index" json:"item_id"`
+	Stage     string    `gorm:"type:varchar(20);not null" json:"stage"`
+	Note      string    `gorm:"type:text" json:"note,omitempty"`
+	ScannedBy uuid.UUID `gorm:"type:uuid;not null" json:"scanned_by"`
+	ScannedAt time.Time `gorm:"not null" json:"scanned_at"`
+}
+
+func (g *GarmentScan) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	JobStatusPending = "pending"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusDead    = "dead" // gave up after MaxAttempts; kept for inspection and retry
+)
+
+// Job types handled by the worker.
+const (
+	JobPublishEvent    = "event.publish"
+	JobWebhookDispatch = "webhook.dispatch" // fans an event out to the laundry's webhooks
+	JobWebhookDeliver  = "webhook.deliver"  // sends one webhook delivery
+)
+
+// Job is a unit of background work in the Postgres-backed queue.
+type Job struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Type        string     `gorm:"type:varchar(50);not null;index" json:"type"`
+	Payload     string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts    int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int        `gorm:"not null;default:8" json:"max_attempts"`
+	RunAt       time.Time  `gorm:"not null" json:"run_at"`
+	LockedAt    *time.Time `json:"locked_at,omitempty"`
+	LockedBy    string     `gorm:"type:varchar(100)" json:"locked_by,omitempty"`
+	LastError   string     `gorm:"type:text" json:"last_error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (j *Job) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return nil
+}
+
+// OutboxEvent is a domain event recorded in the same transaction as the
+// change it describes. The worker relays undispatched events to their
+// consumers as jobs.
+type OutboxEvent struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	Type         string     `gorm:"type:varchar(50);not null" json:"type"`
+	AggregateID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"aggregate_id"`
+	Payload      string     `gorm:"type:jsonb;not null" json:"payload"`
+	DispatchedAt *time.Time `gorm:"index" json:"dispatched_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kinds of laundry image. A laundry has at most one logo and one cover.
+const (
+	LaundryImageLogo    = "logo"
+	LaundryImageCover   = "cover"
+	LaundryImageGallery = "gallery"
+)
+
+// LaundryImage is an uploaded laundry image. The original and its resized
+// JPEG variants live in the blob store.
+type LaundryImage struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	LaundryID   uuid.UUID `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	Kind        string    `gorm:"type:varchar(20);not null" json:"kind"`
+	Position    int       `gorm:"not null;default:0" json:"position"` // order within the gallery
+	ContentType string    `gorm:"type:varchar(50);not null" json:"content_type"`
+	Width       int       `gorm:"not null" json:"width"`
+	Height      int       `gorm:"not null" json:"height"`
+	Size        int64     `gorm:"not null" json:"size"`
+	OriginalKey string    `gorm:"type:varchar(255);not null" json:"-"`
+	MediumKey   string    `gorm:"type:varchar(255);not null" json:"-"`
+	ThumbKey    string    `gorm:"type:varchar(255);not null" json:"-"`
+	UploadedBy  uuid.UUID `gorm:"type:uuid;not null" json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (i *LaundryImage) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
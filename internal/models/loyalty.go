@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	LoyaltyEntryEarn   = "earn"
+	LoyaltyEntryRedeem = "redeem"
+	LoyaltyEntryRefund = "refund"
+	// LoyaltyEntryReversal takes back points earned on an order that was
+	// later refunded
+	LoyaltyEntryReversal = "reversal"
+)
+
+// LoyaltyLedgerEntry is one movement of a user's loyalty points. Credit
+// entries (earn, refund) track how many of their points are still unspent in
+// RemainingPoints; redemptions and reversals consume credits oldest-expiry
+// first.
+type LoyaltyLedgerEntry struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	OrderID         *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_loyalty_order_type" json:"order_id,omitempty"`
+	LaundryID       *uuid.UUID `gorm:"type:uuid" json:"laundry_id,omitempty"`
+	Type            string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_loyalty_order_type" json:"type"`
+	Points          int64      `gorm:"not null" json:"points"`
+	RemainingPoints int64      `gorm:"not null;default:0" json:"remaining_points"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Description     string     `gorm:"type:text" json:"description"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (e *LoyaltyLedgerEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
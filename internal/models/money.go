@@ -0,0 +1,206 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in minor currency units (1/100, e.g. sen for Rupiah).
+// It maps to DECIMAL(12,2) columns and is encoded in JSON as a decimal number.
+//
+// Rounding rules:
+//   - Parsing a value with more than 2 decimals rounds half away from zero.
+//   - A line amount (unit price x quantity) is computed exactly and rounded
+//     once, half away from zero, to the nearest minor unit (see MulQuantity).
+//   - Totals are the sum of already rounded line amounts and are never
+//     rounded again.
+type Money int64
+
+const moneyScale = 100
+
+// NewMoney returns a Money value for a whole amount in major units (e.g. Rp 15000).
+func NewMoney(major int64) Money {
+	return Money(major * moneyScale)
+}
+
+// ParseMoney parses a decimal string such as "15000", "7333.5" or "-12.345".
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty money value")
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid money value %q", s)
+	}
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid money value %q", s)
+		}
+	}
+
+	var major int64
+	if intPart != "" {
+		v, err := strconv.ParseInt(intPart, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid money value %q", s)
+		}
+		major = v
+	}
+
+	// Keep two decimals and round half away from zero on the third one.
+	var minor int64
+	for i := 0; i < 2; i++ {
+		minor *= 10
+		if i < len(fracPart) {
+			minor += int64(fracPart[i] - '0')
+		}
+	}
+	if len(fracPart) > 2 && fracPart[2] >= '5' {
+		minor++
+	}
+
+	if major > (math.MaxInt64-minor)/moneyScale {
+		return 0, fmt.Errorf("money value %q out of range", s)
+	}
+
+	m := Money(major*moneyScale + minor)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+// MulQuantity returns the price of qty units. The quantity is normalised to
+// 2 decimals (the precision of order_services.quantity), the product is
+// computed exactly and rounded half away from zero to the nearest minor unit.
+func (m Money) MulQuantity(qty float64) Money {
+	hundredths := int64(math.Round(qty * 100))
+	return Money(divRound(int64(m)*hundredths, 100))
+}
+
+// Percent returns pct percent of m, rounded half away from zero to the
+// nearest minor unit. pct is normalised to 2 decimals (basis points).
+func (m Money) Percent(pct float64) Money {
+	bps := int64(math.Round(pct * 100))
+	return Money(divRound(int64(m)*bps, 10000))
+}
+
+// Major returns the whole major-unit part of m, truncated toward zero.
+func (m Money) Major() int64 {
+	return int64(m) / moneyScale
+}
+
+// String returns m as a plain decimal string with two decimals, e.g. "15000.50".
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/moneyScale, v%moneyScale)
+}
+
+// Rupiah formats m as Indonesian Rupiah, e.g. "Rp 15.000" or "Rp 7.333,50".
+func (m Money) Rupiah() string {
+	return m.Format(DefaultCurrency, "id-ID")
+}
+
+func (m *Money) Scan(value interface{}) error {
+	if value == nil {
+		*m = 0
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		parsed, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case string:
+		parsed, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case int64:
+		*m = NewMoney(v)
+	case float64:
+		parsed, err := ParseMoney(strconv.FormatFloat(v, 'f', -1, 64))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*m = 0
+		return nil
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// divRound divides a by b (b > 0), rounding half away from zero.
+func divRound(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
+}
+
+func groupThousands(v int64, sep string) string {
+	digits := strconv.FormatInt(v, 10)
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
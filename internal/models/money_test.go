@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: "15000", want: 1500000},
+		{in: "7333.5", want: 733350},
+		{in: "7333.50", want: 733350},
+		{in: "+1.2", want: 120},
+		{in: ".5", want: 50},
+		{in: " 42 ", want: 4200},
+		{in: "0.004", want: 0},
+		{in: "0.005", want: 1},
+		{in: "0.995", want: 100},
+		{in: "-12.345", want: -1235},
+		{in: "-12.344", want: -1234},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) = %d, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyMulQuantity(t *testing.T) {
+	tests := []struct {
+		price Money
+		qty   float64
+		want  Money
+	}{
+		{price: NewMoney(7000), qty: 2.5, want: NewMoney(17500)},
+		{price: 733350, qty: 1.5, want: 1100025},
+		{price: NewMoney(7333), qty: 0.33, want: 241989},
+		// 0.05 x 0.5 = 0.025 rounds half away from zero
+		{price: 5, qty: 0.5, want: 3},
+		{price: -5, qty: 0.5, want: -3},
+		// the quantity is normalised to 2 decimals first
+		{price: NewMoney(1000), qty: 1.004, want: NewMoney(1000)},
+		{price: NewMoney(1000), qty: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.price.MulQuantity(tt.qty); got != tt.want {
+			t.Errorf("%s.MulQuantity(%v) = %s, want %s", tt.price, tt.qty, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	tests := []struct {
+		amount Money
+		pct    float64
+		want   Money
+	}{
+		{amount: NewMoney(10000), pct: 12.5, want: NewMoney(1250)},
+		{amount: NewMoney(10000), pct: 100, want: NewMoney(10000)},
+		{amount: 333, pct: 50, want: 167},
+		{amount: -333, pct: 50, want: -167},
+		{amount: 333, pct: 0.001, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Percent(tt.pct); got != tt.want {
+			t.Errorf("%s.Percent(%v) = %s, want %s", tt.amount, tt.pct, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 1500050, want: "15000.50"},
+		{in: -5, want: "-0.05"},
+		{in: NewMoney(-7), want: "-7.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	for _, in := range []string{`12.34`, `"12.34"`, `12.335`} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err != nil {
+			t.Fatalf("Unmarshal(%s) returned error: %v", in, err)
+		}
+		if m != 1234 {
+			t.Errorf("Unmarshal(%s) = %d, want 1234", in, m)
+		}
+	}
+
+	var m Money = 99
+	if err := json.Unmarshal([]byte(`null`), &m); err != nil || m != 0 {
+		t.Errorf("Unmarshal(null) = %d, %v, want 0, nil", m, err)
+	}
+
+	out, err := json.Marshal(struct {
+		Total Money `json:"total"`
+	}{Total: 1500050})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"total":15000.50}` {
+		t.Errorf("Marshal = %s", out)
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationChannelEmail    = "email"
+	NotificationChannelSMS      = "sms"
+	NotificationChannelWhatsApp = "whatsapp"
+	NotificationChannelPush     = "push"
+)
+
+const (
+	NotificationStatusPending = "pending"
+	NotificationStatusSending = "sending" // claimed by a dispatcher until next_attempt_at
+	NotificationStatusSent    = "sent"
+	NotificationStatusFailed  = "failed"
+)
+
+// NotificationPreference records which channels a user wants to be notified
+// on. Users without a row get DefaultNotificationPreference.
+type NotificationPreference struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	Email     bool      `gorm:"not null" json:"email"`
+	SMS       bool      `gorm:"not null" json:"sms"`
+	WhatsApp  bool      `gorm:"column:whatsapp;not null" json:"whatsapp"`
+	Push      bool      `gorm:"not null" json:"push"`
+	PushToken string    `gorm:"type:varchar(255)" json:"push_token,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func DefaultNotificationPreference(userID uuid.UUID) *NotificationPreference {
+	return &NotificationPreference{
+		UserID: userID,
+		Email:  true,
+		Push:   true,
+	}
+}
+
+// Notification is one message to one recipient on one channel. Rows are the
+// outbox: they are written with the change that caused them and delivered
+// by the dispatcher, so nothing is lost if the server restarts.
+type Notification struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	OrderID       *uuid.UUID `gorm:"type:uuid;index" json:"order_id,omitempty"`
+	Event         string     `gorm:"type:varchar(50);not null" json:"event"`
+	Channel       string     `gorm:"type:varchar(20);not null" json:"channel"`
+	Recipient     string     `gorm:"type:varchar(255);not null" json:"recipient"`
+	Subject       string     `gorm:"type:varchar(255)" json:"subject"`
+	Body          string     `gorm:"type:text;not null" json:"body"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
@@ -8,22 +8,36 @@ import (
 )
 
 type Order struct {
-	ID                 uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	UserID             uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
-	User               User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	LaundryID          uuid.UUID      `gorm:"type:uuid;not null;index" json:"laundry_id"`
-	Laundry            Laundry        `gorm:"foreignKey:LaundryID" json:"laundry,omitempty"`
-	Status             string         `gorm:"type:varchar(50);not null;default:'pending';index" json:"status"`
-	TotalPrice         float64        `gorm:"type:decimal(12,2);not null" json:"total_price"`
-	DeliveryAddress    string         `gorm:"type:text;not null" json:"delivery_address"`
-	Notes              string         `gorm:"type:text" json:"notes"`
-	EstimatedPickupAt *time.Time     `json:"estimated_pickup_at,omitempty"`
-	EstimatedDeliveryAt *time.Time    `json:"estimated_delivery_at,omitempty"`
-	ActualPickupAt     *time.Time     `json:"actual_pickup_at,omitempty"`
-	ActualDeliveryAt   *time.Time     `json:"actual_delivery_at,omitempty"`
-	OrderServices      []OrderService `gorm:"foreignKey:OrderID" json:"order_services,omitempty"`
-	CreatedAt          time.Time      `json:"created_at"`
-	UpdatedAt          time.Time      `json:"updated_at"`
+	ID                  uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID              uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	User                User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	LaundryID           uuid.UUID      `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	Laundry             Laundry        `gorm:"foreignKey:LaundryID" json:"laundry,omitempty"`
+	Status              string         `gorm:"type:varchar(50);not null;default:'pending';index" json:"status"`
+	Subtotal            Money          `gorm:"type:decimal(12,2);not null;default:0" json:"subtotal"`
+	DiscountAmount      Money          `gorm:"type:decimal(12,2);not null;default:0" json:"discount_amount"`
+	PromoCode           string         `gorm:"type:varchar(50)" json:"promo_code,omitempty"`
+	PointsRedeemed      int64          `gorm:"not null;default:0" json:"points_redeemed"`
+	PointsDiscount      Money          `gorm:"type:decimal(12,2);not null;default:0" json:"points_discount"`
+	SubscriptionID      *uuid.UUID     `gorm:"type:uuid;index" json:"subscription_id,omitempty"`
+	TotalPrice          Money          `gorm:"type:decimal(12,2);not null" json:"total_price"`
+	Currency            string         `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	PaymentMethod       string         `gorm:"type:varchar(20);not null;default:'cash'" json:"payment_method"`
+	PaymentStatus       string         `gorm:"type:varchar(20);not null;default:'unpaid'" json:"payment_status"`
+	PaidAt              *time.Time     `json:"paid_at,omitempty"`
+	DeliveryAddress     string         `gorm:"type:text;not null" json:"delivery_address"`
+	DeliveryLatitude    *float64       `gorm:"type:decimal(10,8)" json:"delivery_latitude,omitempty"`
+	DeliveryLongitude   *float64       `gorm:"type:decimal(11,8)" json:"delivery_longitude,omitempty"`
+	Notes               string         `gorm:"type:text" json:"notes"`
+	EstimatedPickupAt   *time.Time     `json:"estimated_pickup_at,omitempty"`
+	EstimatedDeliveryAt *time.Time     `json:"estimated_delivery_at,omitempty"`
+	ActualPickupAt      *time.Time     `json:"actual_pickup_at,omitempty"`
+	ActualDeliveryAt    *time.Time     `json:"actual_delivery_at,omitempty"`
+	ConfirmBy           *time.Time     `gorm:"index" json:"confirm_by,omitempty"`                   // pending orders are cancelled after this
+	RecurringOrderID    *uuid.UUID     `gorm:"type:uuid;index" json:"recurring_order_id,omitempty"` // the template that placed the order
+	OrderServices       []OrderService `gorm:"foreignKey:OrderID" json:"order_services,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
 }
 
 func (o *Order) BeforeCreate(tx *gorm.DB) error {
@@ -34,17 +48,18 @@ func (o *Order) BeforeCreate(tx *gorm.DB) error {
 }
 
 type OrderService struct {
-	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	OrderID     uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
-	Order       Order     `gorm:"foreignKey:OrderID" json:"order,omitempty"`
-	ServiceID   uuid.UUID `gorm:"type:uuid;not null" json:"service_id"`
-	Service     Service   `gorm:"foreignKey:ServiceID" json:"service,omitempty"`
-	ServiceName string    `gorm:"type:varchar(255);not null" json:"service_name"`
-	Quantity    float64   `gorm:"type:decimal(10,2);not null" json:"quantity"`
-	UnitPrice   float64   `gorm:"type:decimal(12,2);not null" json:"unit_price"`
-	Unit        string    `gorm:"type:varchar(20);not null" json:"unit"`
-	Subtotal    float64   `gorm:"type:decimal(12,2);not null" json:"subtotal"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID       uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	Order         Order     `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	ServiceID     uuid.UUID `gorm:"type:uuid;not null" json:"service_id"`
+	Service       Service   `gorm:"foreignKey:ServiceID" json:"service,omitempty"`
+	ServiceName   string    `gorm:"type:varchar(255);not null" json:"service_name"`
+	Quantity      float64   `gorm:"type:decimal(10,2);not null" json:"quantity"`
+	QuotaQuantity float64   `gorm:"type:decimal(10,2);not null;default:0" json:"quota_quantity"` // covered by a subscription
+	UnitPrice     Money     `gorm:"type:decimal(12,2);not null" json:"unit_price"`
+	Unit          string    `gorm:"type:varchar(20);not null" json:"unit"`
+	Subtotal      Money     `gorm:"type:decimal(12,2);not null" json:"subtotal"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 func (os *OrderService) BeforeCreate(tx *gorm.DB) error {
@@ -54,3 +69,25 @@ func (os *OrderService) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// OrderStatusHistory records every status transition of an order. ChangedBy
+// is nil for changes made by the system, such as automatic expiry.
+type OrderStatusHistory struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"order_id"`
+	FromStatus string     `gorm:"type:varchar(50)" json:"from_status"`
+	ToStatus   string     `gorm:"type:varchar(50);not null" json:"to_status"`
+	Reason     string     `gorm:"type:text" json:"reason,omitempty"`
+	ChangedBy  *uuid.UUID `gorm:"type:uuid" json:"changed_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
+
+func (h *OrderStatusHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Order adjustment statuses. Processing cannot continue past picked-up
+// while the latest adjustment is pending or rejected.
+const (
+	OrderAdjustmentPending      = "pending"
+	OrderAdjustmentAccepted     = "accepted"
+	OrderAdjustmentAutoAccepted = "auto_accepted" // within the weight tolerance
+	OrderAdjustmentRejected     = "rejected"
+	OrderAdjustmentSuperseded   = "superseded" // replaced by a newer adjustment before it was answered
+)
+
+// How the price difference of an applied adjustment was settled.
+const (
+	AdjustmentSettlementNone           = "none" // the order was not paid yet
+	AdjustmentSettlementWalletCharged  = "wallet_charged"
+	AdjustmentSettlementWalletRefunded = "wallet_refunded"
+	AdjustmentSettlementCollectCash    = "collect_cash" // the customer owes the laundry
+	AdjustmentSettlementRefundCash     = "refund_cash"  // the laundry owes the customer
+)
+
+// OrderAdjustment is a change to an order's measured quantities after
+// pickup. It keeps the totals before and after the change and is applied to
+// the order only once accepted.
+type OrderAdjustment struct {
+	ID              uuid.UUID             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID         uuid.UUID             `gorm:"type:uuid;not null;index" json:"order_id"`
+	Status          string                `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Note            string                `gorm:"type:text" json:"note,omitempty"`
+	SubtotalBefore  Money                 `gorm:"type:decimal(12,2);not null" json:"subtotal_before"`
+	SubtotalAfter   Money                 `gorm:"type:decimal(12,2);not null" json:"subtotal_after"`
+	DiscountBefore  Money                 `gorm:"type:decimal(12,2);not null;default:0" json:"discount_before"`
+	DiscountAfter   Money                 `gorm:"type:decimal(12,2);not null;default:0" json:"discount_after"`
+	TotalBefore     Money                 `gorm:"type:decimal(12,2);not null" json:"total_before"`
+	TotalAfter      Money                 `gorm:"type:decimal(12,2);not null" json:"total_after"`
+	Settlement      string                `gorm:"type:varchar(20)" json:"settlement,omitempty"`
+	RequestedBy     uuid.UUID             `gorm:"type:uuid;not null" json:"requested_by"`
+	ResolvedBy      *uuid.UUID            `gorm:"type:uuid" json:"resolved_by,omitempty"` // nil when auto-accepted
+	ResolvedAt      *time.Time            `json:"resolved_at,omitempty"`
+	RejectionReason string                `gorm:"type:text" json:"rejection_reason,omitempty"`
+	Lines           []OrderAdjustmentLine `gorm:"foreignKey:AdjustmentID" json:"lines,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+func (a *OrderAdjustment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// Difference is what the customer pays extra, or gets back when negative.
+func (a *OrderAdjustment) Difference() Money {
+	return a.TotalAfter - a.TotalBefore
+}
+
+// OrderAdjustmentLine is the change to one order line.
+type OrderAdjustmentLine struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AdjustmentID   uuid.UUID `gorm:"type:uuid;not null;index" json:"adjustment_id"`
+	OrderServiceID uuid.UUID `gorm:"type:uuid;not null" json:"order_service_id"`
+	ServiceName    string    `gorm:"type:varchar(255);not null" json:"service_name"`
+	Unit           string    `gorm:"type:varchar(20);not null" json:"unit"`
+	QuantityBefore float64   `gorm:"type:decimal(10,2);not null" json:"quantity_before"`
+	QuantityAfter  float64   `gorm:"type:decimal(10,2);not null" json:"quantity_after"`
+	QuotaBefore    float64   `gorm:"type:decimal(10,2);not null;default:0" json:"quota_before"`
+	QuotaAfter     float64   `gorm:"type:decimal(10,2);not null;default:0" json:"quota_after"`
+	SubtotalBefore Money     `gorm:"type:decimal(12,2);not null" json:"subtotal_before"`
+	SubtotalAfter  Money     `gorm:"type:decimal(12,2);not null" json:"subtotal_after"`
+}
+
+func (l *OrderAdjustmentLine) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderMessage is one message in an order's chat between the customer, the
+// laundry's owner and the couriers moving the order. An attachment, when
+// present, lives in the blob store under AttachmentKey.
+type OrderMessage struct {
+	ID                    uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID               uuid.UUID `gorm:"type:uuid;not null;index:idx_order_messages_order_created,priority:1" json:"order_id"`
+	SenderID              uuid.UUID `gorm:"type:uuid;not null" json:"sender_id"`
+	Sender                User      `gorm:"foreignKey:SenderID" json:"sender,omitempty"`
+	SenderRole            string    `gorm:"type:varchar(20);not null" json:"sender_role"`
+	Body                  string    `gorm:"type:text" json:"body,omitempty"`
+	AttachmentKey         string    `gorm:"type:varchar(255)" json:"-"`
+	AttachmentContentType string    `gorm:"type:varchar(50)" json:"attachment_content_type,omitempty"`
+	AttachmentSize        int64     `json:"attachment_size,omitempty"`
+	CreatedAt             time.Time `gorm:"index:idx_order_messages_order_created,priority:2" json:"created_at"`
+}
+
+func (m *OrderMessage) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// HasAttachment reports whether the message carries a file.
+func (m *OrderMessage) HasAttachment() bool {
+	return m.AttachmentKey != ""
+}
+
+// OrderChatRead is a participant's read receipt: every message in the
+// order's chat up to LastReadAt has been seen.
+type OrderChatRead struct {
+	OrderID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"order_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	LastReadAt time.Time `gorm:"not null" json:"last_read_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kinds of proof that can be attached to an order leg.
+const (
+	ProofKindPhoto     = "photo"
+	ProofKindSignature = "signature" // at most one per leg
+)
+
+// OrderProof is a photo or signature taken at pickup or drop-off. The file
+// lives in the blob store under StorageKey; rows are never updated.
+type OrderProof struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OrderID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"order_id"`
+	DeliveryID  *uuid.UUID `gorm:"type:uuid" json:"delivery_id,omitempty"`
+	Leg         string     `gorm:"type:varchar(20);not null" json:"leg"`
+	Kind        string     `gorm:"type:varchar(20);not null" json:"kind"`
+	StorageKey  string     `gorm:"type:varchar(255);not null" json:"-"`
+	ContentType string     `gorm:"type:varchar(50);not null" json:"content_type"`
+	Size        int64      `gorm:"not null" json:"size"`
+	UploadedBy  uuid.UUID  `gorm:"type:uuid;not null" json:"uploaded_by"`
+	Uploader    User       `gorm:"foreignKey:UploadedBy" json:"uploader,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (p *OrderProof) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
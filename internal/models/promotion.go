@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+)
+
+// Promotion is a promo code. A nil LaundryID makes it platform-wide.
+type Promotion struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Code           string     `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"`
+	Description    string     `gorm:"type:text" json:"description"`
+	LaundryID      *uuid.UUID `gorm:"type:uuid;index" json:"laundry_id,omitempty"`
+	DiscountType   string     `gorm:"type:varchar(20);not null" json:"discount_type"`
+	PercentOff     float64    `gorm:"type:decimal(5,2);default:0" json:"percent_off"`
+	AmountOff      Money      `gorm:"type:decimal(12,2);default:0" json:"amount_off"`
+	MaxDiscount    Money      `gorm:"type:decimal(12,2);default:0" json:"max_discount"`
+	MinOrderValue  Money      `gorm:"type:decimal(12,2);default:0" json:"min_order_value"`
+	Currency       string     `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	UsageLimit     *int       `json:"usage_limit,omitempty"`
+	PerUserLimit   *int       `json:"per_user_limit,omitempty"`
+	FirstOrderOnly bool       `gorm:"default:false" json:"first_order_only"`
+	UsedCount      int        `gorm:"default:0" json:"used_count"`
+	IsActive       bool       `gorm:"default:true" json:"is_active"`
+	CreatedBy      uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (p *Promotion) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// PromotionRedemption records one use of a promotion by an order.
+type PromotionRedemption struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PromotionID    uuid.UUID `gorm:"type:uuid;not null;index" json:"promotion_id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	OrderID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"order_id"`
+	DiscountAmount Money     `gorm:"type:decimal(12,2);not null" json:"discount_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (r *PromotionRedemption) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
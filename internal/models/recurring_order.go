@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Recurring order statuses. Only active templates place orders.
+const (
+	RecurringOrderActive    = "active"
+	RecurringOrderPaused    = "paused"
+	RecurringOrderCancelled = "cancelled"
+	RecurringOrderEnded     = "ended" // no pickups left before the end date
+)
+
+// How often a recurring order repeats.
+const (
+	RecurringFrequencyWeekly   = "weekly"
+	RecurringFrequencyBiweekly = "biweekly"
+)
+
+// RecurringOrder is a customer's template for an order placed again on the
+// same weekday and time, ahead of every pickup until EndDate.
+type RecurringOrder struct {
+	ID                uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID            uuid.UUID            `gorm:"type:uuid;not null;index" json:"user_id"`
+	LaundryID         uuid.UUID            `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	Laundry           Laundry              `gorm:"foreignKey:LaundryID" json:"laundry,omitempty"`
+	Status            string               `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	Frequency         string               `gorm:"type:varchar(20);not null" json:"frequency"`
+	Weekday           int                  `gorm:"not null" json:"weekday"`                     // 0 is Sunday
+	PickupTime        string               `gorm:"type:varchar(5);not null" json:"pickup_time"` // HH:MM
+	EndDate           *time.Time           `gorm:"type:date" json:"end_date,omitempty"`         // last day a pickup may fall on
+	DeliveryAddress   string               `gorm:"type:text;not null" json:"delivery_address"`
+	DeliveryLatitude  *float64             `gorm:"type:decimal(10,8)" json:"delivery_latitude,omitempty"`
+	DeliveryLongitude *float64             `gorm:"type:decimal(11,8)" json:"delivery_longitude,omitempty"`
+	Notes             string               `gorm:"type:text" json:"notes"`
+	PaymentMethod     string               `gorm:"type:varchar(20);not null;default:'cash'" json:"payment_method"`
+	NextPickupAt      *time.Time           `gorm:"index" json:"next_pickup_at,omitempty"` // nil once cancelled or ended
+	LastOrderID       *uuid.UUID           `gorm:"type:uuid" json:"last_order_id,omitempty"`
+	LastFailure       string               `gorm:"type:text" json:"last_failure,omitempty"`
+	LastFailedAt      *time.Time           `json:"last_failed_at,omitempty"`
+	Lines             []RecurringOrderLine `gorm:"foreignKey:RecurringOrderID" json:"lines,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+}
+
+func (r *RecurringOrder) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// RecurringOrderLine is one service ordered on every occurrence.
+type RecurringOrderLine struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RecurringOrderID uuid.UUID `gorm:"type:uuid;not null;index" json:"recurring_order_id"`
+	ServiceID        uuid.UUID `gorm:"type:uuid;not null" json:"service_id"`
+	Service          Service   `gorm:"foreignKey:ServiceID" json:"service,omitempty"`
+	Quantity         float64   `gorm:"type:decimal(10,2);not null" json:"quantity"`
+}
+
+func (l *RecurringOrderLine) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
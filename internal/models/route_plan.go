@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoutePlan is a laundry's planned courier runs for a time window. Its
+// stops are ordered per courier; stops no courier could take are kept with
+// no courier and the reason in Note.
+type RoutePlan struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	LaundryID   uuid.UUID       `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	CreatedBy   uuid.UUID       `gorm:"type:uuid;not null" json:"created_by"`
+	WindowStart time.Time       `gorm:"not null" json:"window_start"`
+	WindowEnd   time.Time       `gorm:"not null;index" json:"window_end"`
+	Capacity    int             `gorm:"not null" json:"capacity"` // max stops per courier
+	Stops       []RoutePlanStop `gorm:"foreignKey:PlanID" json:"stops,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func (p *RoutePlan) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// RoutePlanStop is one order leg in a route plan.
+type RoutePlanStop struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlanID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"plan_id"`
+	CourierID      *uuid.UUID `gorm:"type:uuid;index" json:"courier_id,omitempty"`
+	Courier        *User      `gorm:"foreignKey:CourierID" json:"courier,omitempty"`
+	Sequence       int        `gorm:"not null;default:0" json:"sequence"` // 1-based within the courier's run
+	OrderID        uuid.UUID  `gorm:"type:uuid;not null" json:"order_id"`
+	Order          Order      `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	DeliveryID     *uuid.UUID `gorm:"type:uuid" json:"delivery_id,omitempty"`
+	Leg            string     `gorm:"type:varchar(20);not null" json:"leg"`
+	Latitude       *float64   `gorm:"type:decimal(10,8)" json:"latitude,omitempty"`
+	Longitude      *float64   `gorm:"type:decimal(11,8)" json:"longitude,omitempty"`
+	WindowStart    time.Time  `gorm:"not null" json:"window_start"`
+	WindowEnd      time.Time  `gorm:"not null" json:"window_end"`
+	PlannedArrival *time.Time `json:"planned_arrival,omitempty"`
+	DistanceKm     float64    `gorm:"type:decimal(8,2);not null;default:0" json:"distance_km"` // from the previous stop
+	Note           string     `gorm:"type:varchar(255)" json:"note,omitempty"`
+}
+
+func (s *RoutePlanStop) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
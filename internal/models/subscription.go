@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	SubscriptionStatusActive  = "active"
+	SubscriptionStatusExpired = "expired"
+)
+
+// SubscriptionPackage is a monthly (or other period) package a laundry
+// sells, e.g. "30 kg per month".
+type SubscriptionPackage struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	LaundryID   uuid.UUID `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	Laundry     Laundry   `gorm:"foreignKey:LaundryID" json:"laundry,omitempty"`
+	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	QuotaKg     float64   `gorm:"type:decimal(10,2);not null" json:"quota_kg"`
+	Price       Money     `gorm:"type:decimal(12,2);not null" json:"price"`
+	Currency    string    `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	PeriodDays  int       `gorm:"not null;default:30" json:"period_days"`
+	IsActive    bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *SubscriptionPackage) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// Subscription is a customer's membership of a package. Each renewal starts a
+// new billing period on the same row with a fresh quota; unused kg do not roll
+// over.
+type Subscription struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID           `gorm:"type:uuid;not null;index" json:"user_id"`
+	PackageID      uuid.UUID           `gorm:"type:uuid;not null;index" json:"package_id"`
+	Package        SubscriptionPackage `gorm:"foreignKey:PackageID" json:"package,omitempty"`
+	LaundryID      uuid.UUID           `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	Status         string              `gorm:"type:varchar(20);not null;default:'active';index" json:"status"`
+	PeriodStart    time.Time           `gorm:"not null" json:"period_start"`
+	PeriodEnd      time.Time           `gorm:"not null" json:"period_end"`
+	QuotaTotal     float64             `gorm:"type:decimal(10,2);not null" json:"quota_total"`
+	QuotaRemaining float64             `gorm:"type:decimal(10,2);not null" json:"quota_remaining"`
+	AutoRenew      bool                `gorm:"default:true" json:"auto_renew"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+func (s *Subscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// SubscriptionUsage records quota drawn down by one order line.
+type SubscriptionUsage struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index" json:"subscription_id"`
+	OrderID        uuid.UUID `gorm:"type:uuid;not null;index" json:"order_id"`
+	OrderServiceID uuid.UUID `gorm:"type:uuid;not null" json:"order_service_id"`
+	Quantity       float64   `gorm:"type:decimal(10,2);not null" json:"quantity"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (u *SubscriptionUsage) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
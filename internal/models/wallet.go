@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	WalletAccountCustomer   = "customer"
+	WalletAccountFunding    = "system_funding"    // counterpart of top-ups from outside the platform
+	WalletAccountRevenue    = "system_revenue"    // receives order payments, funds refunds
+	WalletAccountAdjustment = "system_adjustment" // counterpart of manual corrections
+
+	WalletTxTopUp           = "topup"
+	WalletTxOrderPayment    = "order_payment"
+	WalletTxRefund          = "refund"
+	WalletTxAdjustment      = "adjustment"
+	WalletTxSubscription    = "subscription"
+	WalletTxOrderAdjustment = "order_adjustment" // price difference after an order's weight is adjusted
+	WalletTxClaimRefund     = "claim_refund"
+)
+
+var walletAccountNamespace = uuid.MustParse("6f1c3a52-7d1e-4f7b-9a43-0c5f0b8f2e11")
+
+// WalletAccount is one side of the double-entry wallet ledger. Customer
+// accounts belong to a user and may never go negative; system accounts have
+// no user and act as the counterparty for money entering or leaving wallets.
+type WalletAccount struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	Kind      string     `gorm:"type:varchar(30);not null" json:"kind"`
+	Currency  string     `gorm:"type:varchar(3);not null" json:"currency"`
+	Balance   Money      `gorm:"type:decimal(12,2);not null;default:0;check:chk_wallet_customer_balance,kind <> 'customer' OR balance >= 0" json:"balance"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CustomerWalletAccountID returns the deterministic account ID of a user's
+// wallet in a currency, so concurrent first use cannot create duplicates.
+func CustomerWalletAccountID(userID uuid.UUID, currency string) uuid.UUID {
+	return uuid.NewSHA1(walletAccountNamespace, []byte(WalletAccountCustomer+":"+userID.String()+":"+currency))
+}
+
+// SystemWalletAccountID returns the deterministic account ID of a system account.
+func SystemWalletAccountID(kind, currency string) uuid.UUID {
+	return uuid.NewSHA1(walletAccountNamespace, []byte(kind+":"+currency))
+}
+
+// WalletTransaction groups the balanced entries of one money movement.
+type WalletTransaction struct {
+	ID          uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Type        string        `gorm:"type:varchar(20);not null;index" json:"type"`
+	OrderID     *uuid.UUID    `gorm:"type:uuid;index" json:"order_id,omitempty"`
+	Reference   string        `gorm:"type:varchar(255)" json:"reference,omitempty"`
+	Description string        `gorm:"type:text" json:"description"`
+	CreatedBy   *uuid.UUID    `gorm:"type:uuid" json:"created_by,omitempty"`
+	Entries     []WalletEntry `gorm:"foreignKey:TransactionID" json:"entries,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+func (t *WalletTransaction) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// WalletEntry is a signed change to one account. The entries of a
+// transaction always sum to zero.
+type WalletEntry struct {
+	ID            uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TransactionID uuid.UUID         `gorm:"type:uuid;not null;index" json:"transaction_id"`
+	Transaction   WalletTransaction `gorm:"foreignKey:TransactionID" json:"transaction,omitempty"`
+	AccountID     uuid.UUID         `gorm:"type:uuid;not null;index" json:"account_id"`
+	Amount        Money             `gorm:"type:decimal(12,2);not null" json:"amount"`
+	BalanceAfter  Money             `gorm:"type:decimal(12,2);not null" json:"balance_after"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+func (e *WalletEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
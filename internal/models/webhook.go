@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed" // gave up after the job's last attempt
+)
+
+// Webhook is an endpoint a laundry owner registered to receive order events.
+// Events is a comma-separated list of subscribed event types.
+type Webhook struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	LaundryID uuid.UUID `gorm:"type:uuid;not null;index" json:"laundry_id"`
+	URL       string    `gorm:"type:text;not null" json:"url"`
+	Secret    string    `gorm:"type:varchar(100);not null" json:"-"`
+	Events    string    `gorm:"type:text;not null" json:"events"`
+	IsActive  bool      `gorm:"not null" json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// WebhookDelivery is one attempt to deliver an event to a webhook, kept as a
+// log the owner can inspect and replay. Payload is the exact body sent. An
+// event is delivered to a webhook once; replays are separate rows.
+type WebhookDelivery struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	WebhookID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_event,where:replay_of IS NULL" json:"webhook_id"`
+	EventID        string     `gorm:"type:varchar(64);not null;uniqueIndex:idx_webhook_deliveries_event" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload        string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus int        `gorm:"not null;default:0" json:"response_status,omitempty"`
+	ResponseBody   string     `gorm:"type:text" json:"response_body,omitempty"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	ReplayOf       *uuid.UUID `gorm:"type:uuid" json:"replay_of,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPSender sends email through an SMTP server.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{addr: host + ":" + port, auth: auth, from: from}
+}
+
+func (s *SMTPSender) Channel() string {
+	return "email"
+}
+
+func (s *SMTPSender) Send(ctx context.Context, message Message) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.Recipient)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(message.Body)
+
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{message.Recipient}, []byte(b.String()))
+}
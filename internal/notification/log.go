@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogSender writes messages as JSON lines instead of delivering them. It
+// stands in for any channel during development and tests.
+type LogSender struct {
+	channel string
+	mu      *sync.Mutex
+	w       io.Writer
+}
+
+func NewLogSender(channel string, w io.Writer, mu *sync.Mutex) *LogSender {
+	return &LogSender{channel: channel, w: w, mu: mu}
+}
+
+func (s *LogSender) Channel() string {
+	return s.channel
+}
+
+func (s *LogSender) Send(ctx context.Context, message Message) error {
+	line, err := json.Marshal(struct {
+		Time      time.Time `json:"time"`
+		Channel   string    `json:"channel"`
+		Recipient string    `json:"recipient"`
+		Subject   string    `json:"subject,omitempty"`
+		Body      string    `json:"body"`
+	}{time.Now(), message.Channel, message.Recipient, message.Subject, message.Body})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// PushSender posts push notifications to an Expo-compatible push gateway
+// ({"to", "title", "body"} JSON).
+type PushSender struct {
+	url    string
+	key    string
+	client *http.Client
+}
+
+func NewPushSender(url, key string) *PushSender {
+	return &PushSender{
+		url:    url,
+		key:    key,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *PushSender) Channel() string {
+	return "push"
+}
+
+func (s *PushSender) Send(ctx context.Context, message Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"to":    message.Recipient,
+		"title": message.Subject,
+		"body":  message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.key != "" {
+		req.Header.Set("Authorization", "Bearer "+s.key)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("push: status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+	"errors"
+)
+
+// Message is a rendered notification ready to send on one channel.
+type Message struct {
+	Channel   string
+	Recipient string // email address, phone number or push token
+	Subject   string
+	Body      string
+}
+
+// Sender delivers messages on one channel.
+type Sender interface {
+	Channel() string
+	Send(ctx context.Context, message Message) error
+}
+
+// Senders routes messages to the sender for their channel.
+type Senders map[string]Sender
+
+func (s Senders) Send(ctx context.Context, message Message) error {
+	sender, ok := s[message.Channel]
+	if !ok {
+		return errors.New("no sender configured for channel " + message.Channel)
+	}
+	return sender.Send(ctx, message)
+}
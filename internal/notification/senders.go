@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"io"
+	"laundry-go/internal/config"
+	"laundry-go/internal/models"
+	"os"
+	"sync"
+)
+
+// NewSenders builds a sender for every channel. Channels without
+// credentials fall back to the log sender, so the app runs fully offline by
+// default.
+func NewSenders(cfg config.NotificationConfig) (Senders, error) {
+	var w io.Writer = os.Stdout
+	if cfg.LogFile != "" {
+		file, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		w = file
+	}
+	mu := &sync.Mutex{}
+
+	senders := Senders{}
+	for _, channel := range []string{
+		models.NotificationChannelEmail,
+		models.NotificationChannelSMS,
+		models.NotificationChannelWhatsApp,
+		models.NotificationChannelPush,
+	} {
+		senders[channel] = NewLogSender(channel, w, mu)
+	}
+
+	if cfg.SMTPHost != "" && cfg.SMTPFrom != "" {
+		senders[models.NotificationChannelEmail] = NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+	if cfg.TwilioAccountSID != "" && cfg.TwilioAuthToken != "" {
+		if cfg.TwilioSMSFrom != "" {
+			senders[models.NotificationChannelSMS] = NewTwilioSender(models.NotificationChannelSMS, cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioSMSFrom)
+		}
+		if cfg.TwilioWhatsApp != "" {
+			senders[models.NotificationChannelWhatsApp] = NewTwilioSender(models.NotificationChannelWhatsApp, cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioWhatsApp)
+		}
+	}
+	if cfg.PushURL != "" {
+		senders[models.NotificationChannelPush] = NewPushSender(cfg.PushURL, cfg.PushKey)
+	}
+
+	return senders, nil
+}
@@ -0,0 +1,178 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Notification events sent to customers about their orders.
+const (
+	EventOrderPlaced    = "order_placed"
+	EventOrderConfirmed = "order_confirmed"
+	EventOrderPickedUp  = "order_picked_up"
+	EventOrderReady     = "order_ready"
+	EventOrderDelivered = "order_delivered"
+	EventOrderCancelled = "order_cancelled"
+
+	EventOrderAdjustmentRequested = "order_adjustment_requested"
+	EventOrderAdjusted            = "order_adjusted"
+	EventClaimResolved            = "claim_resolved"
+
+	EventRecurringOrderFailed = "recurring_order_failed"
+)
+
+// Notification events sent to laundry owners.
+const (
+	EventOrderReceived = "order_received"
+)
+
+// OrderData is what order templates can refer to.
+type OrderData struct {
+	CustomerName string
+	OrderNumber  string
+	LaundryName  string
+	Total        string
+}
+
+// RecurringOrderData is what recurring order templates can refer to.
+type RecurringOrderData struct {
+	CustomerName string
+	LaundryName  string
+	PickupAt     string
+	Reason       string
+}
+
+// OwnerOrderData is what templates for laundry owners can refer to.
+type OwnerOrderData struct {
+	OwnerName    string
+	CustomerName string
+	OrderNumber  string
+	LaundryName  string
+	Total        string
+	ConfirmBy    string
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newTemplate(subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// templates holds each event's message by language. Indonesian is the
+// fallback for languages without their own text.
+var templates = map[string]map[string]messageTemplate{
+	EventOrderPlaced: {
+		"id": newTemplate("Pesanan {{.OrderNumber}} diterima",
+			"Halo {{.CustomerName}}, pesanan {{.OrderNumber}} di {{.LaundryName}} sudah kami terima. Total: {{.Total}}. Kami kabari setelah laundry mengonfirmasi."),
+		"en": newTemplate("Order {{.OrderNumber}} received",
+			"Hi {{.CustomerName}}, we have received order {{.OrderNumber}} at {{.LaundryName}}. Total: {{.Total}}. We will let you know once the laundry confirms it."),
+	},
+	EventOrderConfirmed: {
+		"id": newTemplate("Pesanan {{.OrderNumber}} dikonfirmasi",
+			"Halo {{.CustomerName}}, {{.LaundryName}} sudah mengonfirmasi pesanan {{.OrderNumber}}."),
+		"en": newTemplate("Order {{.OrderNumber}} confirmed",
+			"Hi {{.CustomerName}}, {{.LaundryName}} has confirmed order {{.OrderNumber}}."),
+	},
+	EventOrderPickedUp: {
+		"id": newTemplate("Pesanan {{.OrderNumber}} sudah dijemput",
+			"Halo {{.CustomerName}}, cucian untuk pesanan {{.OrderNumber}} sudah dijemput oleh {{.LaundryName}}."),
+		"en": newTemplate("Order {{.OrderNumber}} picked up",
+			"Hi {{.CustomerName}}, {{.LaundryName}} has picked up the laundry for order {{.OrderNumber}}."),
+	},
+	EventOrderReady: {
+		"id": newTemplate("Pesanan {{.OrderNumber}} siap",
+			"Halo {{.CustomerName}}, cucian untuk pesanan {{.OrderNumber}} di {{.LaundryName}} sudah selesai dan siap diantar."),
+		"en": newTemplate("Order {{.OrderNumber}} is ready",
+			"Hi {{.CustomerName}}, the laundry for order {{.OrderNumber}} at {{.LaundryName}} is done and ready for delivery."),
+	},
+	EventOrderDelivered: {
+		"id": newTemplate("Pesanan {{.OrderNumber}} sudah diantar",
+			"Halo {{.CustomerName}}, pesanan {{.OrderNumber}} dari {{.LaundryName}} sudah diantar. Terima kasih!"),
+		"en": newTemplate("Order {{.OrderNumber}} delivered",
+			"Hi {{.CustomerName}}, order {{.OrderNumber}} from {{.LaundryName}} has been delivered. Thank you!"),
+	},
+	EventOrderCancelled: {
+		"id": newTemplate("Pesanan {{.OrderNumber}} dibatalkan",
+			"Halo {{.CustomerName}}, pesanan {{.OrderNumber}} di {{.LaundryName}} telah dibatalkan."),
+		"en": newTemplate("Order {{.OrderNumber}} cancelled",
+			"Hi {{.CustomerName}}, order {{.OrderNumber}} at {{.LaundryName}} has been cancelled."),
+	},
+	EventOrderAdjustmentRequested: {
+		"id": newTemplate("Konfirmasi berat pesanan {{.OrderNumber}}",
+			"Halo {{.CustomerName}}, {{.LaundryName}} sudah menimbang cucian pesanan {{.OrderNumber}}. Total baru: {{.Total}}. Mohon setujui atau tolak perubahan ini di aplikasi agar cucian bisa diproses."),
+		"en": newTemplate("Confirm the weight of order {{.OrderNumber}}",
+			"Hi {{.CustomerName}}, {{.LaundryName}} has weighed the laundry for order {{.OrderNumber}}. New total: {{.Total}}. Please accept or reject the change in the app so your laundry can be processed."),
+	},
+	EventOrderAdjusted: {
+		"id": newTemplate("Total pesanan {{.OrderNumber}} diperbarui",
+			"Halo {{.CustomerName}}, total pesanan {{.OrderNumber}} di {{.LaundryName}} disesuaikan dengan berat cucian menjadi {{.Total}}."),
+		"en": newTemplate("Order {{.OrderNumber}} total updated",
+			"Hi {{.CustomerName}}, the total of order {{.OrderNumber}} at {{.LaundryName}} was updated to {{.Total}} to match the weight of your laundry."),
+	},
+	EventClaimResolved: {
+		"id": newTemplate("Klaim pesanan {{.OrderNumber}} sudah ditanggapi",
+			"Halo {{.CustomerName}}, klaim Anda untuk pesanan {{.OrderNumber}} di {{.LaundryName}} sudah diselesaikan. Cek detailnya di aplikasi; jika belum puas, Anda bisa meneruskannya ke admin."),
+		"en": newTemplate("Your claim on order {{.OrderNumber}} was resolved",
+			"Hi {{.CustomerName}}, your claim on order {{.OrderNumber}} at {{.LaundryName}} has been resolved. See the details in the app; if you are not satisfied you can escalate it to an admin."),
+	},
+	EventRecurringOrderFailed: {
+		"id": newTemplate("Pesanan rutin di {{.LaundryName}} tidak dibuat",
+			"Halo {{.CustomerName}}, pesanan rutin Anda di {{.LaundryName}} untuk penjemputan {{.PickupAt}} tidak bisa dibuat ({{.Reason}}). Jadwal berikutnya tetap berjalan; periksa pesanan rutin Anda di aplikasi."),
+		"en": newTemplate("Your recurring order at {{.LaundryName}} was not placed",
+			"Hi {{.CustomerName}}, your recurring order at {{.LaundryName}} for the pickup on {{.PickupAt}} could not be placed ({{.Reason}}). The next pickups stay scheduled; check your recurring order in the app."),
+	},
+	EventOrderReceived: {
+		"id": newTemplate("Pesanan baru {{.OrderNumber}} di {{.LaundryName}}",
+			"Halo {{.OwnerName}}, ada pesanan baru {{.OrderNumber}} dari {{.CustomerName}} di {{.LaundryName}}. Total: {{.Total}}. Konfirmasi sebelum {{.ConfirmBy}} atau pesanan dibatalkan otomatis."),
+		"en": newTemplate("New order {{.OrderNumber}} at {{.LaundryName}}",
+			"Hi {{.OwnerName}}, {{.CustomerName}} placed order {{.OrderNumber}} at {{.LaundryName}}. Total: {{.Total}}. Confirm it before {{.ConfirmBy}} or it is cancelled automatically."),
+	},
+}
+
+// EventForStatus maps an order status to the event customers are notified
+// of, or "" if the status is not announced.
+func EventForStatus(status string) string {
+	switch status {
+	case "confirmed":
+		return EventOrderConfirmed
+	case "picked-up":
+		return EventOrderPickedUp
+	case "ready":
+		return EventOrderReady
+	case "delivered":
+		return EventOrderDelivered
+	case "cancelled":
+		return EventOrderCancelled
+	}
+	return ""
+}
+
+// Render fills in the event's template for a locale such as "en-US".
+func Render(event, locale string, data interface{}) (subject, body string, err error) {
+	byLanguage, ok := templates[event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for event %s", event)
+	}
+
+	language := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+	tmpl, ok := byLanguage[language]
+	if !ok {
+		tmpl = byLanguage["id"]
+	}
+
+	var s, b strings.Builder
+	if err := tmpl.subject.Execute(&s, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&b, data); err != nil {
+		return "", "", err
+	}
+	return s.String(), b.String(), nil
+}
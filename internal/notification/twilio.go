@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const twilioAPI = "https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json"
+
+// TwilioSender sends SMS or WhatsApp messages through the Twilio Messages
+// API. WhatsApp numbers are prefixed with "whatsapp:" as Twilio expects.
+type TwilioSender struct {
+	channel    string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func NewTwilioSender(channel, accountSID, authToken, from string) *TwilioSender {
+	return &TwilioSender{
+		channel:    channel,
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *TwilioSender) Channel() string {
+	return s.channel
+}
+
+func (s *TwilioSender) Send(ctx context.Context, message Message) error {
+	from, to := s.from, message.Recipient
+	if s.channel == "whatsapp" {
+		from, to = "whatsapp:"+from, "whatsapp:"+to
+	}
+
+	form := url.Values{}
+	form.Set("From", from)
+	form.Set("To", to)
+	form.Set("Body", message.Body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(twilioAPI, s.accountSID), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("twilio: status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClaimFilter narrows claim lists. Zero values match everything.
+type ClaimFilter struct {
+	UserID        *uuid.UUID
+	LaundryIDs    []uuid.UUID
+	Status        string
+	EscalatedOnly bool
+}
+
+type ClaimRepository interface {
+	WithTx(tx *gorm.DB) ClaimRepository
+	Create(claim *models.Claim) error
+	FindByID(id uuid.UUID) (*models.Claim, error)
+	FindByIDForUpdate(id uuid.UUID) (*models.Claim, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.Claim, error)
+	FindAll(filter ClaimFilter, page, limit int) ([]models.Claim, int64, error)
+	HasActive(orderID uuid.UUID) (bool, error)
+	HasUnresolved(orderID uuid.UUID) (bool, error)
+	SumRefundedByOrderID(orderID uuid.UUID) (models.Money, error)
+	Update(claim *models.Claim) error
+	CreatePhoto(photo *models.ClaimPhoto) error
+	CountPhotos(claimID uuid.UUID) (int64, error)
+	FindPhotoByID(id uuid.UUID) (*models.ClaimPhoto, error)
+	CreateMessage(message *models.ClaimMessage) error
+}
+
+type claimRepository struct {
+	db *gorm.DB
+}
+
+func NewClaimRepository(db *gorm.DB) ClaimRepository {
+	return &claimRepository{db: db}
+}
+
+func (r *claimRepository) WithTx(tx *gorm.DB) ClaimRepository {
+	return &claimRepository{db: tx}
+}
+
+// Create writes the claim and its items. Should run inside a transaction.
+func (r *claimRepository) Create(claim *models.Claim) error {
+	if err := r.db.Omit(clause.Associations).Create(claim).Error; err != nil {
+		return err
+	}
+	for i := range claim.Items {
+		claim.Items[i].ClaimID = claim.ID
+	}
+	if len(claim.Items) == 0 {
+		return nil
+	}
+	return r.db.Create(&claim.Items).Error
+}
+
+// FindByID returns the claim with its items, photos and messages, oldest
+// first.
+func (r *claimRepository) FindByID(id uuid.UUID) (*models.Claim, error) {
+	var claim models.Claim
+	err := r.db.Preload("Items").
+		Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Messages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("id = ?", id).First(&claim).Error
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+func (r *claimRepository) FindByIDForUpdate(id uuid.UUID) (*models.Claim, error) {
+	var claim models.Claim
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&claim).Error
+	if err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// FindByOrderID returns the order's claims, newest first.
+func (r *claimRepository) FindByOrderID(orderID uuid.UUID) ([]models.Claim, error) {
+	var claims []models.Claim
+	err := r.db.Preload("Items").
+		Where("order_id = ?", orderID).
+		Order("created_at DESC").
+		Find(&claims).Error
+	return claims, err
+}
+
+// FindAll lists claims matching filter, newest first.
+func (r *claimRepository) FindAll(filter ClaimFilter, page, limit int) ([]models.Claim, int64, error) {
+	var claims []models.Claim
+	var total int64
+
+	query := r.db.Model(&models.Claim{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.LaundryIDs != nil {
+		query = query.Where("laundry_id IN ?", filter.LaundryIDs)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EscalatedOnly {
+		query = query.Where("escalated_at IS NOT NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Items").Order("created_at DESC").Offset(offset).Limit(limit).Find(&claims).Error
+	return claims, total, err
+}
+
+// HasActive reports whether the order has a claim that is not closed.
+func (r *claimRepository) HasActive(orderID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Claim{}).
+		Where("order_id = ? AND status <> ?", orderID, models.ClaimStatusClosed).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasUnresolved reports whether the order has a claim waiting for a
+// resolution.
+func (r *claimRepository) HasUnresolved(orderID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Claim{}).
+		Where("order_id = ? AND status IN ?", orderID, []string{models.ClaimStatusOpen, models.ClaimStatusUnderReview}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *claimRepository) SumRefundedByOrderID(orderID uuid.UUID) (models.Money, error) {
+	var total models.Money
+	err := r.db.Model(&models.Claim{}).
+		Where("order_id = ?", orderID).
+		Select("COALESCE(SUM(refunded_amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *claimRepository) Update(claim *models.Claim) error {
+	return r.db.Omit(clause.Associations).Save(claim).Error
+}
+
+func (r *claimRepository) CreatePhoto(photo *models.ClaimPhoto) error {
+	return r.db.Create(photo).Error
+}
+
+func (r *claimRepository) CountPhotos(claimID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ClaimPhoto{}).Where("claim_id = ?", claimID).Count(&count).Error
+	return count, err
+}
+
+func (r *claimRepository) FindPhotoByID(id uuid.UUID) (*models.ClaimPhoto, error) {
+	var photo models.ClaimPhoto
+	err := r.db.Where("id = ?", id).First(&photo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func (r *claimRepository) CreateMessage(message *models.ClaimMessage) error {
+	return r.db.Create(message).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CourierLocationRepository interface {
+	WithTx(tx *gorm.DB) CourierLocationRepository
+	SaveLatest(location *models.CourierLocation) error
+	FindLatest(courierID uuid.UUID) (*models.CourierLocation, error)
+	CreatePing(ping *models.CourierLocationPing) error
+	FindTrail(courierID uuid.UUID, since time.Time, limit int) ([]models.CourierLocationPing, error)
+	DeletePingsBefore(before time.Time) (int64, error)
+}
+
+type courierLocationRepository struct {
+	db *gorm.DB
+}
+
+func NewCourierLocationRepository(db *gorm.DB) CourierLocationRepository {
+	return &courierLocationRepository{db: db}
+}
+
+func (r *courierLocationRepository) WithTx(tx *gorm.DB) CourierLocationRepository {
+	return &courierLocationRepository{db: tx}
+}
+
+func (r *courierLocationRepository) SaveLatest(location *models.CourierLocation) error {
+	return r.db.Save(location).Error
+}
+
+func (r *courierLocationRepository) FindLatest(courierID uuid.UUID) (*models.CourierLocation, error) {
+	var location models.CourierLocation
+	err := r.db.Where("courier_id = ?", courierID).First(&location).Error
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (r *courierLocationRepository) CreatePing(ping *models.CourierLocationPing) error {
+	return r.db.Create(ping).Error
+}
+
+// FindTrail returns the courier's pings since the given time, oldest first,
+// keeping only the most recent limit points.
+func (r *courierLocationRepository) FindTrail(courierID uuid.UUID, since time.Time, limit int) ([]models.CourierLocationPing, error) {
+	var pings []models.CourierLocationPing
+	err := r.db.Where("courier_id = ? AND recorded_at >= ?", courierID, since).
+		Order("recorded_at DESC").Limit(limit).Find(&pings).Error
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(pings)-1; i < j; i, j = i+1, j-1 {
+		pings[i], pings[j] = pings[j], pings[i]
+	}
+	return pings, nil
+}
+
+func (r *courierLocationRepository) DeletePingsBefore(before time.Time) (int64, error) {
+	result := r.db.Where("recorded_at < ?", before).Delete(&models.CourierLocationPing{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var activeDeliveryStatuses = []string{models.DeliveryStatusEnRoute, models.DeliveryStatusArrived}
+
+type DeliveryRepository interface {
+	WithTx(tx *gorm.DB) DeliveryRepository
+	Create(delivery *models.Delivery) error
+	FindByID(id uuid.UUID) (*models.Delivery, error)
+	FindByIDForUpdate(id uuid.UUID) (*models.Delivery, error)
+	FindByOrderAndLegForUpdate(orderID uuid.UUID, leg string) (*models.Delivery, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.Delivery, error)
+	FindByOrderIDs(orderIDs []uuid.UUID) ([]models.Delivery, error)
+	FindByCourierID(courierID uuid.UUID, status string, page, limit int) ([]models.Delivery, int64, error)
+	FindActiveByCourierID(courierID uuid.UUID) ([]models.Delivery, error)
+	FindActiveByOrderID(orderID uuid.UUID) (*models.Delivery, error)
+	Update(delivery *models.Delivery) error
+	CancelOpenByOrderID(orderID uuid.UUID) error
+}
+
+type deliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) DeliveryRepository {
+	return &deliveryRepository{db: db}
+}
+
+func (r *deliveryRepository) WithTx(tx *gorm.DB) DeliveryRepository {
+	return &deliveryRepository{db: tx}
+}
+
+func (r *deliveryRepository) Create(delivery *models.Delivery) error {
+	return r.db.Create(delivery).Error
+}
+
+func (r *deliveryRepository) FindByID(id uuid.UUID) (*models.Delivery, error) {
+	var delivery models.Delivery
+	err := r.db.Preload("Order.Laundry").Preload("Order.User").Preload("Courier").
+		Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindByIDForUpdate locks the delivery row. Must run inside a transaction.
+func (r *deliveryRepository) FindByIDForUpdate(id uuid.UUID) (*models.Delivery, error) {
+	var delivery models.Delivery
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindByOrderAndLegForUpdate locks the order's delivery for the leg. Must
+// run inside a transaction.
+func (r *deliveryRepository) FindByOrderAndLegForUpdate(orderID uuid.UUID, leg string) (*models.Delivery, error) {
+	var delivery models.Delivery
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND leg = ?", orderID, leg).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *deliveryRepository) FindByOrderID(orderID uuid.UUID) ([]models.Delivery, error) {
+	var deliveries []models.Delivery
+	err := r.db.Preload("Order.Laundry").Preload("Order.User").Preload("Courier").
+		Where("order_id = ?", orderID).Order("created_at ASC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *deliveryRepository) FindByOrderIDs(orderIDs []uuid.UUID) ([]models.Delivery, error) {
+	var deliveries []models.Delivery
+	if len(orderIDs) == 0 {
+		return deliveries, nil
+	}
+	err := r.db.Where("order_id IN ?", orderIDs).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindByCourierID returns the courier's deliveries, open ones first.
+func (r *deliveryRepository) FindByCourierID(courierID uuid.UUID, status string, page, limit int) ([]models.Delivery, int64, error) {
+	var deliveries []models.Delivery
+	var total int64
+
+	query := r.db.Model(&models.Delivery{}).Where("courier_id = ?", courierID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Order.Laundry").Preload("Order.User").Preload("Courier").
+		Order("CASE WHEN status IN ('done', 'cancelled') THEN 1 ELSE 0 END, assigned_at ASC").
+		Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+// FindActiveByCourierID returns the courier's deliveries that are on the
+// road (en route or arrived).
+func (r *deliveryRepository) FindActiveByCourierID(courierID uuid.UUID) ([]models.Delivery, error) {
+	var deliveries []models.Delivery
+	err := r.db.Preload("Order").
+		Where("courier_id = ? AND status IN ?", courierID, activeDeliveryStatuses).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindActiveByOrderID returns the order's delivery that is on the road,
+// preferring the drop-off leg. It returns nil when there is none.
+func (r *deliveryRepository) FindActiveByOrderID(orderID uuid.UUID) (*models.Delivery, error) {
+	var deliveries []models.Delivery
+	err := r.db.Preload("Courier").
+		Where("order_id = ? AND status IN ?", orderID, activeDeliveryStatuses).
+		Order("CASE WHEN leg = 'dropoff' THEN 0 ELSE 1 END").
+		Limit(1).Find(&deliveries).Error
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+func (r *deliveryRepository) Update(delivery *models.Delivery) error {
+	return r.db.Omit(clause.Associations).Save(delivery).Error
+}
+
+// CancelOpenByOrderID cancels the order's deliveries that are not done.
+func (r *deliveryRepository) CancelOpenByOrderID(orderID uuid.UUID) error {
+	return r.db.Model(&models.Delivery{}).
+		Where("order_id = ? AND status NOT IN ?", orderID, []string{models.DeliveryStatusDone, models.DeliveryStatusCancelled}).
+		Update("status", models.DeliveryStatusCancelled).Error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FavoriteRepository interface {
+	Add(favorite *models.FavoriteLaundry) error
+	Remove(userID, laundryID uuid.UUID) (bool, error)
+	FindByUserID(userID uuid.UUID, page, limit int) ([]models.FavoriteLaundry, int64, error)
+}
+
+type favoriteRepository struct {
+	db *gorm.DB
+}
+
+func NewFavoriteRepository(db *gorm.DB) FavoriteRepository {
+	return &favoriteRepository{db: db}
+}
+
+// Add saves the favourite; adding one that exists already is a no-op.
+func (r *favoriteRepository) Add(favorite *models.FavoriteLaundry) error {
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error
+}
+
+// Remove deletes the favourite and reports whether there was one.
+func (r *favoriteRepository) Remove(userID, laundryID uuid.UUID) (bool, error) {
+	result := r.db.Where("user_id = ? AND laundry_id = ?", userID, laundryID).Delete(&models.FavoriteLaundry{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindByUserID lists the user's favourites with their laundries, most
+// recently added first.
+func (r *favoriteRepository) FindByUserID(userID uuid.UUID, page, limit int) ([]models.FavoriteLaundry, int64, error) {
+	var favorites []models.FavoriteLaundry
+	var total int64
+
+	query := r.db.Model(&models.FavoriteLaundry{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Laundry").Order("created_at DESC").Offset(offset).Limit(limit).Find(&favorites).Error
+	return favorites, total, err
+}
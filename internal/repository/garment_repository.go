@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GarmentRepository interface {
+	WithTx(tx *gorm.DB) GarmentRepository
+	Create(item *models.GarmentItem) error
+	FindByID(id uuid.UUID) (*models.GarmentItem, error)
+	FindByTagCodeForUpdate(tagCode string) (*models.GarmentItem, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.GarmentItem, error)
+	TagCodeExists(tagCode string) (bool, error)
+	Update(item *models.GarmentItem) error
+	Delete(id uuid.UUID) error
+	CreateScan(scan *models.GarmentScan) error
+}
+
+type garmentRepository struct {
+	db *gorm.DB
+}
+
+func NewGarmentRepository(db *gorm.DB) GarmentRepository {
+	return &garmentRepository{db: db}
+}
+
+func (r *garmentRepository) WithTx(tx *gorm.DB) GarmentRepository {
+	return &garmentRepository{db: tx}
+}
+
+func (r *garmentRepository) Create(item *models.GarmentItem) error {
+	return r.db.Omit(clause.Associations).Create(item).Error
+}
+
+func (r *garmentRepository) FindByID(id uuid.UUID) (*models.GarmentItem, error) {
+	var item models.GarmentItem
+	err := r.db.Preload("Scans", func(db *gorm.DB) *gorm.DB {
+		return db.Order("scanned_at ASC")
+	}).Where("id = ?", id).First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindByTagCodeForUpdate locks the garment with the tag. Must run inside a
+// transaction.
+func (r *garmentRepository) FindByTagCodeForUpdate(tagCode string) (*models.GarmentItem, error) {
+	var item models.GarmentItem
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tag_code = ?", tagCode).First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindByOrderID returns the order's garments in the order they were
+// tagged, with their scans.
+func (r *garmentRepository) FindByOrderID(orderID uuid.UUID) ([]models.GarmentItem, error) {
+	var items []models.GarmentItem
+	err := r.db.Preload("Scans", func(db *gorm.DB) *gorm.DB {
+		return db.Order("scanned_at ASC")
+	}).Where("order_id = ?", orderID).Order("created_at ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *garmentRepository) TagCodeExists(tagCode string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.GarmentItem{}).Where("tag_code = ?", tagCode).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *garmentRepository) Update(item *models.GarmentItem) error {
+	return r.db.Omit(clause.Associations).Save(item).Error
+}
+
+// Delete removes the garment and its scans. Should run inside a
+// transaction.
+func (r *garmentRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("item_id = ?", id).Delete(&models.GarmentScan{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("id = ?", id).Delete(&models.GarmentItem{}).Error
+}
+
+func (r *garmentRepository) CreateScan(scan *models.GarmentScan) error {
+	return r.db.Create(scan).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepository interface {
+	WithTx(tx *gorm.DB) JobRepository
+	Create(job *models.Job) error
+	FindByID(id uuid.UUID) (*models.Job, error)
+	FindAll(status string, page, limit int) ([]models.Job, int64, error)
+	ClaimNext(now, staleBefore time.Time) (*models.Job, error)
+	Update(job *models.Job) error
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) WithTx(tx *gorm.DB) JobRepository {
+	return &jobRepository{db: tx}
+}
+
+func (r *jobRepository) Create(job *models.Job) error {
+	return r.db.Create(job).Error
+}
+
+func (r *jobRepository) FindByID(id uuid.UUID) (*models.Job, error) {
+	var job models.Job
+	err := r.db.Where("id = ?", id).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) FindAll(status string, page, limit int) ([]models.Job, int64, error) {
+	var jobs []models.Job
+	var total int64
+
+	query := r.db.Model(&models.Job{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&jobs).Error
+	return jobs, total, err
+}
+
+// ClaimNext locks the next runnable job: a pending job that is due, or a
+// running job whose worker stopped heartbeating before staleBefore. Rows
+// locked by other workers are skipped. Call it inside a transaction.
+func (r *jobRepository) ClaimNext(now, staleBefore time.Time) (*models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_at < ?)",
+			models.JobStatusPending, now, models.JobStatusRunning, staleBefore).
+		Order("run_at ASC").
+		Limit(1).
+		Find(&jobs).Error
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return &jobs[0], nil
+}
+
+func (r *jobRepository) Update(job *models.Job) error {
+	return r.db.Save(job).Error
+}
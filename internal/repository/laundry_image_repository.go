@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LaundryImageRepository interface {
+	WithTx(tx *gorm.DB) LaundryImageRepository
+	Create(image *models.LaundryImage) error
+	FindByID(id uuid.UUID) (*models.LaundryImage, error)
+	FindByLaundryID(laundryID uuid.UUID) ([]models.LaundryImage, error)
+	FindByLaundryIDs(laundryIDs []uuid.UUID) ([]models.LaundryImage, error)
+	FindByKind(laundryID uuid.UUID, kind string) ([]models.LaundryImage, error)
+	CountByKind(laundryID uuid.UUID, kind string) (int64, error)
+	MaxPosition(laundryID uuid.UUID, kind string) (int, error)
+	UpdatePosition(id uuid.UUID, position int) error
+	Delete(id uuid.UUID) error
+}
+
+type laundryImageRepository struct {
+	db *gorm.DB
+}
+
+func NewLaundryImageRepository(db *gorm.DB) LaundryImageRepository {
+	return &laundryImageRepository{db: db}
+}
+
+func (r *laundryImageRepository) WithTx(tx *gorm.DB) LaundryImageRepository {
+	return &laundryImageRepository{db: tx}
+}
+
+func (r *laundryImageRepository) Create(image *models.LaundryImage) error {
+	return r.db.Create(image).Error
+}
+
+func (r *laundryImageRepository) FindByID(id uuid.UUID) (*models.LaundryImage, error) {
+	var image models.LaundryImage
+	if err := r.db.Where("id = ?", id).First(&image).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// FindByLaundryID returns the laundry's images in gallery order.
+func (r *laundryImageRepository) FindByLaundryID(laundryID uuid.UUID) ([]models.LaundryImage, error) {
+	var images []models.LaundryImage
+	err := r.db.Where("laundry_id = ?", laundryID).
+		Order("position ASC, created_at ASC").
+		Find(&images).Error
+	return images, err
+}
+
+func (r *laundryImageRepository) FindByLaundryIDs(laundryIDs []uuid.UUID) ([]models.LaundryImage, error) {
+	var images []models.LaundryImage
+	if len(laundryIDs) == 0 {
+		return images, nil
+	}
+	err := r.db.Where("laundry_id IN ?", laundryIDs).
+		Order("position ASC, created_at ASC").
+		Find(&images).Error
+	return images, err
+}
+
+func (r *laundryImageRepository) FindByKind(laundryID uuid.UUID, kind string) ([]models.LaundryImage, error) {
+	var images []models.LaundryImage
+	err := r.db.Where("laundry_id = ? AND kind = ?", laundryID, kind).Find(&images).Error
+	return images, err
+}
+
+func (r *laundryImageRepository) CountByKind(laundryID uuid.UUID, kind string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.LaundryImage{}).
+		Where("laundry_id = ? AND kind = ?", laundryID, kind).
+		Count(&count).Error
+	return count, err
+}
+
+// MaxPosition returns the highest position among the laundry's images of
+// the kind, or -1 when there are none.
+func (r *laundryImageRepository) MaxPosition(laundryID uuid.UUID, kind string) (int, error) {
+	var position int
+	err := r.db.Model(&models.LaundryImage{}).
+		Where("laundry_id = ? AND kind = ?", laundryID, kind).
+		Select("COALESCE(MAX(position), -1)").
+		Scan(&position).Error
+	return position, err
+}
+
+func (r *laundryImageRepository) UpdatePosition(id uuid.UUID, position int) error {
+	return r.db.Model(&models.LaundryImage{}).Where("id = ?", id).Update("position", position).Error
+}
+
+func (r *laundryImageRepository) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.LaundryImage{}).Error
+}
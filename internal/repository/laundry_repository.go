@@ -2,20 +2,69 @@ package repository
 
 import (
 	"laundry-go/internal/models"
+	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type LaundryRepository interface {
 	Create(laundry *models.Laundry) error
 	FindByID(id uuid.UUID) (*models.Laundry, error)
-	FindAll(search string, isOpen *bool, page, limit int) ([]models.Laundry, int64, error)
+	FindAll(filter LaundryFilter, page, limit int) ([]models.Laundry, int64, error)
+	CountCategories(filter LaundryFilter) ([]CategoryCount, error)
+	CountMinRatings(filter LaundryFilter, minRatings []float64) ([]int64, error)
 	FindByOwnerID(ownerID uuid.UUID) ([]models.Laundry, error)
 	Update(laundry *models.Laundry) error
 	Delete(id uuid.UUID) error
 }
 
+// LaundryFilter narrows and orders FindAll. With Search, laundries are
+// ranked by relevance weighted by rating and, when a location is given,
+// distance. Otherwise they are ordered by distance, or by rating without a
+// location.
+//
+// Categories, PriceUnit, MinPrice and MaxPrice match a laundry that has one
+// active service meeting all of them. Delivery and MaxDistanceKm use the
+// location; MaxDistanceKm is ignored without one.
+type LaundryFilter struct {
+	Search        string
+	IsOpen        *bool
+	Categories    []string // any of
+	PriceUnit     string
+	MinPrice      *models.Money
+	MaxPrice      *models.Money
+	MinRating     *float64
+	Delivery      bool // offers delivery, to the location when known
+	MaxDistanceKm *float64
+	Latitude      *float64
+	Longitude     *float64
+}
+
+// CategoryCount is how many laundries offer a service category.
+type CategoryCount struct {
+	Category string
+	Count    int64
+}
+
+// Search ranking. Relevance is scaled up by rating (a 5-star laundry scores
+// searchRatingWeight+1 times an unrated one) and down by distance (halved
+// every searchDistanceScaleKm). Laundries without coordinates are not
+// penalised.
+const (
+	searchRatingWeight    = 0.5
+	searchDistanceScaleKm = 5.0
+	// Trigram similarity counts for less than a full-text match
+	searchTrigramWeight = 0.3
+)
+
+// distanceSQL is the great-circle distance in kilometres from a point to a
+// laundry, NULL when the laundry has no coordinates. Its parameters are
+// latitude, longitude, latitude.
+const distanceSQL = `(6371 * acos(least(1, cos(radians(?)) * cos(radians(laundries.latitude)) * ` +
+	`cos(radians(laundries.longitude) - radians(?)) + sin(radians(?)) * sin(radians(laundries.latitude)))))`
+
 type laundryRepository struct {
 	db *gorm.DB
 }
@@ -37,25 +86,34 @@ func (r *laundryRepository) FindByID(id uuid.UUID) (*models.Laundry, error) {
 	return &laundry, nil
 }
 
-func (r *laundryRepository) FindAll(search string, isOpen *bool, page, limit int) ([]models.Laundry, int64, error) {
+func (r *laundryRepository) FindAll(filter LaundryFilter, page, limit int) ([]models.Laundry, int64, error) {
 	var laundries []models.Laundry
 	var total int64
 
-	query := r.db.Model(&models.Laundry{})
-
-	if search != "" {
-		query = query.Where("name ILIKE ? OR address ILIKE ?", "%"+search+"%", "%"+search+"%")
-	}
-
-	if isOpen != nil {
-		query = query.Where("is_open = ?", *isOpen)
-	}
+	query := r.filtered(filter)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	search := strings.TrimSpace(filter.Search)
+	distance, hasLocation := filter.distance()
+	switch {
+	case search != "":
+		rank := gorm.Expr(
+			"(ts_rank_cd(laundries.search_vector, websearch_to_tsquery('laundry_search', ?)) + ? * word_similarity(lower(unaccent(?)), laundries.search_text)) * (1 + ? * laundries.rating / 5)",
+			search, searchTrigramWeight, search, searchRatingWeight,
+		)
+		if hasLocation {
+			rank = gorm.Expr("? / (1 + COALESCE(?, 0) / ?)", rank, distance, searchDistanceScaleKm)
+		}
+		query = query.Order(clause.OrderBy{Expression: gorm.Expr("? DESC", rank)})
+	case hasLocation:
+		query = query.Order(clause.OrderBy{Expression: gorm.Expr("? ASC NULLS LAST", distance)})
+	}
+	query = query.Order("laundries.rating DESC").Order("laundries.id")
+
 	// Get paginated results
 	offset := (page - 1) * limit
 	err := query.Offset(offset).Limit(limit).Find(&laundries).Error
@@ -66,6 +124,132 @@ func (r *laundryRepository) FindAll(search string, isOpen *bool, page, limit int
 	return laundries, total, nil
 }
 
+// CountCategories counts the laundries matching filter per category of
+// their active services. filter.Categories is ignored so every category
+// shows how many laundries choosing it would return.
+func (r *laundryRepository) CountCategories(filter LaundryFilter) ([]CategoryCount, error) {
+	filter.Categories = nil
+	conditions, args := filter.serviceConditions("facet_services")
+
+	var counts []CategoryCount
+	err := r.filtered(filter).
+		Joins("JOIN services facet_services ON "+conditions, args...).
+		Select("facet_services.category AS category, COUNT(DISTINCT laundries.id) AS count").
+		Group("facet_services.category").
+		Order("COUNT(DISTINCT laundries.id) DESC").Order("facet_services.category").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// CountMinRatings counts the laundries matching filter rated at least each
+// of minRatings. filter.MinRating is ignored.
+func (r *laundryRepository) CountMinRatings(filter LaundryFilter, minRatings []float64) ([]int64, error) {
+	filter.MinRating = nil
+
+	columns := make([]string, len(minRatings))
+	args := make([]interface{}, len(minRatings))
+	for i, minRating := range minRatings {
+		columns[i] = "COUNT(*) FILTER (WHERE laundries.rating >= ?)"
+		args[i] = minRating
+	}
+
+	counts := make([]int64, len(minRatings))
+	dest := make([]interface{}, len(minRatings))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+
+	err := r.filtered(filter).Select(strings.Join(columns, ", "), args...).Row().Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// filtered returns a laundries query with filter's conditions applied.
+func (r *laundryRepository) filtered(filter LaundryFilter) *gorm.DB {
+	query := r.db.Model(&models.Laundry{})
+
+	// search_vector and search_text are kept up to date by the triggers in
+	// migrations/022_laundry_search.sql. Substring and trigram matches keep
+	// partial words and typos finding results.
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		query = query.Where(
+			"laundries.search_vector @@ websearch_to_tsquery('laundry_search', ?) OR "+
+				"laundries.search_text LIKE '%' || lower(unaccent(?)) || '%' OR "+
+				"lower(unaccent(?)) <% laundries.search_text",
+			search, escapeLike(search), search,
+		)
+	}
+
+	if filter.IsOpen != nil {
+		query = query.Where("laundries.is_open = ?", *filter.IsOpen)
+	}
+
+	if filter.hasServiceConditions() {
+		conditions, args := filter.serviceConditions("filter_services")
+		query = query.Where("EXISTS (SELECT 1 FROM services filter_services WHERE "+conditions+")", args...)
+	}
+
+	if filter.MinRating != nil {
+		query = query.Where("laundries.rating >= ?", *filter.MinRating)
+	}
+
+	distance, hasLocation := filter.distance()
+	if filter.Delivery {
+		query = query.Where("laundries.offers_delivery")
+		if hasLocation {
+			query = query.Where("laundries.delivery_radius_km IS NULL OR ? <= laundries.delivery_radius_km", distance)
+		}
+	}
+	if filter.MaxDistanceKm != nil && hasLocation {
+		query = query.Where("? <= ?", distance, *filter.MaxDistanceKm)
+	}
+
+	return query
+}
+
+// distance is the distance to the filter's location, if it has one.
+func (f LaundryFilter) distance() (clause.Expr, bool) {
+	if f.Latitude == nil || f.Longitude == nil {
+		return clause.Expr{}, false
+	}
+	return gorm.Expr(distanceSQL, *f.Latitude, *f.Longitude, *f.Latitude), true
+}
+
+func (f LaundryFilter) hasServiceConditions() bool {
+	return len(f.Categories) > 0 || f.PriceUnit != "" || f.MinPrice != nil || f.MaxPrice != nil
+}
+
+// serviceConditions matches the active services of the current laundry,
+// aliased as alias, that meet the filter's service conditions.
+func (f LaundryFilter) serviceConditions(alias string) (string, []interface{}) {
+	conditions := []string{alias + ".laundry_id = laundries.id", alias + ".is_active"}
+	var args []interface{}
+	if len(f.Categories) > 0 {
+		conditions = append(conditions, alias+".category IN ?")
+		args = append(args, f.Categories)
+	}
+	if f.PriceUnit != "" {
+		conditions = append(conditions, alias+".unit = ?")
+		args = append(args, f.PriceUnit)
+	}
+	if f.MinPrice != nil {
+		conditions = append(conditions, alias+".price >= ?")
+		args = append(args, *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		conditions = append(conditions, alias+".price <= ?")
+		args = append(args, *f.MaxPrice)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *laundryRepository) FindByOwnerID(ownerID uuid.UUID) ([]models.Laundry, error) {
 	var laundries []models.Laundry
 	err := r.db.Where("owner_id = ?", ownerID).Find(&laundries).Error
@@ -79,4 +263,3 @@ func (r *laundryRepository) Update(laundry *models.Laundry) error {
 func (r *laundryRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Laundry{}, id).Error
 }
-
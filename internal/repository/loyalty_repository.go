@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoyaltyRepository interface {
+	WithTx(tx *gorm.DB) LoyaltyRepository
+	Create(entry *models.LoyaltyLedgerEntry) error
+	FindByOrderAndType(orderID uuid.UUID, entryType string) (*models.LoyaltyLedgerEntry, error)
+	FindRedeemableForUpdate(userID uuid.UUID, now time.Time) ([]models.LoyaltyLedgerEntry, error)
+	UpdateRemaining(id uuid.UUID, remaining int64) error
+	UpdatePoints(id uuid.UUID, points int64) error
+	GetBalance(userID uuid.UUID, now time.Time) (int64, error)
+	FindByUserID(userID uuid.UUID, page, limit int) ([]models.LoyaltyLedgerEntry, int64, error)
+}
+
+type loyaltyRepository struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepository(db *gorm.DB) LoyaltyRepository {
+	return &loyaltyRepository{db: db}
+}
+
+func (r *loyaltyRepository) WithTx(tx *gorm.DB) LoyaltyRepository {
+	return &loyaltyRepository{db: tx}
+}
+
+func (r *loyaltyRepository) Create(entry *models.LoyaltyLedgerEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *loyaltyRepository) FindByOrderAndType(orderID uuid.UUID, entryType string) (*models.LoyaltyLedgerEntry, error) {
+	var entry models.LoyaltyLedgerEntry
+	err := r.db.Where("order_id = ? AND type = ?", orderID, entryType).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindRedeemableForUpdate locks the user's unexpired credit entries that still
+// have points left, soonest-expiring first.
+func (r *loyaltyRepository) FindRedeemableForUpdate(userID uuid.UUID, now time.Time) ([]models.LoyaltyLedgerEntry, error) {
+	var entries []models.LoyaltyLedgerEntry
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND remaining_points > 0 AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Order("expires_at ASC NULLS LAST, created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *loyaltyRepository) UpdateRemaining(id uuid.UUID, remaining int64) error {
+	return r.db.Model(&models.LoyaltyLedgerEntry{}).Where("id = ?", id).
+		UpdateColumn("remaining_points", remaining).Error
+}
+
+func (r *loyaltyRepository) UpdatePoints(id uuid.UUID, points int64) error {
+	return r.db.Model(&models.LoyaltyLedgerEntry{}).Where("id = ?", id).
+		UpdateColumn("points", points).Error
+}
+
+func (r *loyaltyRepository) GetBalance(userID uuid.UUID, now time.Time) (int64, error) {
+	var balance int64
+	err := r.db.Model(&models.LoyaltyLedgerEntry{}).
+		Where("user_id = ? AND remaining_points > 0 AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Select("COALESCE(SUM(remaining_points), 0)").
+		Scan(&balance).Error
+	return balance, err
+}
+
+func (r *loyaltyRepository) FindByUserID(userID uuid.UUID, page, limit int) ([]models.LoyaltyLedgerEntry, int64, error) {
+	var entries []models.LoyaltyLedgerEntry
+	var total int64
+
+	query := r.db.Model(&models.LoyaltyLedgerEntry{}).Where("user_id = ?", userID)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&entries).Error
+
+	return entries, total, err
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	WithTx(tx *gorm.DB) NotificationRepository
+	Create(notification *models.Notification) error
+	Update(notification *models.Notification) error
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]models.Notification, error)
+	FindByUserID(userID uuid.UUID, page, limit int) ([]models.Notification, int64, error)
+	FindPreference(userID uuid.UUID) (*models.NotificationPreference, error)
+	SavePreference(preference *models.NotificationPreference) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) WithTx(tx *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: tx}
+}
+
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) Update(notification *models.Notification) error {
+	return r.db.Save(notification).Error
+}
+
+// ClaimDue marks due notifications as sending until leaseUntil and counts
+// the attempt. Notifications whose lease ran out, because their dispatcher
+// stopped mid-send, are due again. Rows locked by another dispatcher are
+// skipped, so several instances can dispatch concurrently. Call it inside a
+// transaction and send after it commits.
+func (r *notificationRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status IN ? AND next_attempt_at <= ?", []string{models.NotificationStatusPending, models.NotificationStatusSending}, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&notifications).Error
+	if err != nil || len(notifications) == 0 {
+		return notifications, err
+	}
+
+	ids := make([]uuid.UUID, len(notifications))
+	for i := range notifications {
+		ids[i] = notifications[i].ID
+		notifications[i].Status = models.NotificationStatusSending
+		notifications[i].NextAttemptAt = leaseUntil
+		notifications[i].Attempts++
+	}
+	err = r.db.Model(&models.Notification{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":          models.NotificationStatusSending,
+		"next_attempt_at": leaseUntil,
+		"attempts":        gorm.Expr("attempts + 1"),
+	}).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) FindByUserID(userID uuid.UUID, page, limit int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&notifications).Error
+	return notifications, total, err
+}
+
+// FindPreference returns the user's saved preference, or the defaults if
+// they never saved one.
+func (r *notificationRepository) FindPreference(userID uuid.UUID) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultNotificationPreference(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *notificationRepository) SavePreference(preference *models.NotificationPreference) error {
+	return r.db.Save(preference).Error
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderAdjustmentRepository interface {
+	WithTx(tx *gorm.DB) OrderAdjustmentRepository
+	Create(adjustment *models.OrderAdjustment) error
+	FindByID(id uuid.UUID) (*models.OrderAdjustment, error)
+	FindByIDForUpdate(id uuid.UUID) (*models.OrderAdjustment, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.OrderAdjustment, error)
+	FindLatestByOrderID(orderID uuid.UUID) (*models.OrderAdjustment, error)
+	FindPendingByOrderIDForUpdate(orderID uuid.UUID) ([]models.OrderAdjustment, error)
+	Update(adjustment *models.OrderAdjustment) error
+}
+
+type orderAdjustmentRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderAdjustmentRepository(db *gorm.DB) OrderAdjustmentRepository {
+	return &orderAdjustmentRepository{db: db}
+}
+
+func (r *orderAdjustmentRepository) WithTx(tx *gorm.DB) OrderAdjustmentRepository {
+	return &orderAdjustmentRepository{db: tx}
+}
+
+// Create writes the adjustment and its lines. Should run inside a
+// transaction.
+func (r *orderAdjustmentRepository) Create(adjustment *models.OrderAdjustment) error {
+	if err := r.db.Omit(clause.Associations).Create(adjustment).Error; err != nil {
+		return err
+	}
+	for i := range adjustment.Lines {
+		adjustment.Lines[i].AdjustmentID = adjustment.ID
+	}
+	if len(adjustment.Lines) == 0 {
+		return nil
+	}
+	return r.db.Create(&adjustment.Lines).Error
+}
+
+func (r *orderAdjustmentRepository) FindByID(id uuid.UUID) (*models.OrderAdjustment, error) {
+	var adjustment models.OrderAdjustment
+	err := r.db.Preload("Lines").Where("id = ?", id).First(&adjustment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+func (r *orderAdjustmentRepository) FindByIDForUpdate(id uuid.UUID) (*models.OrderAdjustment, error) {
+	var adjustment models.OrderAdjustment
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&adjustment).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("adjustment_id = ?", id).Find(&adjustment.Lines).Error; err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+// FindByOrderID returns the order's adjustments, newest first.
+func (r *orderAdjustmentRepository) FindByOrderID(orderID uuid.UUID) ([]models.OrderAdjustment, error) {
+	var adjustments []models.OrderAdjustment
+	err := r.db.Preload("Lines").
+		Where("order_id = ?", orderID).
+		Order("created_at DESC").
+		Find(&adjustments).Error
+	return adjustments, err
+}
+
+// FindLatestByOrderID returns the newest adjustment that was not
+// superseded, or gorm.ErrRecordNotFound if the order has none.
+func (r *orderAdjustmentRepository) FindLatestByOrderID(orderID uuid.UUID) (*models.OrderAdjustment, error) {
+	var adjustment models.OrderAdjustment
+	err := r.db.Where("order_id = ? AND status <> ?", orderID, models.OrderAdjustmentSuperseded).
+		Order("created_at DESC").
+		First(&adjustment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+func (r *orderAdjustmentRepository) FindPendingByOrderIDForUpdate(orderID uuid.UUID) ([]models.OrderAdjustment, error) {
+	var adjustments []models.OrderAdjustment
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderID, models.OrderAdjustmentPending).
+		Find(&adjustments).Error
+	return adjustments, err
+}
+
+func (r *orderAdjustmentRepository) Update(adjustment *models.OrderAdjustment) error {
+	return r.db.Omit(clause.Associations).Save(adjustment).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderMessageRepository interface {
+	Create(message *models.OrderMessage) error
+	FindByID(id uuid.UUID) (*models.OrderMessage, error)
+	FindByOrderID(orderID uuid.UUID, before *time.Time, limit int) ([]models.OrderMessage, error)
+	CountUnread(orderID, userID uuid.UUID, after time.Time) (int64, error)
+	MarkRead(read *models.OrderChatRead) error
+	FindReads(orderID uuid.UUID) ([]models.OrderChatRead, error)
+}
+
+type orderMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderMessageRepository(db *gorm.DB) OrderMessageRepository {
+	return &orderMessageRepository{db: db}
+}
+
+func (r *orderMessageRepository) Create(message *models.OrderMessage) error {
+	return r.db.Omit(clause.Associations).Create(message).Error
+}
+
+func (r *orderMessageRepository) FindByID(id uuid.UUID) (*models.OrderMessage, error) {
+	var message models.OrderMessage
+	err := r.db.Preload("Sender").Where("id = ?", id).First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// FindByOrderID returns up to limit of the order's messages sent before
+// before (or the latest ones when nil), newest first.
+func (r *orderMessageRepository) FindByOrderID(orderID uuid.UUID, before *time.Time, limit int) ([]models.OrderMessage, error) {
+	var messages []models.OrderMessage
+	query := r.db.Preload("Sender").Where("order_id = ?", orderID)
+	if before != nil {
+		query = query.Where("created_at < ?", *before)
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// CountUnread counts messages from the other participants sent after
+// after.
+func (r *orderMessageRepository) CountUnread(orderID, userID uuid.UUID, after time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.OrderMessage{}).
+		Where("order_id = ? AND sender_id <> ? AND created_at > ?", orderID, userID, after).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead saves a read receipt. A receipt never moves backwards.
+func (r *orderMessageRepository) MarkRead(read *models.OrderChatRead) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "order_id"}, {Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_read_at": gorm.Expr("GREATEST(order_chat_reads.last_read_at, EXCLUDED.last_read_at)"),
+			"updated_at":   gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(read).Error
+}
+
+func (r *orderMessageRepository) FindReads(orderID uuid.UUID) ([]models.OrderChatRead, error) {
+	var reads []models.OrderChatRead
+	err := r.db.Where("order_id = ?", orderID).Find(&reads).Error
+	return reads, err
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderProofRepository interface {
+	WithTx(tx *gorm.DB) OrderProofRepository
+	Create(proof *models.OrderProof) error
+	FindByID(id uuid.UUID) (*models.OrderProof, error)
+	FindByOrderID(orderID uuid.UUID) ([]models.OrderProof, error)
+	HasSignature(orderID uuid.UUID, leg string) (bool, error)
+}
+
+type orderProofRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderProofRepository(db *gorm.DB) OrderProofRepository {
+	return &orderProofRepository{db: db}
+}
+
+func (r *orderProofRepository) WithTx(tx *gorm.DB) OrderProofRepository {
+	return &orderProofRepository{db: tx}
+}
+
+func (r *orderProofRepository) Create(proof *models.OrderProof) error {
+	return r.db.Omit(clause.Associations).Create(proof).Error
+}
+
+func (r *orderProofRepository) FindByID(id uuid.UUID) (*models.OrderProof, error) {
+	var proof models.OrderProof
+	err := r.db.Preload("Uploader").Where("id = ?", id).First(&proof).Error
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// FindByOrderID returns the order's proofs, pickup first, oldest first.
+func (r *orderProofRepository) FindByOrderID(orderID uuid.UUID) ([]models.OrderProof, error) {
+	var proofs []models.OrderProof
+	err := r.db.Preload("Uploader").
+		Where("order_id = ?", orderID).
+		Order("CASE WHEN leg = 'pickup' THEN 0 ELSE 1 END, created_at ASC").
+		Find(&proofs).Error
+	return proofs, err
+}
+
+func (r *orderProofRepository) HasSignature(orderID uuid.UUID, leg string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.OrderProof{}).
+		Where("order_id = ? AND leg = ? AND kind = ?", orderID, leg, models.ProofKindSignature).
+		Count(&count).Error
+	return count > 0, err
+}
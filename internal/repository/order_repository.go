@@ -2,17 +2,28 @@ package repository
 
 import (
 	"laundry-go/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository interface {
+	WithTx(tx *gorm.DB) OrderRepository
 	Create(order *models.Order) error
 	FindByID(id uuid.UUID) (*models.Order, error)
+	FindByIDForUpdate(id uuid.UUID) (*models.Order, error)
 	FindByUserID(userID uuid.UUID, status string, page, limit int) ([]models.Order, int64, error)
 	FindByLaundryID(laundryID uuid.UUID, status string, page, limit int) ([]models.Order, int64, error)
 	Update(order *models.Order) error
+	CountActiveByUserID(userID uuid.UUID) (int64, error)
+	FindOpenByLaundryIDs(laundryIDs []uuid.UUID) ([]models.Order, error)
+	ClaimExpiredPending(now time.Time) (*models.Order, error)
+	CreateStatusHistory(entry *models.OrderStatusHistory) error
+	FindStatusHistory(orderID uuid.UUID) ([]models.OrderStatusHistory, error)
+	FindRoutable(laundryID uuid.UUID, from, to time.Time) ([]models.Order, error)
+	ExistsForRecurrence(recurringOrderID uuid.UUID, pickupAt time.Time) (bool, error)
 }
 
 type orderRepository struct {
@@ -23,6 +34,10 @@ func NewOrderRepository(db *gorm.DB) OrderRepository {
 	return &orderRepository{db: db}
 }
 
+func (r *orderRepository) WithTx(tx *gorm.DB) OrderRepository {
+	return &orderRepository{db: tx}
+}
+
 func (r *orderRepository) Create(order *models.Order) error {
 	return r.db.Create(order).Error
 }
@@ -36,6 +51,23 @@ func (r *orderRepository) FindByID(id uuid.UUID) (*models.Order, error) {
 	return &order, nil
 }
 
+// FindByIDForUpdate locks the order row and loads it with its lines and
+// laundry. Must run inside a transaction.
+func (r *orderRepository) FindByIDForUpdate(id uuid.UUID) (*models.Order, error) {
+	var order models.Order
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&order).Association("OrderServices").Find(&order.OrderServices); err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("id = ?", order.LaundryID).First(&order.Laundry).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 func (r *orderRepository) FindByUserID(userID uuid.UUID, status string, page, limit int) ([]models.Order, int64, error) {
 	var orders []models.Order
 	var total int64
@@ -88,3 +120,85 @@ func (r *orderRepository) Update(order *models.Order) error {
 	return r.db.Save(order).Error
 }
 
+// CountActiveByUserID counts the user's orders that were not cancelled.
+func (r *orderRepository) CountActiveByUserID(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Order{}).
+		Where("user_id = ? AND status <> ?", userID, "cancelled").
+		Count(&count).Error
+	return count, err
+}
+
+// FindOpenByLaundryIDs returns the laundries' orders that are still in
+// progress, oldest first.
+func (r *orderRepository) FindOpenByLaundryIDs(laundryIDs []uuid.UUID) ([]models.Order, error) {
+	var orders []models.Order
+	if len(laundryIDs) == 0 {
+		return orders, nil
+	}
+	err := r.db.Preload("OrderServices").Preload("Laundry").
+		Where("laundry_id IN ? AND status NOT IN ?", laundryIDs, []string{"completed", "cancelled"}).
+		Order("created_at ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// ClaimExpiredPending locks one pending order whose confirmation deadline
+// has passed and that has not been picked up, skipping orders locked by
+// another worker. It returns nil when there is none. Must run inside a transaction.
+func (r *orderRepository) ClaimExpiredPending(now time.Time) (*models.Order, error) {
+	var orders []models.Order
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND confirm_by IS NOT NULL AND confirm_by <= ? AND actual_pickup_at IS NULL", "pending", now).
+		Order("confirm_by ASC").
+		Limit(1).
+		Find(&orders).Error
+	if err != nil || len(orders) == 0 {
+		return nil, err
+	}
+
+	order := &orders[0]
+	if err := r.db.Model(order).Association("OrderServices").Find(&order.OrderServices); err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("id = ?", order.LaundryID).First(&order.Laundry).Error; err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (r *orderRepository) CreateStatusHistory(entry *models.OrderStatusHistory) error {
+	return r.db.Create(entry).Error
+}
+
+// FindStatusHistory returns the order's status changes, oldest first.
+func (r *orderRepository) FindStatusHistory(orderID uuid.UUID) ([]models.OrderStatusHistory, error) {
+	var history []models.OrderStatusHistory
+	err := r.db.Where("order_id = ?", orderID).Order("created_at ASC").Find(&history).Error
+	return history, err
+}
+
+// FindRoutable returns the laundry's orders with a courier leg due in the
+// window: confirmed orders awaiting pickup and ready orders awaiting
+// delivery. Orders without an estimated time for the leg are included.
+func (r *orderRepository) FindRoutable(laundryID uuid.UUID, from, to time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.Preload("User").
+		Where("laundry_id = ?", laundryID).
+		Where(r.db.
+			Where("status = ? AND actual_pickup_at IS NULL AND (estimated_pickup_at IS NULL OR estimated_pickup_at BETWEEN ? AND ?)", "confirmed", from, to).
+			Or("status = ? AND actual_delivery_at IS NULL AND (estimated_delivery_at IS NULL OR estimated_delivery_at BETWEEN ? AND ?)", "ready", from, to)).
+		Order("created_at ASC").
+		Find(&orders).Error
+	return orders, err
+}
+
+// ExistsForRecurrence reports whether the recurring order already placed
+// its order for the pickup at pickupAt.
+func (r *orderRepository) ExistsForRecurrence(recurringOrderID uuid.UUID, pickupAt time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Order{}).
+		Where("recurring_order_id = ? AND estimated_pickup_at = ?", recurringOrderID, pickupAt).
+		Count(&count).Error
+	return count > 0, err
+}
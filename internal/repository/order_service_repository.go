@@ -4,12 +4,15 @@ import (
 	"laundry-go/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderServiceRepository interface {
+	WithTx(tx *gorm.DB) OrderServiceRepository
 	Create(orderService *models.OrderService) error
 	CreateBatch(orderServices []models.OrderService) error
 	FindByOrderID(orderID string) ([]models.OrderService, error)
+	UpdateQuantity(orderService *models.OrderService) error
 }
 
 type orderServiceRepository struct {
@@ -20,6 +23,10 @@ func NewOrderServiceRepository(db *gorm.DB) OrderServiceRepository {
 	return &orderServiceRepository{db: db}
 }
 
+func (r *orderServiceRepository) WithTx(tx *gorm.DB) OrderServiceRepository {
+	return &orderServiceRepository{db: tx}
+}
+
 func (r *orderServiceRepository) Create(orderService *models.OrderService) error {
 	return r.db.Create(orderService).Error
 }
@@ -34,3 +41,11 @@ func (r *orderServiceRepository) FindByOrderID(orderID string) ([]models.OrderSe
 	return orderServices, err
 }
 
+// UpdateQuantity saves a line's quantity, quota and subtotal.
+func (r *orderServiceRepository) UpdateQuantity(orderService *models.OrderService) error {
+	return r.db.Model(orderService).Omit(clause.Associations).Updates(map[string]interface{}{
+		"quantity":       orderService.Quantity,
+		"quota_quantity": orderService.QuotaQuantity,
+		"subtotal":       orderService.Subtotal,
+	}).Error
+}
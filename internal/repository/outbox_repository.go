@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	WithTx(tx *gorm.DB) OutboxRepository
+	Create(event *models.OutboxEvent) error
+	ClaimUndispatched(limit int) ([]models.OutboxEvent, error)
+	MarkDispatched(ids []uuid.UUID, dispatchedAt time.Time) error
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) WithTx(tx *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: tx}
+}
+
+func (r *outboxRepository) Create(event *models.OutboxEvent) error {
+	return r.db.Create(event).Error
+}
+
+// ClaimUndispatched locks the oldest events not yet relayed, skipping rows
+// another relay holds. Call it inside a transaction.
+func (r *outboxRepository) ClaimUndispatched(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("dispatched_at IS NULL").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *outboxRepository) MarkDispatched(ids []uuid.UUID, dispatchedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Update("dispatched_at", dispatchedAt).Error
+}
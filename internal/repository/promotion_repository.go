@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromotionRepository interface {
+	WithTx(tx *gorm.DB) PromotionRepository
+	Create(promotion *models.Promotion) error
+	FindByID(id uuid.UUID) (*models.Promotion, error)
+	FindByCode(code string) (*models.Promotion, error)
+	FindByCodeForUpdate(code string) (*models.Promotion, error)
+	FindAll(laundryIDs []uuid.UUID, includePlatform bool) ([]models.Promotion, error)
+	Update(promotion *models.Promotion) error
+	IncrementUsage(id uuid.UUID) error
+	CreateRedemption(redemption *models.PromotionRedemption) error
+	CountRedemptionsByUser(promotionID, userID uuid.UUID) (int64, error)
+	UpdateRedemptionDiscount(orderID uuid.UUID, discount models.Money) error
+	ReleaseRedemption(orderID uuid.UUID) error
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) WithTx(tx *gorm.DB) PromotionRepository {
+	return &promotionRepository{db: tx}
+}
+
+func (r *promotionRepository) Create(promotion *models.Promotion) error {
+	return r.db.Create(promotion).Error
+}
+
+func (r *promotionRepository) FindByID(id uuid.UUID) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.Where("id = ?", id).First(&promotion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) FindByCode(code string) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.Where("code = ?", code).First(&promotion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// FindByCodeForUpdate locks the promotion row until the surrounding
+// transaction ends, serialising concurrent redemptions of the same code.
+func (r *promotionRepository) FindByCodeForUpdate(code string) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&promotion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) FindAll(laundryIDs []uuid.UUID, includePlatform bool) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+
+	query := r.db.Model(&models.Promotion{})
+	switch {
+	case includePlatform && len(laundryIDs) > 0:
+		query = query.Where("laundry_id IS NULL OR laundry_id IN ?", laundryIDs)
+	case includePlatform:
+		query = query.Where("laundry_id IS NULL")
+	case len(laundryIDs) > 0:
+		query = query.Where("laundry_id IN ?", laundryIDs)
+	default:
+		return promotions, nil
+	}
+
+	err := query.Order("created_at DESC").Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *promotionRepository) Update(promotion *models.Promotion) error {
+	return r.db.Save(promotion).Error
+}
+
+func (r *promotionRepository) IncrementUsage(id uuid.UUID) error {
+	return r.db.Model(&models.Promotion{}).Where("id = ?", id).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1")).Error
+}
+
+func (r *promotionRepository) CreateRedemption(redemption *models.PromotionRedemption) error {
+	return r.db.Create(redemption).Error
+}
+
+func (r *promotionRepository) CountRedemptionsByUser(promotionID, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.PromotionRedemption{}).
+		Where("promotion_id = ? AND user_id = ?", promotionID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateRedemptionDiscount records a changed discount on the order's
+// redemption, after its quantities were adjusted.
+func (r *promotionRepository) UpdateRedemptionDiscount(orderID uuid.UUID, discount models.Money) error {
+	return r.db.Model(&models.PromotionRedemption{}).Where("order_id = ?", orderID).
+		Update("discount_amount", discount).Error
+}
+
+// ReleaseRedemption deletes the order's redemption and gives its use back to
+// the promotion, so a cancelled order no longer counts against either limit.
+func (r *promotionRepository) ReleaseRedemption(orderID uuid.UUID) error {
+	var redemptions []models.PromotionRedemption
+	err := r.db.Clauses(clause.Returning{}).Where("order_id = ?", orderID).Delete(&redemptions).Error
+	if err != nil {
+		return err
+	}
+	for _, redemption := range redemptions {
+		err := r.db.Model(&models.Promotion{}).Where("id = ? AND used_count > 0", redemption.PromotionID).
+			UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecurringOrderRepository interface {
+	WithTx(tx *gorm.DB) RecurringOrderRepository
+	Create(recurring *models.RecurringOrder) error
+	FindByID(id uuid.UUID) (*models.RecurringOrder, error)
+	FindByIDForUpdate(id uuid.UUID) (*models.RecurringOrder, error)
+	FindByUserID(userID uuid.UUID, status string, page, limit int) ([]models.RecurringOrder, int64, error)
+	ClaimDue(horizon time.Time, exclude []uuid.UUID) (*models.RecurringOrder, error)
+	Update(recurring *models.RecurringOrder) error
+}
+
+type recurringOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewRecurringOrderRepository(db *gorm.DB) RecurringOrderRepository {
+	return &recurringOrderRepository{db: db}
+}
+
+func (r *recurringOrderRepository) WithTx(tx *gorm.DB) RecurringOrderRepository {
+	return &recurringOrderRepository{db: tx}
+}
+
+// Create writes the template and its lines. Should run inside a
+// transaction.
+func (r *recurringOrderRepository) Create(recurring *models.RecurringOrder) error {
+	if err := r.db.Omit(clause.Associations).Create(recurring).Error; err != nil {
+		return err
+	}
+	for i := range recurring.Lines {
+		recurring.Lines[i].RecurringOrderID = recurring.ID
+	}
+	return r.db.Omit(clause.Associations).Create(&recurring.Lines).Error
+}
+
+func (r *recurringOrderRepository) FindByID(id uuid.UUID) (*models.RecurringOrder, error) {
+	var recurring models.RecurringOrder
+	err := r.db.Preload("Laundry").Preload("Lines.Service").Where("id = ?", id).First(&recurring).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+func (r *recurringOrderRepository) FindByIDForUpdate(id uuid.UUID) (*models.RecurringOrder, error) {
+	var recurring models.RecurringOrder
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&recurring).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+// FindByUserID lists the user's recurring orders, newest first.
+func (r *recurringOrderRepository) FindByUserID(userID uuid.UUID, status string, page, limit int) ([]models.RecurringOrder, int64, error) {
+	var recurring []models.RecurringOrder
+	var total int64
+
+	query := r.db.Model(&models.RecurringOrder{}).Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Laundry").Preload("Lines.Service").
+		Order("created_at DESC").Offset(offset).Limit(limit).Find(&recurring).Error
+	return recurring, total, err
+}
+
+// ClaimDue locks one active template whose next pickup is at or before
+// horizon, skipping templates in exclude and those locked by another
+// worker. It returns nil when there is none. Must run inside a transaction.
+func (r *recurringOrderRepository) ClaimDue(horizon time.Time, exclude []uuid.UUID) (*models.RecurringOrder, error) {
+	var due []models.RecurringOrder
+	query := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_pickup_at IS NOT NULL AND next_pickup_at <= ?", models.RecurringOrderActive, horizon)
+	if len(exclude) > 0 {
+		query = query.Where("id NOT IN ?", exclude)
+	}
+	err := query.Order("next_pickup_at ASC").
+		Limit(1).
+		Find(&due).Error
+	if err != nil || len(due) == 0 {
+		return nil, err
+	}
+
+	recurring := &due[0]
+	if err := r.db.Preload("Service").Where("recurring_order_id = ?", recurring.ID).Find(&recurring.Lines).Error; err != nil {
+		return nil, err
+	}
+	// A deleted laundry is left zero, which reads as closed
+	if err := r.db.Where("id = ?", recurring.LaundryID).Limit(1).Find(&recurring.Laundry).Error; err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+func (r *recurringOrderRepository) Update(recurring *models.RecurringOrder) error {
+	return r.db.Omit(clause.Associations).Save(recurring).Error
+}
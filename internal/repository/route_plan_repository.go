@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoutePlanRepository interface {
+	WithTx(tx *gorm.DB) RoutePlanRepository
+	Create(plan *models.RoutePlan) error
+	FindByID(id uuid.UUID) (*models.RoutePlan, error)
+	FindByLaundryID(laundryID uuid.UUID, page, limit int) ([]models.RoutePlan, int64, error)
+	FindStopsForCourier(courierID uuid.UUID, windowEndAfter time.Time) ([]models.RoutePlanStop, error)
+}
+
+type routePlanRepository struct {
+	db *gorm.DB
+}
+
+func NewRoutePlanRepository(db *gorm.DB) RoutePlanRepository {
+	return &routePlanRepository{db: db}
+}
+
+func (r *routePlanRepository) WithTx(tx *gorm.DB) RoutePlanRepository {
+	return &routePlanRepository{db: tx}
+}
+
+// Create saves the plan together with its stops. Should run inside a
+// transaction.
+func (r *routePlanRepository) Create(plan *models.RoutePlan) error {
+	if err := r.db.Omit(clause.Associations).Create(plan).Error; err != nil {
+		return err
+	}
+	for i := range plan.Stops {
+		plan.Stops[i].PlanID = plan.ID
+	}
+	if len(plan.Stops) == 0 {
+		return nil
+	}
+	return r.db.Omit(clause.Associations).Create(&plan.Stops).Error
+}
+
+func (r *routePlanRepository) FindByID(id uuid.UUID) (*models.RoutePlan, error) {
+	var plan models.RoutePlan
+	err := r.db.Preload("Stops", func(db *gorm.DB) *gorm.DB {
+		return db.Order("courier_id NULLS LAST, sequence ASC")
+	}).Preload("Stops.Courier").Preload("Stops.Order.User").
+		Where("id = ?", id).First(&plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// FindByLaundryID returns the laundry's plans without their stops, newest
+// first.
+func (r *routePlanRepository) FindByLaundryID(laundryID uuid.UUID, page, limit int) ([]models.RoutePlan, int64, error) {
+	var plans []models.RoutePlan
+	var total int64
+
+	query := r.db.Model(&models.RoutePlan{}).Where("laundry_id = ?", laundryID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&plans).Error
+	return plans, total, err
+}
+
+// FindStopsForCourier returns the courier's stops from the latest plan of
+// each laundry whose window has not ended, in run order.
+func (r *routePlanRepository) FindStopsForCourier(courierID uuid.UUID, windowEndAfter time.Time) ([]models.RoutePlanStop, error) {
+	latest := r.db.Model(&models.RoutePlan{}).
+		Select("DISTINCT ON (route_plans.laundry_id) route_plans.id").
+		Joins("JOIN route_plan_stops ON route_plan_stops.plan_id = route_plans.id").
+		Where("route_plan_stops.courier_id = ? AND route_plans.window_end > ?", courierID, windowEndAfter).
+		Order("route_plans.laundry_id, route_plans.created_at DESC")
+
+	var stops []models.RoutePlanStop
+	err := r.db.Preload("Order.User").Preload("Order.Laundry").
+		Where("courier_id = ? AND plan_id IN (?)", courierID, latest).
+		Order("plan_id, sequence ASC").
+		Find(&stops).Error
+	return stops, err
+}
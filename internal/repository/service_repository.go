@@ -13,7 +13,14 @@ type ServiceRepository interface {
 	FindByLaundryID(laundryID uuid.UUID) ([]models.Service, error)
 	Update(service *models.Service) error
 	Delete(id uuid.UUID) error
-	GetPriceRange(laundryID uuid.UUID) (minPrice, maxPrice float64, err error)
+	GetPriceRange(laundryID uuid.UUID) (minPrice, maxPrice models.Money, err error)
+	GetPriceRanges(laundryIDs []uuid.UUID) (map[uuid.UUID]PriceRange, error)
+}
+
+// PriceRange is the cheapest and dearest active service of a laundry.
+type PriceRange struct {
+	Min models.Money
+	Max models.Money
 }
 
 type serviceRepository struct {
@@ -51,10 +58,10 @@ func (r *serviceRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Service{}, id).Error
 }
 
-func (r *serviceRepository) GetPriceRange(laundryID uuid.UUID) (minPrice, maxPrice float64, err error) {
+func (r *serviceRepository) GetPriceRange(laundryID uuid.UUID) (minPrice, maxPrice models.Money, err error) {
 	var result struct {
-		MinPrice float64
-		MaxPrice float64
+		MinPrice models.Money
+		MaxPrice models.Money
 	}
 
 	err = r.db.Model(&models.Service{}).
@@ -69,3 +76,30 @@ func (r *serviceRepository) GetPriceRange(laundryID uuid.UUID) (minPrice, maxPri
 	return result.MinPrice, result.MaxPrice, nil
 }
 
+// GetPriceRanges returns the price range of each of laundryIDs in one
+// query. Laundries without active services are left out.
+func (r *serviceRepository) GetPriceRanges(laundryIDs []uuid.UUID) (map[uuid.UUID]PriceRange, error) {
+	ranges := make(map[uuid.UUID]PriceRange, len(laundryIDs))
+	if len(laundryIDs) == 0 {
+		return ranges, nil
+	}
+
+	var rows []struct {
+		LaundryID uuid.UUID
+		MinPrice  models.Money
+		MaxPrice  models.Money
+	}
+	err := r.db.Model(&models.Service{}).
+		Where("laundry_id IN ? AND is_active = ?", laundryIDs, true).
+		Select("laundry_id, MIN(price) as min_price, MAX(price) as max_price").
+		Group("laundry_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		ranges[row.LaundryID] = PriceRange{Min: row.MinPrice, Max: row.MaxPrice}
+	}
+	return ranges, nil
+}
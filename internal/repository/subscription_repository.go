@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubscriptionRepository interface {
+	WithTx(tx *gorm.DB) SubscriptionRepository
+	CreatePackage(pkg *models.SubscriptionPackage) error
+	FindPackageByID(id uuid.UUID) (*models.SubscriptionPackage, error)
+	FindPackagesByLaundryID(laundryID uuid.UUID, activeOnly bool) ([]models.SubscriptionPackage, error)
+	UpdatePackage(pkg *models.SubscriptionPackage) error
+	Create(subscription *models.Subscription) error
+	FindByID(id uuid.UUID) (*models.Subscription, error)
+	FindByIDForUpdate(id uuid.UUID) (*models.Subscription, error)
+	FindByUserID(userID uuid.UUID) ([]models.Subscription, error)
+	FindActiveForUpdate(userID, laundryID uuid.UUID) (*models.Subscription, error)
+	ClaimEnded(now time.Time) (*models.Subscription, error)
+	Update(subscription *models.Subscription) error
+	CreateUsage(usage *models.SubscriptionUsage) error
+	FindUsageBySubscriptionID(subscriptionID uuid.UUID) ([]models.SubscriptionUsage, error)
+	FindUsageByOrderID(orderID uuid.UUID) ([]models.SubscriptionUsage, error)
+	DeleteUsageByOrderID(orderID uuid.UUID) error
+	UpdateUsage(usage *models.SubscriptionUsage) error
+}
+
+type subscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) SubscriptionRepository {
+	return &subscriptionRepository{db: db}
+}
+
+func (r *subscriptionRepository) WithTx(tx *gorm.DB) SubscriptionRepository {
+	return &subscriptionRepository{db: tx}
+}
+
+func (r *subscriptionRepository) CreatePackage(pkg *models.SubscriptionPackage) error {
+	return r.db.Omit("Laundry").Create(pkg).Error
+}
+
+func (r *subscriptionRepository) FindPackageByID(id uuid.UUID) (*models.SubscriptionPackage, error) {
+	var pkg models.SubscriptionPackage
+	err := r.db.Preload("Laundry").Where("id = ?", id).First(&pkg).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+func (r *subscriptionRepository) FindPackagesByLaundryID(laundryID uuid.UUID, activeOnly bool) ([]models.SubscriptionPackage, error) {
+	var packages []models.SubscriptionPackage
+	query := r.db.Where("laundry_id = ?", laundryID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("price ASC").Find(&packages).Error
+	return packages, err
+}
+
+func (r *subscriptionRepository) UpdatePackage(pkg *models.SubscriptionPackage) error {
+	return r.db.Omit("Laundry").Save(pkg).Error
+}
+
+func (r *subscriptionRepository) Create(subscription *models.Subscription) error {
+	return r.db.Omit("Package").Create(subscription).Error
+}
+
+func (r *subscriptionRepository) FindByID(id uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.Preload("Package").Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *subscriptionRepository) FindByIDForUpdate(id uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *subscriptionRepository) FindByUserID(userID uuid.UUID) ([]models.Subscription, error) {
+	var subscriptions []models.Subscription
+	err := r.db.Preload("Package").Where("user_id = ?", userID).
+		Order("created_at DESC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// FindActiveForUpdate locks the user's active subscription at a laundry. The
+// period may already have ended; callers settle renewal or expiry first.
+func (r *subscriptionRepository) FindActiveForUpdate(userID, laundryID uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND laundry_id = ? AND status = ?", userID, laundryID, models.SubscriptionStatusActive).
+		First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// ClaimEnded locks one active subscription whose period ended by now,
+// skipping those locked by another worker. It returns nil when there is
+// none. Must run inside a transaction.
+func (r *subscriptionRepository) ClaimEnded(now time.Time) (*models.Subscription, error) {
+	var ended []models.Subscription
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND period_end <= ?", models.SubscriptionStatusActive, now).
+		Order("period_end ASC").
+		Limit(1).
+		Find(&ended).Error
+	if err != nil || len(ended) == 0 {
+		return nil, err
+	}
+	return &ended[0], nil
+}
+
+func (r *subscriptionRepository) Update(subscription *models.Subscription) error {
+	return r.db.Omit("Package").Save(subscription).Error
+}
+
+func (r *subscriptionRepository) CreateUsage(usage *models.SubscriptionUsage) error {
+	return r.db.Create(usage).Error
+}
+
+func (r *subscriptionRepository) FindUsageBySubscriptionID(subscriptionID uuid.UUID) ([]models.SubscriptionUsage, error) {
+	var usages []models.SubscriptionUsage
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("created_at DESC").Find(&usages).Error
+	return usages, err
+}
+
+func (r *subscriptionRepository) FindUsageByOrderID(orderID uuid.UUID) ([]models.SubscriptionUsage, error) {
+	var usages []models.SubscriptionUsage
+	err := r.db.Where("order_id = ?", orderID).Find(&usages).Error
+	return usages, err
+}
+
+func (r *subscriptionRepository) DeleteUsageByOrderID(orderID uuid.UUID) error {
+	return r.db.Where("order_id = ?", orderID).Delete(&models.SubscriptionUsage{}).Error
+}
+
+func (r *subscriptionRepository) UpdateUsage(usage *models.SubscriptionUsage) error {
+	return r.db.Model(usage).Update("quantity", usage.Quantity).Error
+}
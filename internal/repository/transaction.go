@@ -0,0 +1,21 @@
+package repository
+
+import "gorm.io/gorm"
+
+// Transactor runs a unit of work inside a single database transaction.
+// Repositories bound with WithTx(tx) inside fn share that transaction.
+type Transactor interface {
+	WithinTransaction(fn func(tx *gorm.DB) error) error
+}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(fn func(tx *gorm.DB) error) error {
+	return t.db.Transaction(fn)
+}
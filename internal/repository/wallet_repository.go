@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WalletRepository interface {
+	WithTx(tx *gorm.DB) WalletRepository
+	EnsureAccount(account *models.WalletAccount) error
+	FindAccountByID(id uuid.UUID) (*models.WalletAccount, error)
+	FindAccountForUpdate(id uuid.UUID) (*models.WalletAccount, error)
+	FindAccountsByUserID(userID uuid.UUID) ([]models.WalletAccount, error)
+	UpdateBalance(id uuid.UUID, balance models.Money) error
+	CreateTransaction(transaction *models.WalletTransaction) error
+	CreateEntries(entries []models.WalletEntry) error
+	FindTransactionByOrder(orderID uuid.UUID, txType string) (*models.WalletTransaction, error)
+	FindEntriesByAccountID(accountID uuid.UUID, page, limit int) ([]models.WalletEntry, int64, error)
+}
+
+type walletRepository struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) WalletRepository {
+	return &walletRepository{db: db}
+}
+
+func (r *walletRepository) WithTx(tx *gorm.DB) WalletRepository {
+	return &walletRepository{db: tx}
+}
+
+// EnsureAccount inserts the account unless one with the same ID already exists.
+func (r *walletRepository) EnsureAccount(account *models.WalletAccount) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(account).Error
+}
+
+func (r *walletRepository) FindAccountByID(id uuid.UUID) (*models.WalletAccount, error) {
+	var account models.WalletAccount
+	err := r.db.Where("id = ?", id).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *walletRepository) FindAccountForUpdate(id uuid.UUID) (*models.WalletAccount, error) {
+	var account models.WalletAccount
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *walletRepository) FindAccountsByUserID(userID uuid.UUID) ([]models.WalletAccount, error) {
+	var accounts []models.WalletAccount
+	err := r.db.Where("user_id = ? AND kind = ?", userID, models.WalletAccountCustomer).
+		Order("currency ASC").Find(&accounts).Error
+	return accounts, err
+}
+
+func (r *walletRepository) UpdateBalance(id uuid.UUID, balance models.Money) error {
+	return r.db.Model(&models.WalletAccount{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"balance": balance, "updated_at": gorm.Expr("CURRENT_TIMESTAMP")}).Error
+}
+
+func (r *walletRepository) CreateTransaction(transaction *models.WalletTransaction) error {
+	return r.db.Omit("Entries").Create(transaction).Error
+}
+
+func (r *walletRepository) CreateEntries(entries []models.WalletEntry) error {
+	return r.db.Omit("Transaction").Create(&entries).Error
+}
+
+func (r *walletRepository) FindTransactionByOrder(orderID uuid.UUID, txType string) (*models.WalletTransaction, error) {
+	var transaction models.WalletTransaction
+	err := r.db.Where("order_id = ? AND type = ?", orderID, txType).First(&transaction).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+func (r *walletRepository) FindEntriesByAccountID(accountID uuid.UUID, page, limit int) ([]models.WalletEntry, int64, error) {
+	var entries []models.WalletEntry
+	var total int64
+
+	query := r.db.Model(&models.WalletEntry{}).Where("account_id = ?", accountID)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	offset := (page - 1) * limit
+	err := query.Preload("Transaction").
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).Find(&entries).Error
+
+	return entries, total, err
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to TEST_DATABASE_URL and creates the wallet tables.
+// Tests that need Postgres are skipped when it is not set.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.WalletAccount{}, &models.WalletTransaction{}, &models.WalletEntry{}); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// testWalletAccount creates a committed account and removes it when the test ends.
+func testWalletAccount(t *testing.T, db *gorm.DB, kind string, balance models.Money) *models.WalletAccount {
+	t.Helper()
+	userID := uuid.New()
+	account := &models.WalletAccount{Kind: kind, Currency: "IDR", Balance: balance}
+	if kind == models.WalletAccountCustomer {
+		account.ID = models.CustomerWalletAccountID(userID, "IDR")
+		account.UserID = &userID
+	} else {
+		account.ID = uuid.New()
+	}
+	if err := db.Create(account).Error; err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("account_id = ?", account.ID).Delete(&models.WalletEntry{})
+		db.Where("id = ?", account.ID).Delete(&models.WalletAccount{})
+	})
+	return account
+}
+
+func TestWalletEnsureAccountKeepsExisting(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewWalletRepository(db)
+	account := testWalletAccount(t, db, models.WalletAccountCustomer, models.NewMoney(5000))
+
+	again := &models.WalletAccount{ID: account.ID, UserID: account.UserID, Kind: account.Kind, Currency: account.Currency}
+	if err := repo.EnsureAccount(again); err != nil {
+		t.Fatalf("EnsureAccount on an existing account: %v", err)
+	}
+
+	var count int64
+	db.Model(&models.WalletAccount{}).Where("id = ?", account.ID).Count(&count)
+	if count != 1 {
+		t.Fatalf("%d accounts with ID %s, want 1", count, account.ID)
+	}
+	stored, err := repo.FindAccountByID(account.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Balance != models.NewMoney(5000) {
+		t.Errorf("balance = %s after EnsureAccount, want 5000.00", stored.Balance)
+	}
+}
+
+func TestWalletCustomerBalanceCannotGoNegative(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewWalletRepository(db)
+	customer := testWalletAccount(t, db, models.WalletAccountCustomer, 0)
+	revenue := testWalletAccount(t, db, models.WalletAccountRevenue, 0)
+
+	if err := repo.UpdateBalance(customer.ID, -1); err == nil {
+		t.Error("a customer balance below zero was accepted")
+	}
+	// System accounts are the counterparty of money entering the platform
+	if err := repo.UpdateBalance(revenue.ID, -models.NewMoney(100)); err != nil {
+		t.Errorf("a negative system balance was rejected: %v", err)
+	}
+}
+
+func TestWalletFindAccountForUpdateLocks(t *testing.T) {
+	db := openTestDB(t)
+	account := testWalletAccount(t, db, models.WalletAccountCustomer, 0)
+
+	holder := db.Begin()
+	defer holder.Rollback()
+	if _, err := NewWalletRepository(holder).FindAccountForUpdate(account.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	waiter := db.Begin()
+	defer waiter.Rollback()
+	if err := waiter.Exec("SET LOCAL lock_timeout = '200ms'").Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewWalletRepository(waiter).FindAccountForUpdate(account.ID); err == nil {
+		t.Error("a second transaction locked an account that is already locked")
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"laundry-go/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	WithTx(tx *gorm.DB) WebhookRepository
+	Create(webhook *models.Webhook) error
+	FindByID(id uuid.UUID) (*models.Webhook, error)
+	FindByLaundryID(laundryID uuid.UUID) ([]models.Webhook, error)
+	FindActiveByLaundryID(laundryID uuid.UUID) ([]models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	Delete(id uuid.UUID) error
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	CreateDeliveryOnce(delivery *models.WebhookDelivery) (bool, error)
+	FindDeliveryByID(id uuid.UUID) (*models.WebhookDelivery, error)
+	FindDeliveriesByWebhookID(webhookID uuid.UUID, page, limit int) ([]models.WebhookDelivery, int64, error)
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) WithTx(tx *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: tx}
+}
+
+func (r *webhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *webhookRepository) FindByID(id uuid.UUID) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) FindByLaundryID(laundryID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("laundry_id = ?", laundryID).Order("created_at ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) FindActiveByLaundryID(laundryID uuid.UUID) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("laundry_id = ? AND is_active = ?", laundryID, true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete removes the webhook together with its delivery log.
+func (r *webhookRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Webhook{}, id).Error
+	})
+}
+
+func (r *webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// CreateDeliveryOnce creates the delivery unless the event was already
+// delivered to the webhook, and reports whether it did.
+func (r *webhookRepository) CreateDeliveryOnce(delivery *models.WebhookDelivery) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *webhookRepository) FindDeliveryByID(id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) FindDeliveriesByWebhookID(webhookID uuid.UUID, page, limit int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *webhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
@@ -0,0 +1,216 @@
+// Package routing plans courier runs with a nearest-neighbour construction
+// followed by 2-opt improvement, respecting stop time windows and a per
+// courier stop limit.
+package routing
+
+import (
+	"laundry-go/internal/utils"
+	"time"
+)
+
+// maxImprovementPasses bounds 2-opt so planning stays fast on large days.
+const maxImprovementPasses = 50
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Stop is a place a courier must visit between WindowStart and WindowEnd.
+// Arriving early means waiting until WindowStart.
+type Stop struct {
+	ID string
+	Point
+	WindowStart time.Time
+	WindowEnd   time.Time
+}
+
+// Vehicle is a courier that can make at most Capacity stops in one run.
+type Vehicle struct {
+	ID       string
+	Capacity int
+}
+
+type Options struct {
+	Start        time.Time     // when couriers leave the depot
+	SpeedKmh     float64       // average travel speed
+	ServiceTime  time.Duration // time spent at each stop
+	DetourFactor float64       // road distance over straight-line distance
+}
+
+// Visit is a stop in a planned route.
+type Visit struct {
+	Stop       Stop
+	Arrival    time.Time // when the courier gets there
+	Start      time.Time // when service starts, after any wait for the window
+	DistanceKm float64   // from the previous point
+}
+
+// Route is one courier's run, starting and ending at the depot.
+type Route struct {
+	VehicleID  string
+	Visits     []Visit
+	DistanceKm float64 // including the return to the depot
+	Finish     time.Time
+}
+
+type Result struct {
+	Routes     []Route
+	Unassigned []Stop // stops no courier could reach in time or fit
+}
+
+// Plan builds one route per vehicle. Vehicles are filled in order: each
+// takes the nearest stop it can still reach within its window until it is
+// full or nothing reachable remains, and its route is then shortened with
+// 2-opt moves that keep every window.
+func Plan(depot Point, stops []Stop, vehicles []Vehicle, opts Options) Result {
+	if opts.DetourFactor <= 0 {
+		opts.DetourFactor = 1
+	}
+
+	remaining := make([]Stop, len(stops))
+	copy(remaining, stops)
+
+	result := Result{Routes: make([]Route, 0, len(vehicles))}
+	for _, vehicle := range vehicles {
+		var sequence []Stop
+		sequence, remaining = nearestNeighbour(depot, remaining, vehicle.Capacity, opts)
+		sequence = twoOpt(depot, sequence, opts)
+		result.Routes = append(result.Routes, schedule(depot, vehicle.ID, sequence, opts))
+	}
+	result.Unassigned = remaining
+	return result
+}
+
+func nearestNeighbour(depot Point, remaining []Stop, capacity int, opts Options) ([]Stop, []Stop) {
+	var sequence []Stop
+	current := depot
+	now := opts.Start
+
+	for len(remaining) > 0 && (capacity <= 0 || len(sequence) < capacity) {
+		best := -1
+		bestDistance := 0.0
+		for i, stop := range remaining {
+			distance := opts.distance(current, stop.Point)
+			if now.Add(opts.travelTime(distance)).After(stop.WindowEnd) {
+				continue
+			}
+			if best < 0 || distance < bestDistance {
+				best, bestDistance = i, distance
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		stop := remaining[best]
+		sequence = append(sequence, stop)
+		remaining = append(remaining[:best:best], remaining[best+1:]...)
+
+		arrival := now.Add(opts.travelTime(bestDistance))
+		if arrival.Before(stop.WindowStart) {
+			arrival = stop.WindowStart
+		}
+		now = arrival.Add(opts.ServiceTime)
+		current = stop.Point
+	}
+	return sequence, remaining
+}
+
+// twoOpt reverses segments of the route while that makes it shorter and
+// keeps every stop inside its window.
+func twoOpt(depot Point, sequence []Stop, opts Options) []Stop {
+	if len(sequence) < 3 {
+		return sequence
+	}
+
+	best := sequence
+	bestDistance := routeDistance(depot, best, opts)
+	for pass := 0; pass < maxImprovementPasses; pass++ {
+		improved := false
+		for i := 0; i < len(best)-1; i++ {
+			for k := i + 1; k < len(best); k++ {
+				candidate := reverseSegment(best, i, k)
+				distance := routeDistance(depot, candidate, opts)
+				if distance < bestDistance-1e-9 && feasible(depot, candidate, opts) {
+					best, bestDistance = candidate, distance
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return best
+}
+
+func schedule(depot Point, vehicleID string, sequence []Stop, opts Options) Route {
+	route := Route{VehicleID: vehicleID, Visits: make([]Visit, 0, len(sequence))}
+	current := depot
+	now := opts.Start
+	for _, stop := range sequence {
+		distance := opts.distance(current, stop.Point)
+		arrival := now.Add(opts.travelTime(distance))
+		start := arrival
+		if start.Before(stop.WindowStart) {
+			start = stop.WindowStart
+		}
+		route.Visits = append(route.Visits, Visit{Stop: stop, Arrival: arrival, Start: start, DistanceKm: distance})
+		route.DistanceKm += distance
+		now = start.Add(opts.ServiceTime)
+		current = stop.Point
+	}
+
+	back := opts.distance(current, depot)
+	route.DistanceKm += back
+	route.Finish = now.Add(opts.travelTime(back))
+	return route
+}
+
+func feasible(depot Point, sequence []Stop, opts Options) bool {
+	current := depot
+	now := opts.Start
+	for _, stop := range sequence {
+		arrival := now.Add(opts.travelTime(opts.distance(current, stop.Point)))
+		if arrival.After(stop.WindowEnd) {
+			return false
+		}
+		if arrival.Before(stop.WindowStart) {
+			arrival = stop.WindowStart
+		}
+		now = arrival.Add(opts.ServiceTime)
+		current = stop.Point
+	}
+	return true
+}
+
+func routeDistance(depot Point, sequence []Stop, opts Options) float64 {
+	total := 0.0
+	current := depot
+	for _, stop := range sequence {
+		total += opts.distance(current, stop.Point)
+		current = stop.Point
+	}
+	return total + opts.distance(current, depot)
+}
+
+func reverseSegment(sequence []Stop, i, k int) []Stop {
+	reversed := make([]Stop, len(sequence))
+	copy(reversed, sequence)
+	for left, right := i, k; left < right; left, right = left+1, right-1 {
+		reversed[left], reversed[right] = reversed[right], reversed[left]
+	}
+	return reversed
+}
+
+func (o Options) distance(a, b Point) float64 {
+	return utils.CalculateDistance(a.Latitude, a.Longitude, b.Latitude, b.Longitude) * o.DetourFactor
+}
+
+func (o Options) travelTime(distanceKm float64) time.Duration {
+	if o.SpeedKmh <= 0 {
+		return 0
+	}
+	return time.Duration(distanceKm / o.SpeedKmh * float64(time.Hour))
+}
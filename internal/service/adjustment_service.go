@@ -0,0 +1,574 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/config"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/notification"
+	"laundry-go/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	errAdjustmentPending  = errors.New("waiting for the customer to accept the weight adjustment")
+	errAdjustmentRejected = errors.New("the customer rejected the weight adjustment; submit a new one or cancel the order")
+)
+
+type AdjustmentService interface {
+	Create(ownerID, orderID string, req CreateAdjustmentRequest) (*AdjustmentResponse, error)
+	GetByOrder(userID, orderID string) ([]AdjustmentResponse, error)
+	Accept(userID, orderID, adjustmentID string) (*AdjustmentResponse, error)
+	Reject(userID, orderID, adjustmentID string, req RejectAdjustmentRequest) (*AdjustmentResponse, error)
+}
+
+type adjustmentService struct {
+	adjustmentRepo   repository.OrderAdjustmentRepository
+	orderRepo        repository.OrderRepository
+	orderServiceRepo repository.OrderServiceRepository
+	promotionRepo    repository.PromotionRepository
+	subscriptionRepo repository.SubscriptionRepository
+	walletRepo       repository.WalletRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	outboxRepo       repository.OutboxRepository
+	transactor       repository.Transactor
+	orderCfg         config.OrderConfig
+}
+
+type CreateAdjustmentRequest struct {
+	Lines []AdjustmentLineRequest `json:"lines"`
+	Note  string                  `json:"note"`
+}
+
+type AdjustmentLineRequest struct {
+	OrderServiceID string  `json:"order_service_id"`
+	Quantity       float64 `json:"quantity"` // measured quantity
+}
+
+type RejectAdjustmentRequest struct {
+	Reason string `json:"reason"`
+}
+
+type AdjustmentResponse struct {
+	ID              string                   `json:"id"`
+	OrderID         string                   `json:"order_id"`
+	Status          string                   `json:"status"`
+	Note            string                   `json:"note,omitempty"`
+	Lines           []AdjustmentLineResponse `json:"lines"`
+	SubtotalBefore  models.Money             `json:"subtotal_before"`
+	SubtotalAfter   models.Money             `json:"subtotal_after"`
+	DiscountBefore  models.Money             `json:"discount_before"`
+	DiscountAfter   models.Money             `json:"discount_after"`
+	TotalBefore     models.Money             `json:"total_before"`
+	TotalAfter      models.Money             `json:"total_after"`
+	Difference      models.Money             `json:"difference"`
+	Settlement      string                   `json:"settlement,omitempty"`
+	RejectionReason string                   `json:"rejection_reason,omitempty"`
+	ResolvedAt      *time.Time               `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+type AdjustmentLineResponse struct {
+	OrderServiceID string       `json:"order_service_id"`
+	ServiceName    string       `json:"service_name"`
+	Unit           string       `json:"unit"`
+	QuantityBefore float64      `json:"quantity_before"`
+	QuantityAfter  float64      `json:"quantity_after"`
+	QuotaBefore    float64      `json:"quota_before"`
+	QuotaAfter     float64      `json:"quota_after"`
+	SubtotalBefore models.Money `json:"subtotal_before"`
+	SubtotalAfter  models.Money `json:"subtotal_after"`
+}
+
+func NewAdjustmentService(
+	adjustmentRepo repository.OrderAdjustmentRepository,
+	orderRepo repository.OrderRepository,
+	orderServiceRepo repository.OrderServiceRepository,
+	promotionRepo repository.PromotionRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	walletRepo repository.WalletRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	outboxRepo repository.OutboxRepository,
+	transactor repository.Transactor,
+	orderCfg config.OrderConfig,
+) AdjustmentService {
+	return &adjustmentService{
+		adjustmentRepo:   adjustmentRepo,
+		orderRepo:        orderRepo,
+		orderServiceRepo: orderServiceRepo,
+		promotionRepo:    promotionRepo,
+		subscriptionRepo: subscriptionRepo,
+		walletRepo:       walletRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
+		transactor:       transactor,
+		orderCfg:         orderCfg,
+	}
+}
+
+// Create records the measured quantities of an order's kg lines. It
+// replaces an adjustment still waiting for the customer. Adjustments that
+// lower the total, or raise it by at most the weight tolerance, are applied
+// at once; the rest wait for the customer.
+func (s *adjustmentService) Create(ownerID, orderID string, req CreateAdjustmentRequest) (*AdjustmentResponse, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	order, err := s.findOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Laundry.OwnerID != ownerUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	var adjustment *models.OrderAdjustment
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		adjustmentRepo := s.adjustmentRepo.WithTx(tx)
+
+		// Price from the locked order so a submission made at the same
+		// time is applied on top of this one, not beside it
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(order.ID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+		if order.Status != "picked-up" {
+			return errors.New("quantities can only be adjusted after pickup and before processing starts")
+		}
+
+		adjustment, err = s.buildAdjustment(order, req)
+		if err != nil {
+			return err
+		}
+		adjustment.RequestedBy = ownerUUID
+
+		pending, err := adjustmentRepo.FindPendingByOrderIDForUpdate(order.ID)
+		if err != nil {
+			return errors.New("failed to adjust order")
+		}
+		for i := range pending {
+			pending[i].Status = models.OrderAdjustmentSuperseded
+			if err := adjustmentRepo.Update(&pending[i]); err != nil {
+				return errors.New("failed to adjust order")
+			}
+		}
+
+		if err := adjustmentRepo.Create(adjustment); err != nil {
+			return errors.New("failed to adjust order")
+		}
+
+		if s.withinTolerance(adjustment) {
+			err := s.apply(tx, order, adjustment, nil, models.OrderAdjustmentAutoAccepted)
+			if err == nil {
+				return nil
+			}
+			// A wallet that cannot cover the difference leaves the
+			// adjustment for the customer to accept after topping up.
+			if !errors.Is(err, errInsufficientWalletBalance) {
+				return err
+			}
+		}
+
+		return s.notify(tx, order, adjustment, events.OrderAdjustmentRequested, notification.EventOrderAdjustmentRequested)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAdjustmentResponse(adjustment), nil
+}
+
+// GetByOrder lists an order's adjustments, newest first, for its customer
+// or the laundry's owner.
+func (s *adjustmentService) GetByOrder(userID, orderID string) ([]AdjustmentResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	order, err := s.findOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userUUID && order.Laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	adjustments, err := s.adjustmentRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch adjustments")
+	}
+
+	responses := make([]AdjustmentResponse, 0, len(adjustments))
+	for i := range adjustments {
+		responses = append(responses, *toAdjustmentResponse(&adjustments[i]))
+	}
+	return responses, nil
+}
+
+// Accept applies a pending adjustment on behalf of the customer, charging
+// or refunding the difference.
+func (s *adjustmentService) Accept(userID, orderID, adjustmentID string) (*AdjustmentResponse, error) {
+	return s.resolve(userID, orderID, adjustmentID, func(tx *gorm.DB, order *models.Order, adjustment *models.OrderAdjustment, customerID uuid.UUID) error {
+		return s.apply(tx, order, adjustment, &customerID, models.OrderAdjustmentAccepted)
+	})
+}
+
+// Reject declines a pending adjustment. The order keeps its totals and is
+// held at picked-up until the laundry submits a new adjustment or cancels.
+func (s *adjustmentService) Reject(userID, orderID, adjustmentID string, req RejectAdjustmentRequest) (*AdjustmentResponse, error) {
+	return s.resolve(userID, orderID, adjustmentID, func(tx *gorm.DB, order *models.Order, adjustment *models.OrderAdjustment, customerID uuid.UUID) error {
+		now := time.Now()
+		adjustment.Status = models.OrderAdjustmentRejected
+		adjustment.RejectionReason = strings.TrimSpace(req.Reason)
+		adjustment.ResolvedBy = &customerID
+		adjustment.ResolvedAt = &now
+		if err := s.adjustmentRepo.WithTx(tx).Update(adjustment); err != nil {
+			return errors.New("failed to reject adjustment")
+		}
+
+		event := events.NewEvent(events.OrderAdjustmentRejected, order.ID, order.UserID, order.LaundryID, adjustmentEventData(order, adjustment))
+		if err := recordEvents(s.outboxRepo.WithTx(tx), []events.Event{event}); err != nil {
+			return errors.New("failed to record order events")
+		}
+		return nil
+	})
+}
+
+// resolve locks a pending adjustment of the customer's order and runs fn
+// on it inside a transaction.
+func (s *adjustmentService) resolve(userID, orderID, adjustmentID string, fn func(tx *gorm.DB, order *models.Order, adjustment *models.OrderAdjustment, customerID uuid.UUID) error) (*AdjustmentResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	adjustmentUUID, err := uuid.Parse(adjustmentID)
+	if err != nil {
+		return nil, errors.New("invalid adjustment ID")
+	}
+
+	order, err := s.findOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	var adjustment *models.OrderAdjustment
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		adjustment, err = s.adjustmentRepo.WithTx(tx).FindByIDForUpdate(adjustmentUUID)
+		if err != nil || adjustment.OrderID != order.ID {
+			return errors.New("adjustment not found")
+		}
+		if adjustment.Status != models.OrderAdjustmentPending {
+			return errors.New("adjustment has already been resolved")
+		}
+
+		current, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(order.ID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+		if current.Status != "picked-up" {
+			return errors.New("order can no longer be adjusted")
+		}
+		if current.TotalPrice != adjustment.TotalBefore {
+			return errors.New("adjustment is out of date")
+		}
+		return fn(tx, current, adjustment, userUUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAdjustmentResponse(adjustment), nil
+}
+
+// buildAdjustment prices the measured quantities. Subscription quota keeps
+// covering at most what it covered before; a percentage promo is worked out
+// again on the new subtotal and a fixed one is kept. Redeemed points are
+// not changed.
+func (s *adjustmentService) buildAdjustment(order *models.Order, req CreateAdjustmentRequest) (*models.OrderAdjustment, error) {
+	if len(req.Lines) == 0 {
+		return nil, errors.New("at least one line is required")
+	}
+
+	measured := make(map[uuid.UUID]float64, len(req.Lines))
+	for _, lineReq := range req.Lines {
+		lineID, err := uuid.Parse(lineReq.OrderServiceID)
+		if err != nil {
+			return nil, errors.New("invalid order service ID")
+		}
+		if _, ok := measured[lineID]; ok {
+			return nil, errors.New("each line can only be adjusted once")
+		}
+		if lineReq.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than zero")
+		}
+		measured[lineID] = lineReq.Quantity
+	}
+
+	adjustment := &models.OrderAdjustment{
+		OrderID:        order.ID,
+		Status:         models.OrderAdjustmentPending,
+		Note:           strings.TrimSpace(req.Note),
+		SubtotalBefore: order.Subtotal,
+		DiscountBefore: order.DiscountAmount,
+		TotalBefore:    order.TotalPrice,
+	}
+
+	var subtotalBefore, subtotalAfter models.Money
+	for _, line := range order.OrderServices {
+		subtotalBefore += line.Subtotal
+
+		quantity, ok := measured[line.ID]
+		if !ok {
+			subtotalAfter += line.Subtotal
+			continue
+		}
+		delete(measured, line.ID)
+		if !strings.EqualFold(line.Unit, "kg") {
+			return nil, errors.New("only kg services can be adjusted")
+		}
+
+		quantityHundredths := kgHundredths(quantity)
+		quotaHundredths := kgHundredths(line.QuotaQuantity)
+		if quotaHundredths > quantityHundredths {
+			quotaHundredths = quantityHundredths
+		}
+		subtotal := line.UnitPrice.MulQuantity(hundredthsKg(quantityHundredths - quotaHundredths))
+		subtotalAfter += subtotal
+
+		adjustment.Lines = append(adjustment.Lines, models.OrderAdjustmentLine{
+			OrderServiceID: line.ID,
+			ServiceName:    line.ServiceName,
+			Unit:           line.Unit,
+			QuantityBefore: line.Quantity,
+			QuantityAfter:  hundredthsKg(quantityHundredths),
+			QuotaBefore:    line.QuotaQuantity,
+			QuotaAfter:     hundredthsKg(quotaHundredths),
+			SubtotalBefore: line.Subtotal,
+			SubtotalAfter:  subtotal,
+		})
+	}
+	if len(measured) > 0 {
+		return nil, errors.New("order_service_id is not a line of this order")
+	}
+
+	// Orders created before promotions existed have no stored subtotal
+	if adjustment.SubtotalBefore == 0 {
+		adjustment.SubtotalBefore = subtotalBefore
+	}
+	adjustment.SubtotalAfter = subtotalAfter
+
+	discount := order.DiscountAmount
+	if order.PromoCode != "" && discount > 0 {
+		if promotion, err := s.promotionRepo.FindByCode(order.PromoCode); err == nil && promotion.DiscountType == models.DiscountTypePercentage {
+			discount = subtotalAfter.Percent(promotion.PercentOff)
+			if promotion.MaxDiscount > 0 && discount > promotion.MaxDiscount {
+				discount = promotion.MaxDiscount
+			}
+		}
+	}
+	if discount > subtotalAfter {
+		discount = subtotalAfter
+	}
+	adjustment.DiscountAfter = discount
+
+	total := subtotalAfter - discount - order.PointsDiscount
+	if total < 0 {
+		total = 0
+	}
+	adjustment.TotalAfter = total
+	return adjustment, nil
+}
+
+func (s *adjustmentService) withinTolerance(adjustment *models.OrderAdjustment) bool {
+	difference := adjustment.Difference()
+	return difference <= 0 || difference <= adjustment.TotalBefore.Percent(s.orderCfg.WeightTolerancePercent)
+}
+
+// apply writes an adjustment to the order and its lines, returns freed
+// subscription quota and settles the price difference. resolvedBy is nil
+// for automatic acceptance.
+func (s *adjustmentService) apply(tx *gorm.DB, order *models.Order, adjustment *models.OrderAdjustment, resolvedBy *uuid.UUID, status string) error {
+	if order.TotalPrice != adjustment.TotalBefore {
+		return errors.New("adjustment is out of date")
+	}
+
+	before := snapshotOrder(order)
+
+	// Settle first so a wallet that cannot cover the difference fails
+	// before anything else is written.
+	adjustment.Settlement = models.AdjustmentSettlementNone
+	if difference := adjustment.Difference(); difference != 0 && order.PaymentStatus == "paid" {
+		switch {
+		case order.PaymentMethod == "wallet":
+			if err := settleOrderAdjustment(s.walletRepo.WithTx(tx), order, difference); err != nil {
+				if errors.Is(err, errInsufficientWalletBalance) {
+					return err
+				}
+				return errors.New("failed to settle adjustment")
+			}
+			adjustment.Settlement = models.AdjustmentSettlementWalletCharged
+			if difference < 0 {
+				adjustment.Settlement = models.AdjustmentSettlementWalletRefunded
+			}
+		case difference > 0:
+			adjustment.Settlement = models.AdjustmentSettlementCollectCash
+		default:
+			adjustment.Settlement = models.AdjustmentSettlementRefundCash
+		}
+	}
+
+	lines := make(map[uuid.UUID]*models.OrderService, len(order.OrderServices))
+	for i := range order.OrderServices {
+		lines[order.OrderServices[i].ID] = &order.OrderServices[i]
+	}
+	orderServiceRepo := s.orderServiceRepo.WithTx(tx)
+	for _, change := range adjustment.Lines {
+		line, ok := lines[change.OrderServiceID]
+		if !ok {
+			return errors.New("adjustment is out of date")
+		}
+		line.Quantity = change.QuantityAfter
+		line.QuotaQuantity = change.QuotaAfter
+		line.Subtotal = change.SubtotalAfter
+		if err := orderServiceRepo.UpdateQuantity(line); err != nil {
+			return errors.New("failed to adjust order")
+		}
+	}
+
+	if err := returnAdjustedQuota(s.subscriptionRepo.WithTx(tx), order, adjustment.Lines); err != nil {
+		return errors.New("failed to return subscription quota")
+	}
+	if adjustment.DiscountAfter != adjustment.DiscountBefore && order.PromoCode != "" {
+		if err := s.promotionRepo.WithTx(tx).UpdateRedemptionDiscount(order.ID, adjustment.DiscountAfter); err != nil {
+			return errors.New("failed to adjust order")
+		}
+	}
+
+	order.Subtotal = adjustment.SubtotalAfter
+	order.DiscountAmount = adjustment.DiscountAfter
+	order.TotalPrice = adjustment.TotalAfter
+	if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+		return errors.New("failed to adjust order")
+	}
+
+	now := time.Now()
+	adjustment.Status = status
+	adjustment.ResolvedBy = resolvedBy
+	adjustment.ResolvedAt = &now
+	if err := s.adjustmentRepo.WithTx(tx).Update(adjustment); err != nil {
+		return errors.New("failed to adjust order")
+	}
+
+	if err := recordEvents(s.outboxRepo.WithTx(tx), orderEvents(before, order)); err != nil {
+		return errors.New("failed to record order events")
+	}
+	return s.notify(tx, order, adjustment, events.OrderAdjusted, notification.EventOrderAdjusted)
+}
+
+// notify records the adjustment event and tells the customer about the new
+// total.
+func (s *adjustmentService) notify(tx *gorm.DB, order *models.Order, adjustment *models.OrderAdjustment, eventType, notificationEvent string) error {
+	event := events.NewEvent(eventType, order.ID, order.UserID, order.LaundryID, adjustmentEventData(order, adjustment))
+	if err := recordEvents(s.outboxRepo.WithTx(tx), []events.Event{event}); err != nil {
+		return errors.New("failed to record order events")
+	}
+
+	user, err := s.userRepo.FindByID(order.UserID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	adjusted := *order
+	adjusted.TotalPrice = adjustment.TotalAfter
+	if err := enqueueOrderNotifications(s.notificationRepo.WithTx(tx), user, &adjusted, notificationEvent); err != nil {
+		return errors.New("failed to queue notifications")
+	}
+	return nil
+}
+
+func (s *adjustmentService) findOrder(orderID string) (*models.Order, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+// requireSettledAdjustment stops an order from leaving picked-up while its
+// latest weight adjustment is pending or was rejected.
+func requireSettledAdjustment(adjustmentRepo repository.OrderAdjustmentRepository, orderID uuid.UUID) error {
+	latest, err := adjustmentRepo.FindLatestByOrderID(orderID)
+	if err != nil {
+		return nil
+	}
+	switch latest.Status {
+	case models.OrderAdjustmentPending:
+		return errAdjustmentPending
+	case models.OrderAdjustmentRejected:
+		return errAdjustmentRejected
+	}
+	return nil
+}
+
+func adjustmentEventData(order *models.Order, adjustment *models.OrderAdjustment) map[string]interface{} {
+	data := orderEventData(order)
+	data["adjustment_id"] = adjustment.ID.String()
+	data["adjustment_status"] = adjustment.Status
+	data["total_before"] = adjustment.TotalBefore
+	data["total_after"] = adjustment.TotalAfter
+	return data
+}
+
+func toAdjustmentResponse(adjustment *models.OrderAdjustment) *AdjustmentResponse {
+	lines := make([]AdjustmentLineResponse, 0, len(adjustment.Lines))
+	for _, line := range adjustment.Lines {
+		lines = append(lines, AdjustmentLineResponse{
+			OrderServiceID: line.OrderServiceID.String(),
+			ServiceName:    line.ServiceName,
+			Unit:           line.Unit,
+			QuantityBefore: line.QuantityBefore,
+			QuantityAfter:  line.QuantityAfter,
+			QuotaBefore:    line.QuotaBefore,
+			QuotaAfter:     line.QuotaAfter,
+			SubtotalBefore: line.SubtotalBefore,
+			SubtotalAfter:  line.SubtotalAfter,
+		})
+	}
+
+	return &AdjustmentResponse{
+		ID:              adjustment.ID.String(),
+		OrderID:         adjustment.OrderID.String(),
+		Status:          adjustment.Status,
+		Note:            adjustment.Note,
+		Lines:           lines,
+		SubtotalBefore:  adjustment.SubtotalBefore,
+		SubtotalAfter:   adjustment.SubtotalAfter,
+		DiscountBefore:  adjustment.DiscountBefore,
+		DiscountAfter:   adjustment.DiscountAfter,
+		TotalBefore:     adjustment.TotalBefore,
+		TotalAfter:      adjustment.TotalAfter,
+		Difference:      adjustment.Difference(),
+		Settlement:      adjustment.Settlement,
+		RejectionReason: adjustment.RejectionReason,
+		ResolvedAt:      adjustment.ResolvedAt,
+		CreatedAt:       adjustment.CreatedAt,
+	}
+}
@@ -15,6 +15,7 @@ type AuthService interface {
 	Login(req LoginRequest) (*LoginResponse, error)
 	GetUserByID(userID string) (*models.User, error)
 	UpdateLocation(userID string, lat, lng float64) (*models.User, error)
+	UpdateLocale(userID, locale string) (*models.User, error)
 }
 
 type authService struct {
@@ -31,6 +32,7 @@ type RegisterRequest struct {
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
 	Role      string   `json:"role,omitempty"`
+	Locale    string   `json:"locale,omitempty"`
 }
 
 type RegisterResponse struct {
@@ -57,6 +59,7 @@ type UserResponse struct {
 	Latitude  *float64 `json:"latitude,omitempty"`
 	Longitude *float64 `json:"longitude,omitempty"`
 	Role      string   `json:"role,omitempty"`
+	Locale    string   `json:"locale,omitempty"`
 }
 
 func NewAuthService(userRepo repository.UserRepository, cfg *config.Config) AuthService {
@@ -86,6 +89,13 @@ func (s *authService) Register(req RegisterRequest) (*RegisterResponse, error) {
 	if utils.IsEmpty(req.Address) {
 		return nil, errors.New("address is required")
 	}
+	locale := ""
+	if req.Locale != "" {
+		locale = utils.NormalizeLocale(req.Locale)
+		if locale == "" {
+			return nil, errors.New("unsupported locale")
+		}
+	}
 
 	// Check if email already exists
 	existingUser, _ := s.userRepo.FindByEmail(req.Email)
@@ -104,7 +114,7 @@ func (s *authService) Register(req RegisterRequest) (*RegisterResponse, error) {
 	if role == "" {
 		role = "customer"
 	}
-	if role != "customer" && role != "laundry_owner" {
+	if role != "customer" && role != "laundry_owner" && role != "courier" {
 		role = "customer"
 	}
 
@@ -118,6 +128,7 @@ func (s *authService) Register(req RegisterRequest) (*RegisterResponse, error) {
 		Latitude:     req.Latitude,
 		Longitude:    req.Longitude,
 		Role:         role,
+		Locale:       locale,
 	}
 
 	if err := s.userRepo.Create(user); err != nil {
@@ -212,6 +223,31 @@ func (s *authService) UpdateLocation(userID string, lat, lng float64) (*models.U
 	return user, nil
 }
 
+func (s *authService) UpdateLocale(userID, locale string) (*models.User, error) {
+	normalized := utils.NormalizeLocale(locale)
+	if normalized == "" {
+		return nil, errors.New("unsupported locale")
+	}
+
+	uuid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	user, err := s.userRepo.FindByID(uuid)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	user.Locale = normalized
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, errors.New("failed to update locale")
+	}
+
+	return user, nil
+}
+
 func toUserResponse(user *models.User) *UserResponse {
 	return &UserResponse{
 		ID:        user.ID.String(),
@@ -222,6 +258,6 @@ func toUserResponse(user *models.User) *UserResponse {
 		Latitude:  user.Latitude,
 		Longitude: user.Longitude,
 		Role:      user.Role,
+		Locale:    user.Locale,
 	}
 }
-
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/storage"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxChatMessageLength keeps a message, with its metadata, inside the
+	// 8000 byte payload Postgres allows a notification.
+	maxChatMessageLength = 1000
+	defaultChatPageSize  = 50
+	maxChatPageSize      = 100
+)
+
+type ChatService interface {
+	GetMessages(userID, orderID, before string, limit int) (*ChatResponse, error)
+	Send(ctx context.Context, userID, orderID string, req SendChatMessageRequest) (*ChatMessageResponse, error)
+	MarkRead(userID, orderID string) (*ChatReadResponse, error)
+	OpenAttachment(ctx context.Context, userID, orderID, messageID string) (*models.OrderMessage, io.ReadCloser, error)
+}
+
+type chatService struct {
+	messageRepo   repository.OrderMessageRepository
+	orderRepo     repository.OrderRepository
+	deliveryRepo  repository.DeliveryRepository
+	userRepo      repository.UserRepository
+	store         storage.BlobStore
+	bus           events.Bus
+	maxUploadSize int64
+}
+
+// SendChatMessageRequest is a text message, a file, or both.
+type SendChatMessageRequest struct {
+	Body       string `json:"body"`
+	Attachment []byte `json:"-"`
+}
+
+// ChatResponse is a page of an order's chat, oldest message first, with
+// everyone taking part and how far each has read.
+type ChatResponse struct {
+	OrderID      string                `json:"order_id"`
+	Participants []ChatParticipant     `json:"participants"`
+	Messages     []ChatMessageResponse `json:"messages"`
+	UnreadCount  int64                 `json:"unread_count"`
+	HasMore      bool                  `json:"has_more"` // older messages can be fetched with ?before=<first message id>
+}
+
+type ChatParticipant struct {
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"` // customer, laundry_owner or courier
+	LastReadAt *time.Time `json:"last_read_at,omitempty"`
+}
+
+type ChatMessageResponse struct {
+	ID         string          `json:"id"`
+	OrderID    string          `json:"order_id"`
+	SenderID   string          `json:"sender_id"`
+	SenderName string          `json:"sender_name"`
+	SenderRole string          `json:"sender_role"`
+	Body       string          `json:"body,omitempty"`
+	Attachment *ChatAttachment `json:"attachment,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type ChatAttachment struct {
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
+
+type ChatReadResponse struct {
+	OrderID    string    `json:"order_id"`
+	UserID     string    `json:"user_id"`
+	Role       string    `json:"role"`
+	LastReadAt time.Time `json:"last_read_at"`
+}
+
+func NewChatService(messageRepo repository.OrderMessageRepository, orderRepo repository.OrderRepository, deliveryRepo repository.DeliveryRepository, userRepo repository.UserRepository, store storage.BlobStore, bus events.Bus, maxUploadSize int64) ChatService {
+	return &chatService{
+		messageRepo:   messageRepo,
+		orderRepo:     orderRepo,
+		deliveryRepo:  deliveryRepo,
+		userRepo:      userRepo,
+		store:         store,
+		bus:           bus,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// GetMessages returns the latest messages of an order's chat, or the ones
+// sent before the message before, to a participant of the order.
+func (s *chatService) GetMessages(userID, orderID, before string, limit int) (*ChatResponse, error) {
+	order, participant, participants, err := s.participantOrder(userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit < 1 {
+		limit = defaultChatPageSize
+	}
+	if limit > maxChatPageSize {
+		limit = maxChatPageSize
+	}
+
+	var beforeAt *time.Time
+	if before != "" {
+		beforeUUID, err := uuid.Parse(before)
+		if err != nil {
+			return nil, errors.New("invalid before message ID")
+		}
+		cursor, err := s.messageRepo.FindByID(beforeUUID)
+		if err != nil || cursor.OrderID != order.ID {
+			return nil, errors.New("message not found")
+		}
+		beforeAt = &cursor.CreatedAt
+	}
+
+	// Fetch one extra to tell whether there is an older page.
+	messages, err := s.messageRepo.FindByOrderID(order.ID, beforeAt, limit+1)
+	if err != nil {
+		return nil, errors.New("failed to fetch messages")
+	}
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	reads, err := s.messageRepo.FindReads(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch messages")
+	}
+	var lastReadAt time.Time
+	for i := range participants {
+		for _, read := range reads {
+			if read.UserID.String() == participants[i].UserID {
+				readAt := read.LastReadAt
+				participants[i].LastReadAt = &readAt
+			}
+		}
+		if participants[i].UserID == participant.UserID && participants[i].LastReadAt != nil {
+			lastReadAt = *participants[i].LastReadAt
+		}
+	}
+
+	unread, err := s.messageRepo.CountUnread(order.ID, uuid.MustParse(participant.UserID), lastReadAt)
+	if err != nil {
+		return nil, errors.New("failed to fetch messages")
+	}
+
+	response := &ChatResponse{
+		OrderID:      order.ID.String(),
+		Participants: participants,
+		Messages:     make([]ChatMessageResponse, 0, len(messages)),
+		UnreadCount:  unread,
+		HasMore:      hasMore,
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		response.Messages = append(response.Messages, toChatMessageResponse(&messages[i]))
+	}
+	return response, nil
+}
+
+// Send posts a message to an order's chat and pushes it to everyone
+// following the chat. The chat closes once the order is completed or
+// cancelled.
+func (s *chatService) Send(ctx context.Context, userID, orderID string, req SendChatMessageRequest) (*ChatMessageResponse, error) {
+	order, participant, _, err := s.participantOrder(userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == "completed" || order.Status == "cancelled" {
+		return nil, errors.New("chat is closed for this order")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" && len(req.Attachment) == 0 {
+		return nil, errors.New("a message needs a body or an attachment")
+	}
+	if utf8.RuneCountInString(body) > maxChatMessageLength {
+		return nil, fmt.Errorf("messages cannot be longer than %d characters", maxChatMessageLength)
+	}
+
+	message := &models.OrderMessage{
+		ID:         uuid.New(),
+		OrderID:    order.ID,
+		SenderID:   uuid.MustParse(participant.UserID),
+		SenderRole: participant.Role,
+		Body:       body,
+	}
+
+	if len(req.Attachment) > 0 {
+		if int64(len(req.Attachment)) > s.maxUploadSize {
+			return nil, fmt.Errorf("files cannot be larger than %d MB", s.maxUploadSize>>20)
+		}
+		contentType := http.DetectContentType(req.Attachment)
+		extension, ok := proofExtensions[contentType]
+		if !ok {
+			return nil, errors.New("files must be JPEG, PNG or WebP images")
+		}
+		message.AttachmentKey = fmt.Sprintf("orders/%s/chat/%s%s", order.ID, message.ID, extension)
+		message.AttachmentContentType = contentType
+		message.AttachmentSize = int64(len(req.Attachment))
+
+		if err := s.store.Put(ctx, message.AttachmentKey, req.Attachment, contentType); err != nil {
+			log.Printf("chat: failed to store %s: %v", message.AttachmentKey, err)
+			return nil, errors.New("failed to store file")
+		}
+	}
+
+	if err := s.messageRepo.Create(message); err != nil {
+		if message.HasAttachment() {
+			deleteBlobs(s.store, []string{message.AttachmentKey})
+		}
+		return nil, errors.New("failed to send message")
+	}
+
+	message.Sender.Name = participant.Name
+	response := toChatMessageResponse(message)
+	s.publish(order, events.ChatMessage, map[string]interface{}{"message": response})
+	return &response, nil
+}
+
+// MarkRead records that the participant has read the order's chat up to
+// now and tells the other participants.
+func (s *chatService) MarkRead(userID, orderID string) (*ChatReadResponse, error) {
+	order, participant, _, err := s.participantOrder(userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.messageRepo.MarkRead(&models.OrderChatRead{
+		OrderID:    order.ID,
+		UserID:     uuid.MustParse(participant.UserID),
+		LastReadAt: now,
+		UpdatedAt:  now,
+	}); err != nil {
+		return nil, errors.New("failed to mark chat as read")
+	}
+
+	response := &ChatReadResponse{
+		OrderID:    order.ID.String(),
+		UserID:     participant.UserID,
+		Role:       participant.Role,
+		LastReadAt: now,
+	}
+	s.publish(order, events.ChatRead, map[string]interface{}{
+		"user_id":      response.UserID,
+		"role":         response.Role,
+		"last_read_at": response.LastReadAt,
+	})
+	return response, nil
+}
+
+// OpenAttachment returns a message's file to a participant of the order.
+// The caller must close the reader.
+func (s *chatService) OpenAttachment(ctx context.Context, userID, orderID, messageID string) (*models.OrderMessage, io.ReadCloser, error) {
+	order, _, _, err := s.participantOrder(userID, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messageUUID, err := uuid.Parse(messageID)
+	if err != nil {
+		return nil, nil, errors.New("invalid message ID")
+	}
+
+	message, err := s.messageRepo.FindByID(messageUUID)
+	if err != nil || message.OrderID != order.ID || !message.HasAttachment() {
+		return nil, nil, errors.New("attachment not found")
+	}
+
+	reader, err := s.store.Get(ctx, message.AttachmentKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("chat: failed to read %s: %v", message.AttachmentKey, err)
+		}
+		return nil, nil, errors.New("attachment file not found")
+	}
+	return message, reader, nil
+}
+
+// participantOrder loads an order with everyone in its chat: the customer,
+// the laundry's owner and every courier with a delivery on the order that
+// was not cancelled. It fails unless the user is one of them.
+func (s *chatService) participantOrder(userID, orderID string) (*models.Order, *ChatParticipant, []ChatParticipant, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil, nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, nil, nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, nil, nil, errors.New("order not found")
+	}
+
+	participants := make([]ChatParticipant, 0, 4)
+	for _, member := range []struct {
+		id   uuid.UUID
+		role string
+	}{
+		{order.UserID, "customer"},
+		{order.Laundry.OwnerID, "laundry_owner"},
+	} {
+		user, err := s.userRepo.FindByID(member.id)
+		if err != nil {
+			return nil, nil, nil, errors.New("failed to fetch participants")
+		}
+		participants = append(participants, ChatParticipant{UserID: user.ID.String(), Name: user.Name, Role: member.role})
+	}
+
+	deliveries, err := s.deliveryRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, nil, nil, errors.New("failed to fetch participants")
+	}
+	seen := map[uuid.UUID]bool{order.UserID: true, order.Laundry.OwnerID: true}
+	for _, delivery := range deliveries {
+		if delivery.Status == models.DeliveryStatusCancelled || seen[delivery.CourierID] {
+			continue
+		}
+		seen[delivery.CourierID] = true
+		participants = append(participants, ChatParticipant{UserID: delivery.CourierID.String(), Name: delivery.Courier.Name, Role: "courier"})
+	}
+
+	for i := range participants {
+		if participants[i].UserID == userUUID.String() {
+			return order, &participants[i], participants, nil
+		}
+	}
+	return nil, nil, nil, errors.New("unauthorized")
+}
+
+// publish pushes a chat event. The message is already stored, so a client
+// that misses it sees it when it reloads the chat.
+func (s *chatService) publish(order *models.Order, eventType string, data map[string]interface{}) {
+	if err := s.bus.Publish(events.NewEvent(eventType, order.ID, order.UserID, order.LaundryID, data)); err != nil {
+		log.Printf("chat: failed to publish %s for order %s: %v", eventType, order.ID, err)
+	}
+}
+
+func toChatMessageResponse(message *models.OrderMessage) ChatMessageResponse {
+	response := ChatMessageResponse{
+		ID:         message.ID.String(),
+		OrderID:    message.OrderID.String(),
+		SenderID:   message.SenderID.String(),
+		SenderName: message.Sender.Name,
+		SenderRole: message.SenderRole,
+		Body:       message.Body,
+		CreatedAt:  message.CreatedAt,
+	}
+	if message.HasAttachment() {
+		response.Attachment = &ChatAttachment{
+			ContentType: message.AttachmentContentType,
+			Size:        message.AttachmentSize,
+			URL:         fmt.Sprintf("/api/v1/orders/%s/messages/%s/attachment", message.OrderID, message.ID),
+		}
+	}
+	return response
+}
@@ -0,0 +1,842 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-go/internal/config"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/notification"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/storage"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxClaimPhotos is the most photos a claim can hold.
+	MaxClaimPhotos = 10
+	maxClaimItems  = 50
+
+	claimAuthorSystem = "system"
+)
+
+var errUnresolvedClaim = errors.New("order has an unresolved claim")
+
+type ClaimService interface {
+	Create(userID, orderID string, req CreateClaimRequest) (*ClaimResponse, error)
+	GetByOrder(userID, role, orderID string) ([]ClaimResponse, error)
+	GetByID(userID, role, claimID string) (*ClaimResponse, error)
+	GetMine(userID, status string, page, limit int) (*ClaimListResponse, error)
+	GetByLaundry(ownerID, laundryID, status string, page, limit int) (*ClaimListResponse, error)
+	GetAll(status string, escalatedOnly bool, page, limit int) (*ClaimListResponse, error)
+	UploadPhotos(ctx context.Context, userID, claimID string, files [][]byte) ([]ClaimPhotoResponse, error)
+	OpenPhoto(ctx context.Context, userID, role, claimID, photoID string) (*models.ClaimPhoto, io.ReadCloser, error)
+	AddMessage(userID, role, claimID string, req ClaimMessageRequest) (*ClaimResponse, error)
+	Resolve(userID, role, claimID string, req ResolveClaimRequest) (*ClaimResponse, error)
+	Escalate(userID, role, claimID string, req EscalateClaimRequest) (*ClaimResponse, error)
+	Close(userID, role, claimID string) (*ClaimResponse, error)
+}
+
+type claimService struct {
+	claimRepo        repository.ClaimRepository
+	orderRepo        repository.OrderRepository
+	laundryRepo      repository.LaundryRepository
+	garmentRepo      repository.GarmentRepository
+	walletRepo       repository.WalletRepository
+	loyaltyRepo      repository.LoyaltyRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	outboxRepo       repository.OutboxRepository
+	transactor       repository.Transactor
+	store            storage.BlobStore
+	orderCfg         config.OrderConfig
+	maxUploadSize    int64
+}
+
+type CreateClaimRequest struct {
+	Type        string             `json:"type"` // damage, lost or other
+	Description string             `json:"description"`
+	Items       []ClaimItemRequest `json:"items"`
+}
+
+type ClaimItemRequest struct {
+	GarmentID     string       `json:"garment_id"` // optional tagged garment of the order
+	Description   string       `json:"description"`
+	ClaimedAmount models.Money `json:"claimed_amount"`
+}
+
+type ClaimMessageRequest struct {
+	Body string `json:"body"`
+}
+
+type ResolveClaimRequest struct {
+	Resolution   string       `json:"resolution"`    // refund, rework or rejected
+	RefundAmount models.Money `json:"refund_amount"` // required for refund
+	Note         string       `json:"note"`
+}
+
+type EscalateClaimRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ClaimListResponse struct {
+	Claims     []ClaimResponse `json:"claims"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+type ClaimResponse struct {
+	ID               string                 `json:"id"`
+	OrderID          string                 `json:"order_id"`
+	LaundryID        string                 `json:"laundry_id"`
+	Type             string                 `json:"type"`
+	Description      string                 `json:"description"`
+	Status           string                 `json:"status"`
+	Resolution       string                 `json:"resolution,omitempty"`
+	ResolutionNote   string                 `json:"resolution_note,omitempty"`
+	RefundedAmount   models.Money           `json:"refunded_amount"`
+	Escalated        bool                   `json:"escalated"`
+	EscalatedAt      *time.Time             `json:"escalated_at,omitempty"`
+	EscalationReason string                 `json:"escalation_reason,omitempty"`
+	ResolvedAt       *time.Time             `json:"resolved_at,omitempty"`
+	ClosedAt         *time.Time             `json:"closed_at,omitempty"`
+	Items            []ClaimItemResponse    `json:"items"`
+	Photos           []ClaimPhotoResponse   `json:"photos,omitempty"`   // only on claim details
+	Messages         []ClaimMessageResponse `json:"messages,omitempty"` // only on claim details
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+type ClaimItemResponse struct {
+	GarmentID     string       `json:"garment_id,omitempty"`
+	Description   string       `json:"description"`
+	ClaimedAmount models.Money `json:"claimed_amount"`
+}
+
+type ClaimPhotoResponse struct {
+	ID          string    `json:"id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ClaimMessageResponse struct {
+	AuthorID   string    `json:"author_id,omitempty"`
+	AuthorRole string    `json:"author_role"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewClaimService(
+	claimRepo repository.ClaimRepository,
+	orderRepo repository.OrderRepository,
+	laundryRepo repository.LaundryRepository,
+	garmentRepo repository.GarmentRepository,
+	walletRepo repository.WalletRepository,
+	loyaltyRepo repository.LoyaltyRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	outboxRepo repository.OutboxRepository,
+	transactor repository.Transactor,
+	store storage.BlobStore,
+	orderCfg config.OrderConfig,
+	maxUploadSize int64,
+) ClaimService {
+	return &claimService{
+		claimRepo:        claimRepo,
+		orderRepo:        orderRepo,
+		laundryRepo:      laundryRepo,
+		garmentRepo:      garmentRepo,
+		walletRepo:       walletRepo,
+		loyaltyRepo:      loyaltyRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
+		transactor:       transactor,
+		store:            store,
+		orderCfg:         orderCfg,
+		maxUploadSize:    maxUploadSize,
+	}
+}
+
+// Create opens a claim on a delivered or completed order, within the claim
+// window after delivery. An order has at most one claim in progress.
+func (s *claimService) Create(userID, orderID string, req CreateClaimRequest) (*ClaimResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if order.UserID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+	if order.Status != "delivered" && order.Status != "completed" {
+		return nil, errors.New("claims can only be opened after the order is delivered")
+	}
+	deliveredAt := order.UpdatedAt
+	if order.ActualDeliveryAt != nil {
+		deliveredAt = *order.ActualDeliveryAt
+	}
+	if time.Since(deliveredAt) > s.orderCfg.ClaimWindow {
+		return nil, errors.New("the claim window for this order has closed")
+	}
+
+	if req.Type != models.ClaimTypeDamage && req.Type != models.ClaimTypeLost && req.Type != models.ClaimTypeOther {
+		return nil, errors.New("type must be damage, lost or other")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, errors.New("description is required")
+	}
+	if len(req.Items) > maxClaimItems {
+		return nil, fmt.Errorf("a claim can list at most %d items", maxClaimItems)
+	}
+
+	claim := &models.Claim{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		LaundryID:   order.LaundryID,
+		Type:        req.Type,
+		Description: description,
+		Status:      models.ClaimStatusOpen,
+	}
+	for _, itemReq := range req.Items {
+		item := models.ClaimItem{
+			Description:   strings.TrimSpace(itemReq.Description),
+			ClaimedAmount: itemReq.ClaimedAmount,
+		}
+		if itemReq.GarmentID != "" {
+			garmentUUID, err := uuid.Parse(itemReq.GarmentID)
+			if err != nil {
+				return nil, errors.New("invalid garment ID")
+			}
+			garment, err := s.garmentRepo.FindByID(garmentUUID)
+			if err != nil || garment.OrderID != order.ID {
+				return nil, errors.New("garment is not part of this order")
+			}
+			item.GarmentItemID = &garment.ID
+			if item.Description == "" {
+				item.Description = garment.Type
+			}
+		}
+		if item.Description == "" {
+			return nil, errors.New("each item needs a description or a garment")
+		}
+		if item.ClaimedAmount < 0 {
+			return nil, errors.New("claimed_amount cannot be negative")
+		}
+		claim.Items = append(claim.Items, item)
+	}
+
+	active, err := s.claimRepo.HasActive(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to open claim")
+	}
+	if active {
+		return nil, errors.New("this order already has a claim in progress")
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		if err := s.claimRepo.WithTx(tx).Create(claim); err != nil {
+			return errors.New("failed to open claim")
+		}
+		event := events.NewEvent(events.ClaimOpened, order.ID, order.UserID, order.LaundryID, claimEventData(claim))
+		if err := recordEvents(s.outboxRepo.WithTx(tx), []events.Event{event}); err != nil {
+			return errors.New("failed to record claim events")
+		}
+		return nil
+	})
+	if err != nil {
+		// A concurrent claim may have won the unique index.
+		if active, _ := s.claimRepo.HasActive(order.ID); active {
+			return nil, errors.New("this order already has a claim in progress")
+		}
+		return nil, err
+	}
+
+	return s.findResponse(claim.ID)
+}
+
+// GetByOrder lists an order's claims for its customer, the laundry's owner
+// or an admin.
+func (s *claimService) GetByOrder(userID, role, orderID string) ([]ClaimResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if order.UserID != userUUID && order.Laundry.OwnerID != userUUID && role != "admin" {
+		return nil, errors.New("unauthorized")
+	}
+
+	claims, err := s.claimRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch claims")
+	}
+	return toClaimResponses(claims), nil
+}
+
+func (s *claimService) GetByID(userID, role, claimID string) (*ClaimResponse, error) {
+	claim, _, err := s.viewableClaim(userID, role, claimID)
+	if err != nil {
+		return nil, err
+	}
+	return toClaimResponse(claim), nil
+}
+
+// GetMine lists the customer's own claims.
+func (s *claimService) GetMine(userID, status string, page, limit int) (*ClaimListResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	return s.list(repository.ClaimFilter{UserID: &userUUID, Status: status}, page, limit)
+}
+
+// GetByLaundry lists the claims on a laundry for its owner.
+func (s *claimService) GetByLaundry(ownerID, laundryID, status string, page, limit int) (*ClaimListResponse, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+	if laundry.OwnerID != ownerUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	return s.list(repository.ClaimFilter{LaundryIDs: []uuid.UUID{laundry.ID}, Status: status}, page, limit)
+}
+
+// GetAll lists every claim for admins, optionally only escalated ones.
+func (s *claimService) GetAll(status string, escalatedOnly bool, page, limit int) (*ClaimListResponse, error) {
+	return s.list(repository.ClaimFilter{Status: status, EscalatedOnly: escalatedOnly}, page, limit)
+}
+
+// UploadPhotos attaches evidence to a claim that is not closed. Only the
+// customer who opened the claim can upload.
+func (s *claimService) UploadPhotos(ctx context.Context, userID, claimID string, files [][]byte) ([]ClaimPhotoResponse, error) {
+	claim, party, err := s.viewableClaim(userID, "", claimID)
+	if err != nil {
+		return nil, err
+	}
+	if party != "customer" {
+		return nil, errors.New("only the customer can add photos")
+	}
+	if claim.Status == models.ClaimStatusClosed {
+		return nil, errors.New("claim is closed")
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("at least one file is required")
+	}
+	if len(claim.Photos)+len(files) > MaxClaimPhotos {
+		return nil, fmt.Errorf("a claim can hold at most %d photos", MaxClaimPhotos)
+	}
+
+	photos := make([]models.ClaimPhoto, 0, len(files))
+	for _, data := range files {
+		if int64(len(data)) > s.maxUploadSize {
+			return nil, fmt.Errorf("files cannot be larger than %d MB", s.maxUploadSize>>20)
+		}
+		contentType := http.DetectContentType(data)
+		extension, ok := proofExtensions[contentType]
+		if !ok {
+			return nil, errors.New("files must be JPEG, PNG or WebP images")
+		}
+
+		id := uuid.New()
+		photos = append(photos, models.ClaimPhoto{
+			ID:          id,
+			ClaimID:     claim.ID,
+			StorageKey:  fmt.Sprintf("claims/%s/%s%s", claim.ID, id, extension),
+			ContentType: contentType,
+			Size:        int64(len(data)),
+			UploadedBy:  claim.UserID,
+		})
+	}
+
+	stored := make([]string, 0, len(photos))
+	for i := range photos {
+		if err := s.store.Put(ctx, photos[i].StorageKey, files[i], photos[i].ContentType); err != nil {
+			log.Printf("claim: failed to store %s: %v", photos[i].StorageKey, err)
+			deleteBlobs(s.store, stored)
+			return nil, errors.New("failed to store file")
+		}
+		stored = append(stored, photos[i].StorageKey)
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		claimRepo := s.claimRepo.WithTx(tx)
+		// Lock the claim so concurrent uploads cannot pass the photo limit.
+		if _, err := claimRepo.FindByIDForUpdate(claim.ID); err != nil {
+			return err
+		}
+		count, err := claimRepo.CountPhotos(claim.ID)
+		if err != nil {
+			return err
+		}
+		if int(count)+len(photos) > MaxClaimPhotos {
+			return fmt.Errorf("a claim can hold at most %d photos", MaxClaimPhotos)
+		}
+		for i := range photos {
+			if err := claimRepo.CreatePhoto(&photos[i]); err != nil {
+				return errors.New("failed to upload photos")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		deleteBlobs(s.store, stored)
+		return nil, err
+	}
+
+	responses := make([]ClaimPhotoResponse, 0, len(photos))
+	for i := range photos {
+		responses = append(responses, toClaimPhotoResponse(&photos[i]))
+	}
+	return responses, nil
+}
+
+// OpenPhoto returns a claim photo to anyone who can see the claim. The
+// caller must close the reader.
+func (s *claimService) OpenPhoto(ctx context.Context, userID, role, claimID, photoID string) (*models.ClaimPhoto, io.ReadCloser, error) {
+	claim, _, err := s.viewableClaim(userID, role, claimID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	photoUUID, err := uuid.Parse(photoID)
+	if err != nil {
+		return nil, nil, errors.New("invalid photo ID")
+	}
+
+	photo, err := s.claimRepo.FindPhotoByID(photoUUID)
+	if err != nil || photo.ClaimID != claim.ID {
+		return nil, nil, errors.New("photo not found")
+	}
+
+	reader, err := s.store.Get(ctx, photo.StorageKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("claim: failed to read %s: %v", photo.StorageKey, err)
+		}
+		return nil, nil, errors.New("photo file not found")
+	}
+	return photo, reader, nil
+}
+
+// AddMessage posts to the claim's conversation. The first reply from the
+// laundry or an admin moves an open claim under review.
+func (s *claimService) AddMessage(userID, role, claimID string, req ClaimMessageRequest) (*ClaimResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("body is required")
+	}
+
+	return s.update(userID, role, claimID, func(tx *gorm.DB, claim *models.Claim, party string, authorID uuid.UUID) error {
+		if claim.Status == models.ClaimStatusClosed {
+			return errors.New("claim is closed")
+		}
+		if err := s.claimRepo.WithTx(tx).CreateMessage(&models.ClaimMessage{
+			ClaimID:    claim.ID,
+			AuthorID:   &authorID,
+			AuthorRole: party,
+			Body:       body,
+		}); err != nil {
+			return errors.New("failed to post message")
+		}
+		if party != "customer" && claim.Status == models.ClaimStatusOpen {
+			claim.Status = models.ClaimStatusUnderReview
+		}
+		return nil
+	})
+}
+
+// Resolve settles a claim with a refund, rework or rejection. The laundry's
+// owner resolves claims until they are escalated; after that only an admin
+// can. Refunds need a paid order, are credited to the customer's wallet
+// and, across all claims, cannot exceed what was paid for the order.
+func (s *claimService) Resolve(userID, role, claimID string, req ResolveClaimRequest) (*ClaimResponse, error) {
+	switch req.Resolution {
+	case models.ClaimResolutionRefund:
+		if req.RefundAmount <= 0 {
+			return nil, errors.New("refund_amount must be greater than zero")
+		}
+	case models.ClaimResolutionRework, models.ClaimResolutionRejected:
+		if req.RefundAmount != 0 {
+			return nil, errors.New("refund_amount is only allowed for refunds")
+		}
+	default:
+		return nil, errors.New("resolution must be refund, rework or rejected")
+	}
+
+	return s.update(userID, role, claimID, func(tx *gorm.DB, claim *models.Claim, party string, authorID uuid.UUID) error {
+		switch party {
+		case "customer":
+			return errors.New("unauthorized")
+		case "laundry_owner":
+			if claim.EscalatedAt != nil {
+				return errors.New("claim was escalated and is now handled by an admin")
+			}
+		}
+		if !claim.Unresolved() {
+			return errors.New("claim is not awaiting a resolution")
+		}
+
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(claim.OrderID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		if req.Resolution == models.ClaimResolutionRefund {
+			if order.PaymentStatus != "paid" {
+				return errors.New("only paid orders can be refunded")
+			}
+			refunded, err := s.claimRepo.WithTx(tx).SumRefundedByOrderID(order.ID)
+			if err != nil {
+				return errors.New("failed to resolve claim")
+			}
+			if refunded+req.RefundAmount > order.TotalPrice {
+				return fmt.Errorf("refund_amount cannot exceed %s, the rest of what was paid for the order", (order.TotalPrice - refunded).String())
+			}
+			if err := refundClaimToWallet(s.walletRepo.WithTx(tx), order, claim.ID, req.RefundAmount, authorID); err != nil {
+				return errors.New("failed to refund claim")
+			}
+			claim.RefundedAmount += req.RefundAmount
+			if err := reverseLoyaltyPoints(s.loyaltyRepo.WithTx(tx), order, refunded+req.RefundAmount, time.Now()); err != nil {
+				return errors.New("failed to take back loyalty points")
+			}
+		}
+
+		now := time.Now()
+		claim.Status = models.ClaimStatusResolved
+		claim.Resolution = req.Resolution
+		claim.ResolutionNote = strings.TrimSpace(req.Note)
+		claim.ResolvedBy = &authorID
+		claim.ResolvedAt = &now
+
+		note := "Resolved: " + req.Resolution
+		if req.Resolution == models.ClaimResolutionRefund {
+			note += " of " + req.RefundAmount.String() + " " + currencyOrDefault(order.Currency)
+		}
+		if claim.ResolutionNote != "" {
+			note += ". " + claim.ResolutionNote
+		}
+		if err := s.systemNote(tx, claim, note); err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.FindByID(order.UserID)
+		if err != nil {
+			return errors.New("user not found")
+		}
+		if err := enqueueOrderNotifications(s.notificationRepo.WithTx(tx), user, order, notification.EventClaimResolved); err != nil {
+			return errors.New("failed to queue notifications")
+		}
+		return nil
+	})
+}
+
+// Escalate asks an admin to step in. The customer or the laundry's owner
+// can escalate once, while the claim is not closed and nothing has been
+// refunded on it; a resolved claim goes back under review.
+func (s *claimService) Escalate(userID, role, claimID string, req EscalateClaimRequest) (*ClaimResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	return s.update(userID, role, claimID, func(tx *gorm.DB, claim *models.Claim, party string, authorID uuid.UUID) error {
+		if party == "admin" {
+			return errors.New("unauthorized")
+		}
+		if claim.Status == models.ClaimStatusClosed {
+			return errors.New("claim is closed")
+		}
+		if claim.EscalatedAt != nil {
+			return errors.New("claim has already been escalated")
+		}
+		// Re-resolving would pay the refund a second time
+		if claim.RefundedAmount > 0 {
+			return errors.New("claim has already been refunded; open a new claim for the rest")
+		}
+
+		now := time.Now()
+		claim.EscalatedAt = &now
+		claim.EscalationReason = reason
+		claim.Status = models.ClaimStatusUnderReview
+		claim.Resolution = ""
+		claim.ResolvedBy = nil
+		claim.ResolvedAt = nil
+		return s.systemNote(tx, claim, "Escalated to an admin by the "+strings.ReplaceAll(party, "_", " ")+": "+reason)
+	})
+}
+
+// Close ends a resolved claim. The customer closes it to accept the
+// resolution; an admin can close any claim.
+func (s *claimService) Close(userID, role, claimID string) (*ClaimResponse, error) {
+	return s.update(userID, role, claimID, func(tx *gorm.DB, claim *models.Claim, party string, authorID uuid.UUID) error {
+		switch party {
+		case "customer":
+			if claim.Status != models.ClaimStatusResolved {
+				return errors.New("only resolved claims can be closed")
+			}
+		case "admin":
+			if claim.Status == models.ClaimStatusClosed {
+				return errors.New("claim is already closed")
+			}
+		default:
+			return errors.New("unauthorized")
+		}
+
+		now := time.Now()
+		claim.Status = models.ClaimStatusClosed
+		claim.ClosedAt = &now
+		return nil
+	})
+}
+
+// update locks the claim and runs fn as the caller's party inside a
+// transaction, then saves the claim and records a status change event.
+func (s *claimService) update(userID, role, claimID string, fn func(tx *gorm.DB, claim *models.Claim, party string, authorID uuid.UUID) error) (*ClaimResponse, error) {
+	viewed, party, err := s.viewableClaim(userID, role, claimID)
+	if err != nil {
+		return nil, err
+	}
+	authorID := uuid.MustParse(userID)
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		claimRepo := s.claimRepo.WithTx(tx)
+		claim, err := claimRepo.FindByIDForUpdate(viewed.ID)
+		if err != nil {
+			return errors.New("claim not found")
+		}
+		previousStatus := claim.Status
+
+		if err := fn(tx, claim, party, authorID); err != nil {
+			return err
+		}
+		if err := claimRepo.Update(claim); err != nil {
+			return errors.New("failed to update claim")
+		}
+
+		if claim.Status != previousStatus {
+			data := claimEventData(claim)
+			data["previous_status"] = previousStatus
+			event := events.NewEvent(events.ClaimStatusChanged, claim.OrderID, claim.UserID, claim.LaundryID, data)
+			if err := recordEvents(s.outboxRepo.WithTx(tx), []events.Event{event}); err != nil {
+				return errors.New("failed to record claim events")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findResponse(viewed.ID)
+}
+
+// viewableClaim loads a claim and works out the caller's party in it:
+// customer, laundry_owner or admin.
+func (s *claimService) viewableClaim(userID, role, claimID string) (*models.Claim, string, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, "", errors.New("invalid user ID")
+	}
+
+	claimUUID, err := uuid.Parse(claimID)
+	if err != nil {
+		return nil, "", errors.New("invalid claim ID")
+	}
+
+	claim, err := s.claimRepo.FindByID(claimUUID)
+	if err != nil {
+		return nil, "", errors.New("claim not found")
+	}
+
+	if claim.UserID == userUUID {
+		return claim, "customer", nil
+	}
+	laundry, err := s.laundryRepo.FindByID(claim.LaundryID)
+	if err == nil && laundry.OwnerID == userUUID {
+		return claim, "laundry_owner", nil
+	}
+	if role == "admin" {
+		return claim, "admin", nil
+	}
+	return nil, "", errors.New("claim not found")
+}
+
+// systemNote adds a note to the claim's conversation that has no author.
+func (s *claimService) systemNote(tx *gorm.DB, claim *models.Claim, body string) error {
+	if err := s.claimRepo.WithTx(tx).CreateMessage(&models.ClaimMessage{
+		ClaimID:    claim.ID,
+		AuthorRole: claimAuthorSystem,
+		Body:       body,
+	}); err != nil {
+		return errors.New("failed to update claim")
+	}
+	return nil
+}
+
+func (s *claimService) list(filter repository.ClaimFilter, page, limit int) (*ClaimListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	claims, total, err := s.claimRepo.FindAll(filter, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch claims")
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &ClaimListResponse{
+		Claims: toClaimResponses(claims),
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *claimService) findResponse(id uuid.UUID) (*ClaimResponse, error) {
+	claim, err := s.claimRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("claim not found")
+	}
+	return toClaimResponse(claim), nil
+}
+
+// requireNoUnresolvedClaim stops an order from being completed while a
+// claim on it waits for a resolution.
+func requireNoUnresolvedClaim(claimRepo repository.ClaimRepository, orderID uuid.UUID) error {
+	unresolved, err := claimRepo.HasUnresolved(orderID)
+	if err != nil {
+		return errors.New("failed to check claims")
+	}
+	if unresolved {
+		return errUnresolvedClaim
+	}
+	return nil
+}
+
+func claimEventData(claim *models.Claim) map[string]interface{} {
+	return map[string]interface{}{
+		"claim_id":        claim.ID.String(),
+		"claim_status":    claim.Status,
+		"claim_type":      claim.Type,
+		"resolution":      claim.Resolution,
+		"refunded_amount": claim.RefundedAmount,
+		"escalated":       claim.EscalatedAt != nil,
+	}
+}
+
+func toClaimResponses(claims []models.Claim) []ClaimResponse {
+	responses := make([]ClaimResponse, 0, len(claims))
+	for i := range claims {
+		responses = append(responses, *toClaimResponse(&claims[i]))
+	}
+	return responses
+}
+
+func toClaimResponse(claim *models.Claim) *ClaimResponse {
+	response := &ClaimResponse{
+		ID:               claim.ID.String(),
+		OrderID:          claim.OrderID.String(),
+		LaundryID:        claim.LaundryID.String(),
+		Type:             claim.Type,
+		Description:      claim.Description,
+		Status:           claim.Status,
+		Resolution:       claim.Resolution,
+		ResolutionNote:   claim.ResolutionNote,
+		RefundedAmount:   claim.RefundedAmount,
+		Escalated:        claim.EscalatedAt != nil,
+		EscalatedAt:      claim.EscalatedAt,
+		EscalationReason: claim.EscalationReason,
+		ResolvedAt:       claim.ResolvedAt,
+		ClosedAt:         claim.ClosedAt,
+		Items:            make([]ClaimItemResponse, 0, len(claim.Items)),
+		CreatedAt:        claim.CreatedAt,
+		UpdatedAt:        claim.UpdatedAt,
+	}
+	for _, item := range claim.Items {
+		itemResponse := ClaimItemResponse{
+			Description:   item.Description,
+			ClaimedAmount: item.ClaimedAmount,
+		}
+		if item.GarmentItemID != nil {
+			itemResponse.GarmentID = item.GarmentItemID.String()
+		}
+		response.Items = append(response.Items, itemResponse)
+	}
+	for i := range claim.Photos {
+		response.Photos = append(response.Photos, toClaimPhotoResponse(&claim.Photos[i]))
+	}
+	for _, message := range claim.Messages {
+		messageResponse := ClaimMessageResponse{
+			AuthorRole: message.AuthorRole,
+			Body:       message.Body,
+			CreatedAt:  message.CreatedAt,
+		}
+		if message.AuthorID != nil {
+			messageResponse.AuthorID = message.AuthorID.String()
+		}
+		response.Messages = append(response.Messages, messageResponse)
+	}
+	return response
+}
+
+func toClaimPhotoResponse(photo *models.ClaimPhoto) ClaimPhotoResponse {
+	return ClaimPhotoResponse{
+		ID:          photo.ID.String(),
+		ContentType: photo.ContentType,
+		Size:        photo.Size,
+		URL:         fmt.Sprintf("/api/v1/claims/%s/photos/%s/file", photo.ClaimID, photo.ID),
+		CreatedAt:   photo.CreatedAt,
+	}
+}
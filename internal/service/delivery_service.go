@@ -0,0 +1,453 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// deliveryStatusOrder is the lifecycle of a delivery. Couriers may only move
+// a delivery forward, but may skip steps.
+var deliveryStatusOrder = []string{
+	models.DeliveryStatusAssigned,
+	models.DeliveryStatusEnRoute,
+	models.DeliveryStatusArrived,
+	models.DeliveryStatusDone,
+}
+
+type DeliveryService interface {
+	Assign(ownerID, orderID string, req AssignDeliveryRequest) (*DeliveryResponse, error)
+	GetByOrder(userID, orderID string) ([]DeliveryResponse, error)
+	GetMine(courierID, status string, page, limit int) (*DeliveryListResponse, error)
+	UpdateStatus(courierID, deliveryID, status string) (*DeliveryResponse, error)
+}
+
+type deliveryService struct {
+	deliveryRepo repository.DeliveryRepository
+	orderRepo    repository.OrderRepository
+	userRepo     repository.UserRepository
+	outboxRepo   repository.OutboxRepository
+	transactor   repository.Transactor
+	orderService OrderService
+}
+
+type AssignDeliveryRequest struct {
+	Leg          string `json:"leg"` // pickup or dropoff
+	CourierID    string `json:"courier_id"`
+	CourierEmail string `json:"courier_email"` // alternative to courier_id
+	Notes        string `json:"notes"`
+}
+
+type UpdateDeliveryStatusRequest struct {
+	Status string `json:"status"`
+}
+
+type DeliveryListResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+type DeliveryResponse struct {
+	ID             string     `json:"id"`
+	OrderID        string     `json:"order_id"`
+	LaundryID      string     `json:"laundry_id"`
+	LaundryName    string     `json:"laundry_name"`
+	LaundryAddress string     `json:"laundry_address"`
+	CourierID      string     `json:"courier_id"`
+	CourierName    string     `json:"courier_name"`
+	Leg            string     `json:"leg"`
+	Status         string     `json:"status"`
+	CustomerName   string     `json:"customer_name"`
+	CustomerPhone  string     `json:"customer_phone"`
+	Address        string     `json:"address"`
+	Notes          string     `json:"notes,omitempty"`
+	AssignedAt     time.Time  `json:"assigned_at"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	ArrivedAt      *time.Time `json:"arrived_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+func NewDeliveryService(deliveryRepo repository.DeliveryRepository, orderRepo repository.OrderRepository, userRepo repository.UserRepository, outboxRepo repository.OutboxRepository, transactor repository.Transactor, orderService OrderService) DeliveryService {
+	return &deliveryService{
+		deliveryRepo: deliveryRepo,
+		orderRepo:    orderRepo,
+		userRepo:     userRepo,
+		outboxRepo:   outboxRepo,
+		transactor:   transactor,
+		orderService: orderService,
+	}
+}
+
+// Assign gives an order leg to a courier. Assigning a leg that already has
+// a courier reassigns it and restarts it from assigned. Pickups can only be
+// assigned once the laundry has confirmed the order.
+func (s *deliveryService) Assign(ownerID, orderID string, req AssignDeliveryRequest) (*DeliveryResponse, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if order.Laundry.OwnerID != ownerUUID {
+		return nil, errors.New("unauthorized")
+	}
+	if req.Leg != models.DeliveryLegPickup && req.Leg != models.DeliveryLegDropoff {
+		return nil, errors.New("leg must be pickup or dropoff")
+	}
+
+	courier, err := findCourier(s.userRepo, req.CourierID, req.CourierEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveryID uuid.UUID
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		// Check the locked order so it cannot be expired while a pickup is
+		// being assigned
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(order.ID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+		if order.Status == "cancelled" || order.Status == "completed" {
+			return errors.New("order is closed")
+		}
+
+		switch req.Leg {
+		case models.DeliveryLegPickup:
+			if order.ActualPickupAt != nil {
+				return errors.New("order has already been picked up")
+			}
+			if order.Status != "confirmed" {
+				return errors.New("the laundry must confirm the order before a pickup is assigned")
+			}
+		case models.DeliveryLegDropoff:
+			if order.ActualDeliveryAt != nil {
+				return errors.New("order has already been delivered")
+			}
+		}
+
+		delivery, err := assignLeg(s.deliveryRepo.WithTx(tx), s.outboxRepo.WithTx(tx), order, courier.ID, req.Leg, req.Notes)
+		if err != nil {
+			return err
+		}
+		deliveryID = delivery.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findResponse(deliveryID)
+}
+
+// GetByOrder lists an order's deliveries for its customer, the laundry
+// owner or one of its couriers.
+func (s *deliveryService) GetByOrder(userID, orderID string) ([]DeliveryResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	deliveries, err := s.deliveryRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch deliveries")
+	}
+
+	allowed := order.UserID == userUUID || order.Laundry.OwnerID == userUUID
+	for _, delivery := range deliveries {
+		if delivery.CourierID == userUUID {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized")
+	}
+
+	items := make([]DeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		items = append(items, *toDeliveryResponse(&deliveries[i]))
+	}
+	return items, nil
+}
+
+func (s *deliveryService) GetMine(courierID, status string, page, limit int) (*DeliveryListResponse, error) {
+	courierUUID, err := uuid.Parse(courierID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	deliveries, total, err := s.deliveryRepo.FindByCourierID(courierUUID, status, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch deliveries")
+	}
+
+	items := make([]DeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		items = append(items, *toDeliveryResponse(&deliveries[i]))
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &DeliveryListResponse{
+		Deliveries: items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// UpdateStatus moves a courier's delivery forward. Finishing a pickup sets
+// the order's ActualPickupAt and moves a confirmed order to picked-up;
+// finishing a drop-off sets ActualDeliveryAt and moves a ready order to
+// delivered.
+func (s *deliveryService) UpdateStatus(courierID, deliveryID, status string) (*DeliveryResponse, error) {
+	courierUUID, err := uuid.Parse(courierID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	deliveryUUID, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, errors.New("invalid delivery ID")
+	}
+
+	next := deliveryStatusIndex(status)
+	if next < 0 {
+		return nil, errors.New("invalid status")
+	}
+
+	found, err := s.deliveryRepo.FindByID(deliveryUUID)
+	if err != nil {
+		return nil, errors.New("delivery not found")
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		deliveryRepo := s.deliveryRepo.WithTx(tx)
+
+		// Lock the order before the delivery, in the same order as a
+		// cancellation that cancels the order's deliveries
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(found.OrderID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		delivery, err := deliveryRepo.FindByIDForUpdate(deliveryUUID)
+		if err != nil {
+			return errors.New("delivery not found")
+		}
+		if delivery.CourierID != courierUUID {
+			return errors.New("unauthorized")
+		}
+		if delivery.Status == models.DeliveryStatusCancelled {
+			return errors.New("delivery is cancelled")
+		}
+		if next <= deliveryStatusIndex(delivery.Status) {
+			return errors.New("delivery status can only move forward")
+		}
+
+		now := time.Now()
+		delivery.Status = status
+		switch status {
+		case models.DeliveryStatusEnRoute:
+			delivery.StartedAt = &now
+		case models.DeliveryStatusArrived:
+			delivery.ArrivedAt = &now
+		case models.DeliveryStatusDone:
+			delivery.CompletedAt = &now
+			if err := s.completeLeg(tx, order, delivery, now); err != nil {
+				return err
+			}
+		}
+
+		if err := deliveryRepo.Update(delivery); err != nil {
+			return errors.New("failed to update delivery")
+		}
+		return recordDeliveryEvent(s.outboxRepo.WithTx(tx), order, delivery)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findResponse(deliveryUUID)
+}
+
+// completeLeg records the finished leg on the order and advances the order
+// when it is at the step the leg completes.
+func (s *deliveryService) completeLeg(tx *gorm.DB, order *models.Order, delivery *models.Delivery, now time.Time) error {
+	var from, to string
+	switch delivery.Leg {
+	case models.DeliveryLegPickup:
+		order.ActualPickupAt = &now
+		from, to = "confirmed", "picked-up"
+	case models.DeliveryLegDropoff:
+		order.ActualDeliveryAt = &now
+		from, to = "ready", "delivered"
+	}
+
+	if order.Status == from {
+		return s.orderService.transition(tx, order, to, &delivery.CourierID, delivery.Leg+" completed by courier")
+	}
+	if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+		return errors.New("failed to update order")
+	}
+	return nil
+}
+
+// assignLeg gives the order's leg to the courier, creating its delivery or
+// reassigning and restarting the existing one. Must run inside a
+// transaction.
+func assignLeg(deliveryRepo repository.DeliveryRepository, outboxRepo repository.OutboxRepository, order *models.Order, courierID uuid.UUID, leg, notes string) (*models.Delivery, error) {
+	now := time.Now()
+
+	delivery, err := deliveryRepo.FindByOrderAndLegForUpdate(order.ID, leg)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("failed to assign courier")
+	}
+
+	if delivery == nil {
+		delivery = &models.Delivery{
+			OrderID:    order.ID,
+			LaundryID:  order.LaundryID,
+			CourierID:  courierID,
+			Leg:        leg,
+			Status:     models.DeliveryStatusAssigned,
+			Notes:      strings.TrimSpace(notes),
+			AssignedAt: now,
+		}
+		if err := deliveryRepo.Create(delivery); err != nil {
+			return nil, errors.New("failed to assign courier")
+		}
+	} else {
+		if delivery.Status == models.DeliveryStatusDone {
+			return nil, errors.New("delivery is already done")
+		}
+		delivery.CourierID = courierID
+		delivery.Status = models.DeliveryStatusAssigned
+		delivery.Notes = strings.TrimSpace(notes)
+		delivery.AssignedAt = now
+		delivery.StartedAt = nil
+		delivery.ArrivedAt = nil
+		if err := deliveryRepo.Update(delivery); err != nil {
+			return nil, errors.New("failed to assign courier")
+		}
+	}
+
+	if err := recordDeliveryEvent(outboxRepo, order, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// findCourier looks a courier up by ID or, failing that, by email.
+func findCourier(userRepo repository.UserRepository, courierID, courierEmail string) (*models.User, error) {
+	var courier *models.User
+	var err error
+	switch {
+	case courierID != "":
+		courierUUID, parseErr := uuid.Parse(courierID)
+		if parseErr != nil {
+			return nil, errors.New("invalid courier ID")
+		}
+		courier, err = userRepo.FindByID(courierUUID)
+	case courierEmail != "":
+		courier, err = userRepo.FindByEmail(strings.TrimSpace(courierEmail))
+	default:
+		return nil, errors.New("courier_id or courier_email is required")
+	}
+
+	if err != nil || courier == nil || courier.Role != "courier" {
+		return nil, errors.New("courier not found")
+	}
+	return courier, nil
+}
+
+func (s *deliveryService) findResponse(id uuid.UUID) (*DeliveryResponse, error) {
+	delivery, err := s.deliveryRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("delivery not found")
+	}
+	return toDeliveryResponse(delivery), nil
+}
+
+// recordDeliveryEvent records a delivery.status_changed event for the
+// order's streams.
+func recordDeliveryEvent(outboxRepo repository.OutboxRepository, order *models.Order, delivery *models.Delivery) error {
+	event := events.NewEvent(events.DeliveryStatusChanged, order.ID, order.UserID, order.LaundryID, map[string]interface{}{
+		"delivery_id": delivery.ID.String(),
+		"leg":         delivery.Leg,
+		"status":      delivery.Status,
+		"courier_id":  delivery.CourierID.String(),
+	})
+	if err := recordEvents(outboxRepo, []events.Event{event}); err != nil {
+		return errors.New("failed to record delivery events")
+	}
+	return nil
+}
+
+func deliveryStatusIndex(status string) int {
+	for i, step := range deliveryStatusOrder {
+		if step == status {
+			return i
+		}
+	}
+	return -1
+}
+
+func toDeliveryResponse(delivery *models.Delivery) *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:             delivery.ID.String(),
+		OrderID:        delivery.OrderID.String(),
+		LaundryID:      delivery.LaundryID.String(),
+		LaundryName:    delivery.Order.Laundry.Name,
+		LaundryAddress: delivery.Order.Laundry.Address,
+		CourierID:      delivery.CourierID.String(),
+		CourierName:    delivery.Courier.Name,
+		Leg:            delivery.Leg,
+		Status:         delivery.Status,
+		CustomerName:   delivery.Order.User.Name,
+		CustomerPhone:  delivery.Order.User.Phone,
+		Address:        delivery.Order.DeliveryAddress,
+		Notes:          delivery.Notes,
+		AssignedAt:     delivery.AssignedAt,
+		StartedAt:      delivery.StartedAt,
+		ArrivedAt:      delivery.ArrivedAt,
+		CompletedAt:    delivery.CompletedAt,
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteService interface {
+	Add(userID, laundryID string) error
+	Remove(userID, laundryID string) error
+	GetMine(userID, locale string, page, limit int) (*FavoriteListResponse, error)
+}
+
+type favoriteService struct {
+	favoriteRepo repository.FavoriteRepository
+	laundryRepo  repository.LaundryRepository
+	serviceRepo  repository.ServiceRepository
+	imageRepo    repository.LaundryImageRepository
+	userRepo     repository.UserRepository
+}
+
+type FavoriteListResponse struct {
+	Laundries  []FavoriteLaundryItem `json:"laundries"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+type FavoriteLaundryItem struct {
+	LaundryListItem
+	FavoritedAt time.Time `json:"favorited_at"`
+}
+
+func NewFavoriteService(favoriteRepo repository.FavoriteRepository, laundryRepo repository.LaundryRepository, serviceRepo repository.ServiceRepository, imageRepo repository.LaundryImageRepository, userRepo repository.UserRepository) FavoriteService {
+	return &favoriteService{
+		favoriteRepo: favoriteRepo,
+		laundryRepo:  laundryRepo,
+		serviceRepo:  serviceRepo,
+		imageRepo:    imageRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// Add saves a laundry to the user's favourites. Adding it twice is fine.
+func (s *favoriteService) Add(userID, laundryID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return errors.New("laundry not found")
+	}
+
+	if err := s.favoriteRepo.Add(&models.FavoriteLaundry{UserID: userUUID, LaundryID: laundry.ID}); err != nil {
+		return errors.New("failed to add favorite")
+	}
+	return nil
+}
+
+func (s *favoriteService) Remove(userID, laundryID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return errors.New("invalid laundry ID")
+	}
+
+	removed, err := s.favoriteRepo.Remove(userUUID, laundryUUID)
+	if err != nil {
+		return errors.New("failed to remove favorite")
+	}
+	if !removed {
+		return errors.New("laundry is not a favorite")
+	}
+	return nil
+}
+
+// GetMine lists the user's favourite laundries, most recently added
+// first, with distances from the user's saved location.
+func (s *favoriteService) GetMine(userID, locale string, page, limit int) (*FavoriteListResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	user, err := s.userRepo.FindByID(userUUID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	locale = resolveLocale(locale, user)
+
+	favorites, total, err := s.favoriteRepo.FindByUserID(userUUID, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch favorites")
+	}
+
+	laundryIDs := make([]uuid.UUID, 0, len(favorites))
+	for _, favorite := range favorites {
+		laundryIDs = append(laundryIDs, favorite.LaundryID)
+	}
+	images, err := s.imageRepo.FindByLaundryIDs(laundryIDs)
+	if err != nil {
+		return nil, errors.New("failed to fetch favorites")
+	}
+	imagesByLaundry := make(map[uuid.UUID][]models.LaundryImage)
+	for _, image := range images {
+		imagesByLaundry[image.LaundryID] = append(imagesByLaundry[image.LaundryID], image)
+	}
+
+	items := make([]FavoriteLaundryItem, 0, len(favorites))
+	for i := range favorites {
+		laundry := &favorites[i].Laundry
+		minPrice, maxPrice, _ := s.serviceRepo.GetPriceRange(laundry.ID)
+
+		var distance *float64
+		if user.Latitude != nil && user.Longitude != nil && laundry.Latitude != nil && laundry.Longitude != nil {
+			dist := utils.CalculateDistance(*user.Latitude, *user.Longitude, *laundry.Latitude, *laundry.Longitude)
+			distance = &dist
+		}
+
+		items = append(items, FavoriteLaundryItem{
+			LaundryListItem: toLaundryListItem(laundry, minPrice, maxPrice, imagesByLaundry[laundry.ID], distance, locale),
+			FavoritedAt:     favorites[i].CreatedAt,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &FavoriteListResponse{
+		Laundries: items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
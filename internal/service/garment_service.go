@@ -0,0 +1,515 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"laundry-go/internal/imaging"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxGarmentsPerRequest = 100
+	// tagCodeAlphabet leaves out letters that are easily misread (I, L, O)
+	// and the digits 0 and 1.
+	tagCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	tagCodeLength   = 8
+	tagCodePrefix   = "G-"
+)
+
+var garmentStages = []string{
+	models.GarmentStageReceived,
+	models.GarmentStageWashing,
+	models.GarmentStageDrying,
+	models.GarmentStageIroning,
+	models.GarmentStageReady,
+	models.GarmentStageReturned,
+}
+
+var garmentDamageFlags = []string{
+	models.GarmentDamageStain,
+	models.GarmentDamageTear,
+	models.GarmentDamageMissingButton,
+	models.GarmentDamageFaded,
+	models.GarmentDamageShrunk,
+	models.GarmentDamageOther,
+}
+
+type GarmentService interface {
+	Create(ownerID, orderID string, req CreateGarmentsRequest) ([]GarmentResponse, error)
+	GetByOrder(userID, orderID string) ([]GarmentResponse, error)
+	Update(ownerID, orderID, garmentID string, req UpdateGarmentRequest) (*GarmentResponse, error)
+	Delete(ownerID, orderID, garmentID string) error
+	Label(ownerID, orderID, garmentID string) (*models.GarmentItem, []byte, error)
+	Scan(ownerID string, req ScanGarmentRequest) (*GarmentResponse, error)
+}
+
+type garmentService struct {
+	garmentRepo repository.GarmentRepository
+	orderRepo   repository.OrderRepository
+	laundryRepo repository.LaundryRepository
+	transactor  repository.Transactor
+}
+
+type CreateGarmentsRequest struct {
+	Items []GarmentRequest `json:"items"`
+}
+
+type GarmentRequest struct {
+	OrderServiceID string   `json:"order_service_id"` // optional order line the garment is billed under
+	Type           string   `json:"type"`
+	Colour         string   `json:"colour"`
+	Brand          string   `json:"brand"`
+	Notes          string   `json:"notes"`
+	DamageFlags    []string `json:"damage_flags"`
+}
+
+type UpdateGarmentRequest struct {
+	Type        *string  `json:"type"`
+	Colour      *string  `json:"colour"`
+	Brand       *string  `json:"brand"`
+	Notes       *string  `json:"notes"`
+	DamageFlags []string `json:"damage_flags"` // replaces the flags when present
+}
+
+type ScanGarmentRequest struct {
+	TagCode string `json:"tag_code"`
+	Stage   string `json:"stage"`
+	Note    string `json:"note"`
+}
+
+type GarmentResponse struct {
+	ID             string                `json:"id"`
+	OrderID        string                `json:"order_id"`
+	OrderServiceID string                `json:"order_service_id,omitempty"`
+	TagCode        string                `json:"tag_code"`
+	Type           string                `json:"type"`
+	Colour         string                `json:"colour,omitempty"`
+	Brand          string                `json:"brand,omitempty"`
+	Notes          string                `json:"notes,omitempty"`
+	DamageFlags    []string              `json:"damage_flags"`
+	Stage          string                `json:"stage"`
+	LabelURL       string                `json:"label_url"`
+	Scans          []GarmentScanResponse `json:"scans"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+type GarmentScanResponse struct {
+	Stage     string    `json:"stage"`
+	Note      string    `json:"note,omitempty"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+func NewGarmentService(garmentRepo repository.GarmentRepository, orderRepo repository.OrderRepository, laundryRepo repository.LaundryRepository, transactor repository.Transactor) GarmentService {
+	return &garmentService{
+		garmentRepo: garmentRepo,
+		orderRepo:   orderRepo,
+		laundryRepo: laundryRepo,
+		transactor:  transactor,
+	}
+}
+
+// Create tags garments of an order, each with a unique tag code and a
+// received scan.
+func (s *garmentService) Create(ownerID, orderID string, req CreateGarmentsRequest) ([]GarmentResponse, error) {
+	ownerUUID, order, err := s.ownedOrder(ownerID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == "cancelled" || order.Status == "completed" {
+		return nil, errors.New("order is closed")
+	}
+
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+	if len(req.Items) > maxGarmentsPerRequest {
+		return nil, fmt.Errorf("at most %d items can be added at once", maxGarmentsPerRequest)
+	}
+
+	lines := make(map[uuid.UUID]bool, len(order.OrderServices))
+	for _, line := range order.OrderServices {
+		lines[line.ID] = true
+	}
+
+	items := make([]models.GarmentItem, 0, len(req.Items))
+	for _, itemReq := range req.Items {
+		item := models.GarmentItem{
+			OrderID:   order.ID,
+			LaundryID: order.LaundryID,
+			Stage:     models.GarmentStageReceived,
+		}
+		if itemReq.OrderServiceID != "" {
+			lineID, err := uuid.Parse(itemReq.OrderServiceID)
+			if err != nil || !lines[lineID] {
+				return nil, errors.New("order_service_id is not a line of this order")
+			}
+			item.OrderServiceID = &lineID
+		}
+		if err := applyGarmentDetails(&item, &itemReq.Type, &itemReq.Colour, &itemReq.Brand, &itemReq.Notes, itemReq.DamageFlags); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	now := time.Now()
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		garmentRepo := s.garmentRepo.WithTx(tx)
+		for i := range items {
+			tagCode, err := s.newTagCode(garmentRepo)
+			if err != nil {
+				return err
+			}
+			items[i].TagCode = tagCode
+			if err := garmentRepo.Create(&items[i]); err != nil {
+				return errors.New("failed to add items")
+			}
+			if err := garmentRepo.CreateScan(&models.GarmentScan{
+				ItemID:    items[i].ID,
+				Stage:     models.GarmentStageReceived,
+				ScannedBy: ownerUUID,
+				ScannedAt: now,
+			}); err != nil {
+				return errors.New("failed to add items")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findByOrder(order.ID)
+}
+
+// GetByOrder lists an order's garments for its customer or the laundry's
+// owner.
+func (s *garmentService) GetByOrder(userID, orderID string) ([]GarmentResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if order.UserID != userUUID && order.Laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	return s.findByOrder(order.ID)
+}
+
+func (s *garmentService) Update(ownerID, orderID, garmentID string, req UpdateGarmentRequest) (*GarmentResponse, error) {
+	_, order, err := s.ownedOrder(ownerID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.findItem(order, garmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyGarmentDetails(item, req.Type, req.Colour, req.Brand, req.Notes, req.DamageFlags); err != nil {
+		return nil, err
+	}
+	if err := s.garmentRepo.Update(item); err != nil {
+		return nil, errors.New("failed to update item")
+	}
+
+	response := toGarmentResponse(item)
+	return &response, nil
+}
+
+// Delete removes a garment tagged by mistake. Garments that have moved
+// past received are kept for the record.
+func (s *garmentService) Delete(ownerID, orderID, garmentID string) error {
+	_, order, err := s.ownedOrder(ownerID, orderID)
+	if err != nil {
+		return err
+	}
+
+	item, err := s.findItem(order, garmentID)
+	if err != nil {
+		return err
+	}
+	if item.Stage != models.GarmentStageReceived {
+		return errors.New("only items that have not been processed can be deleted")
+	}
+
+	return s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		if err := s.garmentRepo.WithTx(tx).Delete(item.ID); err != nil {
+			return errors.New("failed to delete item")
+		}
+		return nil
+	})
+}
+
+// Label renders the garment's printable QR label as PNG.
+func (s *garmentService) Label(ownerID, orderID, garmentID string) (*models.GarmentItem, []byte, error) {
+	_, order, err := s.ownedOrder(ownerID, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item, err := s.findItem(order, garmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	description := item.Type
+	if item.Colour != "" {
+		description += " / " + item.Colour
+	}
+	label, err := imaging.QRLabel(item.TagCode, []string{
+		item.TagCode,
+		description,
+		"Order " + strings.ToUpper(order.ID.String()[:8]),
+	})
+	if err != nil {
+		return nil, nil, errors.New("failed to render label")
+	}
+	return item, label, nil
+}
+
+// Scan records a garment reaching a stage. Scanning a garment at the stage
+// it is already at changes nothing, so double scans are harmless.
+func (s *garmentService) Scan(ownerID string, req ScanGarmentRequest) (*GarmentResponse, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	tagCode := strings.ToUpper(strings.TrimSpace(req.TagCode))
+	if tagCode == "" {
+		return nil, errors.New("tag_code is required")
+	}
+	if !containsString(garmentStages, req.Stage) {
+		return nil, errors.New("stage must be one of " + strings.Join(garmentStages, ", "))
+	}
+
+	var itemID uuid.UUID
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		garmentRepo := s.garmentRepo.WithTx(tx)
+
+		item, err := garmentRepo.FindByTagCodeForUpdate(tagCode)
+		if err != nil {
+			return errors.New("item not found")
+		}
+		laundry, err := s.laundryRepo.FindByID(item.LaundryID)
+		if err != nil || laundry.OwnerID != ownerUUID {
+			return errors.New("item not found")
+		}
+		order, err := s.orderRepo.WithTx(tx).FindByID(item.OrderID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+		if order.Status == "cancelled" {
+			return errors.New("order is cancelled")
+		}
+
+		itemID = item.ID
+		if item.Stage == req.Stage {
+			return nil
+		}
+
+		item.Stage = req.Stage
+		if err := garmentRepo.Update(item); err != nil {
+			return errors.New("failed to record scan")
+		}
+		if err := garmentRepo.CreateScan(&models.GarmentScan{
+			ItemID:    item.ID,
+			Stage:     req.Stage,
+			Note:      strings.TrimSpace(req.Note),
+			ScannedBy: ownerUUID,
+			ScannedAt: time.Now(),
+		}); err != nil {
+			return errors.New("failed to record scan")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.garmentRepo.FindByID(itemID)
+	if err != nil {
+		return nil, errors.New("item not found")
+	}
+	response := toGarmentResponse(item)
+	return &response, nil
+}
+
+func (s *garmentService) ownedOrder(ownerID, orderID string) (uuid.UUID, *models.Order, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return uuid.Nil, nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return uuid.Nil, nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return uuid.Nil, nil, errors.New("order not found")
+	}
+	if order.Laundry.OwnerID != ownerUUID {
+		return uuid.Nil, nil, errors.New("unauthorized")
+	}
+	return ownerUUID, order, nil
+}
+
+func (s *garmentService) findItem(order *models.Order, garmentID string) (*models.GarmentItem, error) {
+	garmentUUID, err := uuid.Parse(garmentID)
+	if err != nil {
+		return nil, errors.New("invalid item ID")
+	}
+
+	item, err := s.garmentRepo.FindByID(garmentUUID)
+	if err != nil || item.OrderID != order.ID {
+		return nil, errors.New("item not found")
+	}
+	return item, nil
+}
+
+func (s *garmentService) findByOrder(orderID uuid.UUID) ([]GarmentResponse, error) {
+	items, err := s.garmentRepo.FindByOrderID(orderID)
+	if err != nil {
+		return nil, errors.New("failed to fetch items")
+	}
+	return toGarmentResponses(items), nil
+}
+
+// newTagCode returns a random tag code that is not in use yet.
+func (s *garmentService) newTagCode(garmentRepo repository.GarmentRepository) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		random := make([]byte, tagCodeLength)
+		if _, err := rand.Read(random); err != nil {
+			return "", errors.New("failed to generate tag code")
+		}
+		code := make([]byte, tagCodeLength)
+		for i, b := range random {
+			code[i] = tagCodeAlphabet[int(b)%len(tagCodeAlphabet)]
+		}
+
+		tagCode := tagCodePrefix + string(code)
+		exists, err := garmentRepo.TagCodeExists(tagCode)
+		if err != nil {
+			return "", errors.New("failed to generate tag code")
+		}
+		if !exists {
+			return tagCode, nil
+		}
+	}
+	return "", errors.New("failed to generate tag code")
+}
+
+// applyGarmentDetails validates and sets the fields that are not nil.
+func applyGarmentDetails(item *models.GarmentItem, garmentType, colour, brand, notes *string, damageFlags []string) error {
+	if garmentType != nil {
+		value := strings.TrimSpace(*garmentType)
+		if value == "" {
+			return errors.New("type is required")
+		}
+		if len(value) > 50 {
+			return errors.New("type cannot be longer than 50 characters")
+		}
+		item.Type = value
+	}
+	if colour != nil {
+		value := strings.TrimSpace(*colour)
+		if len(value) > 50 {
+			return errors.New("colour cannot be longer than 50 characters")
+		}
+		item.Colour = value
+	}
+	if brand != nil {
+		value := strings.TrimSpace(*brand)
+		if len(value) > 100 {
+			return errors.New("brand cannot be longer than 100 characters")
+		}
+		item.Brand = value
+	}
+	if notes != nil {
+		item.Notes = strings.TrimSpace(*notes)
+	}
+	if damageFlags != nil {
+		seen := make(map[string]bool, len(damageFlags))
+		flags := make([]string, 0, len(damageFlags))
+		for _, flag := range damageFlags {
+			flag = strings.TrimSpace(flag)
+			if !containsString(garmentDamageFlags, flag) {
+				return errors.New("damage_flags must be from " + strings.Join(garmentDamageFlags, ", "))
+			}
+			if !seen[flag] {
+				seen[flag] = true
+				flags = append(flags, flag)
+			}
+		}
+		item.DamageFlags = strings.Join(flags, ",")
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func toGarmentResponses(items []models.GarmentItem) []GarmentResponse {
+	responses := make([]GarmentResponse, 0, len(items))
+	for i := range items {
+		responses = append(responses, toGarmentResponse(&items[i]))
+	}
+	return responses
+}
+
+func toGarmentResponse(item *models.GarmentItem) GarmentResponse {
+	response := GarmentResponse{
+		ID:          item.ID.String(),
+		OrderID:     item.OrderID.String(),
+		TagCode:     item.TagCode,
+		Type:        item.Type,
+		Colour:      item.Colour,
+		Brand:       item.Brand,
+		Notes:       item.Notes,
+		DamageFlags: []string{},
+		Stage:       item.Stage,
+		LabelURL:    fmt.Sprintf("/api/v1/orders/%s/garments/%s/label", item.OrderID, item.ID),
+		Scans:       make([]GarmentScanResponse, 0, len(item.Scans)),
+		CreatedAt:   item.CreatedAt,
+	}
+	if item.OrderServiceID != nil {
+		response.OrderServiceID = item.OrderServiceID.String()
+	}
+	if item.DamageFlags != "" {
+		response.DamageFlags = strings.Split(item.DamageFlags, ",")
+	}
+	for _, scan := range item.Scans {
+		response.Scans = append(response.Scans, GarmentScanResponse{
+			Stage:     scan.Stage,
+			Note:      scan.Note,
+			ScannedAt: scan.ScannedAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobService interface {
+	GetAll(status string, page, limit int) (*JobListResponse, error)
+	Retry(jobID string) (*models.Job, error)
+}
+
+type jobService struct {
+	jobRepo repository.JobRepository
+}
+
+type JobListResponse struct {
+	Jobs       []models.Job `json:"jobs"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+func NewJobService(jobRepo repository.JobRepository) JobService {
+	return &jobService{jobRepo: jobRepo}
+}
+
+func (s *jobService) GetAll(status string, page, limit int) (*JobListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	jobs, total, err := s.jobRepo.FindAll(status, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch jobs")
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &JobListResponse{
+		Jobs: jobs,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// Retry puts a dead-lettered job back on the queue with a fresh set of
+// attempts.
+func (s *jobService) Retry(jobID string) (*models.Job, error) {
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, errors.New("invalid job ID")
+	}
+
+	job, err := s.jobRepo.FindByID(jobUUID)
+	if err != nil {
+		return nil, errors.New("job not found")
+	}
+	if job.Status != models.JobStatusDead {
+		return nil, errors.New("only dead jobs can be retried")
+	}
+
+	job.Status = models.JobStatusPending
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.LockedAt = nil
+	job.LockedBy = ""
+	if err := s.jobRepo.Update(job); err != nil {
+		return nil, errors.New("failed to retry job")
+	}
+	return job, nil
+}
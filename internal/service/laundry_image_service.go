@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-go/internal/imaging"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/storage"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxGalleryImages = 20
+	imageMediumSide  = 1024
+	imageThumbSide   = 320
+)
+
+// Image variants served by GET /laundries/:id/images/:imageId/:variant.
+const (
+	ImageVariantOriginal = "original"
+	ImageVariantMedium   = "medium"
+	ImageVariantThumb    = "thumb"
+)
+
+type LaundryImageService interface {
+	Upload(ctx context.Context, ownerID, laundryID, kind string, data []byte) (*LaundryImageResponse, error)
+	Reorder(ownerID, laundryID string, imageIDs []string) ([]LaundryImageResponse, error)
+	Delete(ctx context.Context, ownerID, laundryID, imageID string) error
+	Open(ctx context.Context, laundryID, imageID, variant string) (string, io.ReadCloser, error)
+}
+
+type laundryImageService struct {
+	imageRepo     repository.LaundryImageRepository
+	laundryRepo   repository.LaundryRepository
+	transactor    repository.Transactor
+	store         storage.BlobStore
+	maxUploadSize int64
+}
+
+type ReorderLaundryImagesRequest struct {
+	ImageIDs []string `json:"image_ids"` // every gallery image, in the new order
+}
+
+type LaundryImageResponse struct {
+	ID           string    `json:"id"`
+	Kind         string    `json:"kind"`
+	Position     int       `json:"position"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url"`
+	MediumURL    string    `json:"medium_url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewLaundryImageService(imageRepo repository.LaundryImageRepository, laundryRepo repository.LaundryRepository, transactor repository.Transactor, store storage.BlobStore, maxUploadSize int64) LaundryImageService {
+	return &laundryImageService{
+		imageRepo:     imageRepo,
+		laundryRepo:   laundryRepo,
+		transactor:    transactor,
+		store:         store,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// Upload stores an image with its medium and thumbnail variants. A new
+// logo or cover replaces the previous one; gallery images are appended.
+func (s *laundryImageService) Upload(ctx context.Context, ownerID, laundryID, kind string, data []byte) (*LaundryImageResponse, error) {
+	laundry, err := s.ownedLaundry(ownerID, laundryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind != models.LaundryImageLogo && kind != models.LaundryImageCover && kind != models.LaundryImageGallery {
+		return nil, errors.New("kind must be logo, cover or gallery")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file is required")
+	}
+	if int64(len(data)) > s.maxUploadSize {
+		return nil, fmt.Errorf("files cannot be larger than %d MB", s.maxUploadSize>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := proofExtensions[contentType]
+	if !ok {
+		return nil, errors.New("files must be JPEG, PNG or WebP images")
+	}
+
+	if kind == models.LaundryImageGallery {
+		count, err := s.imageRepo.CountByKind(laundry.ID, kind)
+		if err != nil {
+			return nil, errors.New("failed to upload image")
+		}
+		if count >= maxGalleryImages {
+			return nil, fmt.Errorf("a gallery can hold at most %d images", maxGalleryImages)
+		}
+	}
+
+	decoded, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	medium, err := imaging.EncodeJPEG(imaging.Fit(decoded, imageMediumSide))
+	if err != nil {
+		return nil, errors.New("failed to process image")
+	}
+	thumb, err := imaging.EncodeJPEG(imaging.Fit(decoded, imageThumbSide))
+	if err != nil {
+		return nil, errors.New("failed to process image")
+	}
+
+	id := uuid.New()
+	prefix := fmt.Sprintf("laundries/%s/%s/", laundry.ID, id)
+	image := &models.LaundryImage{
+		ID:          id,
+		LaundryID:   laundry.ID,
+		Kind:        kind,
+		ContentType: contentType,
+		Width:       decoded.Bounds().Dx(),
+		Height:      decoded.Bounds().Dy(),
+		Size:        int64(len(data)),
+		OriginalKey: prefix + ImageVariantOriginal + extension,
+		MediumKey:   prefix + ImageVariantMedium + ".jpg",
+		ThumbKey:    prefix + ImageVariantThumb + ".jpg",
+		UploadedBy:  laundry.OwnerID,
+	}
+
+	stored := make([]string, 0, 3)
+	for _, blob := range []struct {
+		key         string
+		data        []byte
+		contentType string
+	}{
+		{image.OriginalKey, data, contentType},
+		{image.MediumKey, medium, "image/jpeg"},
+		{image.ThumbKey, thumb, "image/jpeg"},
+	} {
+		if err := s.store.Put(ctx, blob.key, blob.data, blob.contentType); err != nil {
+			log.Printf("laundry image: failed to store %s: %v", blob.key, err)
+			deleteBlobs(s.store, stored)
+			return nil, errors.New("failed to store file")
+		}
+		stored = append(stored, blob.key)
+	}
+
+	var replaced []models.LaundryImage
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		imageRepo := s.imageRepo.WithTx(tx)
+
+		if kind == models.LaundryImageGallery {
+			position, err := imageRepo.MaxPosition(laundry.ID, kind)
+			if err != nil {
+				return err
+			}
+			image.Position = position + 1
+		} else {
+			replaced, err = imageRepo.FindByKind(laundry.ID, kind)
+			if err != nil {
+				return err
+			}
+			for _, old := range replaced {
+				if err := imageRepo.Delete(old.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		return imageRepo.Create(image)
+	})
+	if err != nil {
+		deleteBlobs(s.store, stored)
+		return nil, errors.New("failed to upload image")
+	}
+
+	for i := range replaced {
+		deleteBlobs(s.store, imageKeys(&replaced[i]))
+	}
+
+	response := toLaundryImageResponse(image)
+	return &response, nil
+}
+
+// Reorder sets the gallery order. imageIDs must list every gallery image
+// exactly once.
+func (s *laundryImageService) Reorder(ownerID, laundryID string, imageIDs []string) ([]LaundryImageResponse, error) {
+	laundry, err := s.ownedLaundry(ownerID, laundryID)
+	if err != nil {
+		return nil, err
+	}
+
+	gallery, err := s.imageRepo.FindByKind(laundry.ID, models.LaundryImageGallery)
+	if err != nil {
+		return nil, errors.New("failed to fetch images")
+	}
+
+	remaining := make(map[string]bool, len(gallery))
+	for _, image := range gallery {
+		remaining[image.ID.String()] = true
+	}
+	if len(imageIDs) != len(gallery) {
+		return nil, errors.New("image_ids must list every gallery image once")
+	}
+	for _, id := range imageIDs {
+		if !remaining[id] {
+			return nil, errors.New("image_ids must list every gallery image once")
+		}
+		delete(remaining, id)
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		imageRepo := s.imageRepo.WithTx(tx)
+		for position, id := range imageIDs {
+			if err := imageRepo.UpdatePosition(uuid.MustParse(id), position); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("failed to reorder images")
+	}
+
+	images, err := s.imageRepo.FindByLaundryID(laundry.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch images")
+	}
+	items := make([]LaundryImageResponse, 0, len(images))
+	for i := range images {
+		if images[i].Kind == models.LaundryImageGallery {
+			items = append(items, toLaundryImageResponse(&images[i]))
+		}
+	}
+	return items, nil
+}
+
+func (s *laundryImageService) Delete(ctx context.Context, ownerID, laundryID, imageID string) error {
+	laundry, err := s.ownedLaundry(ownerID, laundryID)
+	if err != nil {
+		return err
+	}
+
+	image, err := s.findImage(laundry.ID.String(), imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.imageRepo.Delete(image.ID); err != nil {
+		return errors.New("failed to delete image")
+	}
+	deleteBlobs(s.store, imageKeys(image))
+	return nil
+}
+
+// Open returns the content type and content of an image variant. Images
+// are public. The caller must close the reader.
+func (s *laundryImageService) Open(ctx context.Context, laundryID, imageID, variant string) (string, io.ReadCloser, error) {
+	image, err := s.findImage(laundryID, imageID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key, contentType := image.ThumbKey, "image/jpeg"
+	switch variant {
+	case ImageVariantOriginal:
+		key, contentType = image.OriginalKey, image.ContentType
+	case ImageVariantMedium:
+		key = image.MediumKey
+	case ImageVariantThumb:
+	default:
+		return "", nil, errors.New("variant must be original, medium or thumb")
+	}
+
+	reader, err := s.store.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("laundry image: failed to read %s: %v", key, err)
+		}
+		return "", nil, errors.New("image not found")
+	}
+	return contentType, reader, nil
+}
+
+func (s *laundryImageService) findImage(laundryID, imageID string) (*models.LaundryImage, error) {
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, errors.New("invalid image ID")
+	}
+
+	image, err := s.imageRepo.FindByID(imageUUID)
+	if err != nil || image.LaundryID != laundryUUID {
+		return nil, errors.New("image not found")
+	}
+	return image, nil
+}
+
+func (s *laundryImageService) ownedLaundry(ownerID, laundryID string) (*models.Laundry, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+	if laundry.OwnerID != ownerUUID {
+		return nil, errors.New("unauthorized")
+	}
+	return laundry, nil
+}
+
+// primaryLaundryImage picks the image that represents a laundry in lists:
+// the cover, else the logo, else the first gallery image. images must be
+// in gallery order.
+func primaryLaundryImage(images []models.LaundryImage) *models.LaundryImage {
+	var logo, gallery *models.LaundryImage
+	for i := range images {
+		switch images[i].Kind {
+		case models.LaundryImageCover:
+			return &images[i]
+		case models.LaundryImageLogo:
+			logo = &images[i]
+		case models.LaundryImageGallery:
+			if gallery == nil {
+				gallery = &images[i]
+			}
+		}
+	}
+	if logo != nil {
+		return logo
+	}
+	return gallery
+}
+
+func laundryImageURL(image *models.LaundryImage, variant string) string {
+	return fmt.Sprintf("/api/v1/laundries/%s/images/%s/%s", image.LaundryID, image.ID, variant)
+}
+
+func imageKeys(image *models.LaundryImage) []string {
+	return []string{image.OriginalKey, image.MediumKey, image.ThumbKey}
+}
+
+// deleteBlobs removes files best-effort, logging failures.
+func deleteBlobs(store storage.BlobStore, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(context.Background(), key); err != nil {
+			log.Printf("storage: failed to delete %s: %v", key, err)
+		}
+	}
+}
+
+func toLaundryImageResponse(image *models.LaundryImage) LaundryImageResponse {
+	return LaundryImageResponse{
+		ID:           image.ID.String(),
+		Kind:         image.Kind,
+		Position:     image.Position,
+		Width:        image.Width,
+		Height:       image.Height,
+		URL:          laundryImageURL(image, ImageVariantOriginal),
+		MediumURL:    laundryImageURL(image, ImageVariantMedium),
+		ThumbnailURL: laundryImageURL(image, ImageVariantThumb),
+		CreatedAt:    image.CreatedAt,
+	}
+}
@@ -2,7 +2,7 @@ package service
 
 import (
 	"errors"
-	"fmt"
+	"laundry-go/internal/models"
 	"laundry-go/internal/repository"
 	"laundry-go/internal/utils"
 
@@ -10,20 +10,57 @@ import (
 )
 
 type LaundryService interface {
-	GetAll(search string, isOpen *bool, lat, lng *float64, userID *string, page, limit int) (*LaundryListResponse, error)
-	GetByID(id string, lat, lng *float64) (*LaundryDetailResponse, error)
+	GetAll(filter LaundryListFilter, lat, lng *float64, userID *string, locale string, page, limit int) (*LaundryListResponse, error)
+	GetByID(id string, lat, lng *float64, locale string) (*LaundryDetailResponse, error)
 }
 
+// ratingFacets are the minimum ratings counted in the rating facet.
+var ratingFacets = []float64{4.5, 4, 3, 2}
+
 type laundryService struct {
 	laundryRepo repository.LaundryRepository
 	serviceRepo repository.ServiceRepository
 	userRepo    repository.UserRepository
+	imageRepo   repository.LaundryImageRepository
+}
+
+// LaundryListFilter narrows the laundry list. Categories, PriceUnit,
+// MinPrice and MaxPrice must all hold for the same active service.
+type LaundryListFilter struct {
+	Search        string
+	IsOpen        *bool
+	Categories    []string
+	PriceUnit     string
+	MinPrice      *models.Money
+	MaxPrice      *models.Money
+	MinRating     *float64
+	Delivery      bool
+	MaxDistanceKm *float64
 }
 
 type LaundryListResponse struct {
-	Laundries   []LaundryListItem `json:"laundries"`
-	Pagination  Pagination        `json:"pagination"`
-	UserLocation *UserLocation    `json:"user_location,omitempty"`
+	Laundries    []LaundryListItem `json:"laundries"`
+	Pagination   Pagination        `json:"pagination"`
+	Facets       LaundryFacets     `json:"facets"`
+	UserLocation *UserLocation     `json:"user_location,omitempty"`
+}
+
+// LaundryFacets count the laundries matching the other filters for each
+// category and minimum rating, so clients can show how many results
+// choosing one would give.
+type LaundryFacets struct {
+	Categories []CategoryFacet `json:"categories"`
+	Ratings    []RatingFacet   `json:"ratings"`
+}
+
+type CategoryFacet struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+type RatingFacet struct {
+	MinRating float64 `json:"min_rating"`
+	Count     int     `json:"count"`
 }
 
 type UserLocation struct {
@@ -32,32 +69,45 @@ type UserLocation struct {
 }
 
 type LaundryListItem struct {
-	ID              string              `json:"id"`
-	Name            string              `json:"name"`
-	Description     string              `json:"description"`
-	Address         string              `json:"address"`
-	Rating          float64             `json:"rating"`
-	ReviewCount     int                 `json:"review_count"`
-	Image           string              `json:"image"`
-	PriceRange      string              `json:"price_range"`
-	Distance        *float64            `json:"distance,omitempty"`
-	IsOpen          bool                `json:"is_open"`
-	OperatingHours OperatingHours      `json:"operating_hours"`
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	Description      string         `json:"description"`
+	Address          string         `json:"address"`
+	Rating           float64        `json:"rating"`
+	ReviewCount      int            `json:"review_count"`
+	Image            string         `json:"image"`
+	PriceRange       string         `json:"price_range"`
+	PriceMin         models.Money   `json:"price_min"`
+	PriceMax         models.Money   `json:"price_max"`
+	Currency         string         `json:"currency"`
+	Distance         *float64       `json:"distance,omitempty"`
+	IsOpen           bool           `json:"is_open"`
+	OffersDelivery   bool           `json:"offers_delivery"`
+	DeliveryRadiusKm *float64       `json:"delivery_radius_km,omitempty"`
+	OperatingHours   OperatingHours `json:"operating_hours"`
 }
 
 type LaundryDetailResponse struct {
-	ID              string              `json:"id"`
-	Name            string              `json:"name"`
-	Description     string              `json:"description"`
-	Address         string              `json:"address"`
-	Rating          float64             `json:"rating"`
-	ReviewCount     int                 `json:"review_count"`
-	Image           string              `json:"image"`
-	PriceRange      string              `json:"price_range"`
-	Distance        *float64            `json:"distance,omitempty"`
-	IsOpen          bool                `json:"is_open"`
-	OperatingHours OperatingHours      `json:"operating_hours"`
-	Services        []ServiceResponse   `json:"services"`
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	Address          string                 `json:"address"`
+	Rating           float64                `json:"rating"`
+	ReviewCount      int                    `json:"review_count"`
+	Image            string                 `json:"image"`
+	PriceRange       string                 `json:"price_range"`
+	PriceMin         models.Money           `json:"price_min"`
+	PriceMax         models.Money           `json:"price_max"`
+	Currency         string                 `json:"currency"`
+	Distance         *float64               `json:"distance,omitempty"`
+	IsOpen           bool                   `json:"is_open"`
+	OffersDelivery   bool                   `json:"offers_delivery"`
+	DeliveryRadiusKm *float64               `json:"delivery_radius_km,omitempty"`
+	OperatingHours   OperatingHours         `json:"operating_hours"`
+	Services         []ServiceResponse      `json:"services"`
+	Logo             *LaundryImageResponse  `json:"logo,omitempty"`
+	Cover            *LaundryImageResponse  `json:"cover,omitempty"`
+	Gallery          []LaundryImageResponse `json:"gallery"`
 }
 
 type OperatingHours struct {
@@ -66,13 +116,14 @@ type OperatingHours struct {
 }
 
 type ServiceResponse struct {
-	ID              string  `json:"id"`
-	Name            string  `json:"name"`
-	Description     string  `json:"description"`
-	Price           float64 `json:"price"`
-	Unit            string  `json:"unit"`
-	EstimatedTime   int     `json:"estimated_time"`
-	Category        string  `json:"category"`
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Description    string       `json:"description"`
+	Price          models.Money `json:"price"`
+	PriceFormatted string       `json:"price_formatted"`
+	Unit           string       `json:"unit"`
+	EstimatedTime  int          `json:"estimated_time"`
+	Category       string       `json:"category"`
 }
 
 type Pagination struct {
@@ -82,15 +133,25 @@ type Pagination struct {
 	TotalPages int `json:"total_pages"`
 }
 
-func NewLaundryService(laundryRepo repository.LaundryRepository, serviceRepo repository.ServiceRepository, userRepo repository.UserRepository) LaundryService {
+func NewLaundryService(laundryRepo repository.LaundryRepository, serviceRepo repository.ServiceRepository, userRepo repository.UserRepository, imageRepo repository.LaundryImageRepository) LaundryService {
 	return &laundryService{
 		laundryRepo: laundryRepo,
 		serviceRepo: serviceRepo,
 		userRepo:    userRepo,
+		imageRepo:   imageRepo,
 	}
 }
 
-func (s *laundryService) GetAll(search string, isOpen *bool, lat, lng *float64, userID *string, page, limit int) (*LaundryListResponse, error) {
+// InvalidFilterError is a laundry list filter the caller has to correct.
+type InvalidFilterError struct {
+	Message string
+}
+
+func (e *InvalidFilterError) Error() string {
+	return e.Message
+}
+
+func (s *laundryService) GetAll(filter LaundryListFilter, lat, lng *float64, userID *string, locale string, page, limit int) (*LaundryListResponse, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -98,12 +159,29 @@ func (s *laundryService) GetAll(search string, isOpen *bool, lat, lng *float64,
 		limit = 10
 	}
 
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, &InvalidFilterError{Message: "min_price must not be greater than max_price"}
+	}
+	if filter.MinRating != nil && (*filter.MinRating < 0 || *filter.MinRating > 5) {
+		return nil, &InvalidFilterError{Message: "min_rating must be between 0 and 5"}
+	}
+	if filter.MaxDistanceKm != nil && *filter.MaxDistanceKm <= 0 {
+		return nil, &InvalidFilterError{Message: "max_distance must be greater than zero"}
+	}
+
 	// Prioritas penggunaan lat/lng:
 	// 1. Query params (lat, lng) - PRIORITAS TERTINGGI
 	// 2. User profile lat/lng (jika user sudah login dan punya lokasi)
 	// 3. Null/0 (jika tidak ada sama sekali)
 	var finalLat, finalLng *float64
 	var userLocation *UserLocation
+	var user *models.User
+
+	if userID != nil {
+		if userUUID, err := uuid.Parse(*userID); err == nil {
+			user, _ = s.userRepo.FindByID(userUUID)
+		}
+	}
 
 	if lat != nil && lng != nil {
 		// Gunakan query params jika ada
@@ -113,32 +191,68 @@ func (s *laundryService) GetAll(search string, isOpen *bool, lat, lng *float64,
 			Latitude:  *lat,
 			Longitude: *lng,
 		}
-	} else if userID != nil {
+	} else if user != nil && user.Latitude != nil && user.Longitude != nil {
 		// Coba ambil dari user profile
-		userUUID, err := uuid.Parse(*userID)
-		if err == nil {
-			user, err := s.userRepo.FindByID(userUUID)
-			if err == nil && user.Latitude != nil && user.Longitude != nil {
-				finalLat = user.Latitude
-				finalLng = user.Longitude
-				userLocation = &UserLocation{
-					Latitude:  *user.Latitude,
-					Longitude: *user.Longitude,
-				}
-			}
+		finalLat = user.Latitude
+		finalLng = user.Longitude
+		userLocation = &UserLocation{
+			Latitude:  *user.Latitude,
+			Longitude: *user.Longitude,
 		}
 	}
 
-	laundries, total, err := s.laundryRepo.FindAll(search, isOpen, page, limit)
+	if filter.MaxDistanceKm != nil && finalLat == nil {
+		return nil, &InvalidFilterError{Message: "max_distance needs lat and lng or a saved location"}
+	}
+
+	locale = resolveLocale(locale, user)
+
+	repoFilter := repository.LaundryFilter{
+		Search:        filter.Search,
+		IsOpen:        filter.IsOpen,
+		Categories:    filter.Categories,
+		PriceUnit:     filter.PriceUnit,
+		MinPrice:      filter.MinPrice,
+		MaxPrice:      filter.MaxPrice,
+		MinRating:     filter.MinRating,
+		Delivery:      filter.Delivery,
+		MaxDistanceKm: filter.MaxDistanceKm,
+		Latitude:      finalLat,
+		Longitude:     finalLng,
+	}
+
+	// Ordering happens in the query: by relevance when searching, else by
+	// distance when a location is known, else by rating
+	laundries, total, err := s.laundryRepo.FindAll(repoFilter, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch laundries")
+	}
+
+	facets, err := s.facets(repoFilter)
+	if err != nil {
+		return nil, errors.New("failed to fetch laundries")
+	}
+
+	laundryIDs := make([]uuid.UUID, 0, len(laundries))
+	for _, laundry := range laundries {
+		laundryIDs = append(laundryIDs, laundry.ID)
+	}
+	images, err := s.imageRepo.FindByLaundryIDs(laundryIDs)
+	if err != nil {
+		return nil, errors.New("failed to fetch laundries")
+	}
+	imagesByLaundry := make(map[uuid.UUID][]models.LaundryImage)
+	for _, image := range images {
+		imagesByLaundry[image.LaundryID] = append(imagesByLaundry[image.LaundryID], image)
+	}
+	priceRanges, err := s.serviceRepo.GetPriceRanges(laundryIDs)
 	if err != nil {
 		return nil, errors.New("failed to fetch laundries")
 	}
 
 	items := make([]LaundryListItem, 0, len(laundries))
 	for _, laundry := range laundries {
-		// Get price range
-		minPrice, maxPrice, _ := s.serviceRepo.GetPriceRange(laundry.ID)
-		priceRange := formatPriceRange(minPrice, maxPrice)
+		minPrice, maxPrice := priceRanges[laundry.ID].Min, priceRanges[laundry.ID].Max
 
 		// Calculate distance if lat/lng provided
 		var distance *float64
@@ -147,48 +261,7 @@ func (s *laundryService) GetAll(search string, isOpen *bool, lat, lng *float64,
 			distance = &dist
 		}
 
-		items = append(items, LaundryListItem{
-			ID:              laundry.ID.String(),
-			Name:            laundry.Name,
-			Description:     laundry.Description,
-			Address:         laundry.Address,
-			Rating:          laundry.Rating,
-			ReviewCount:     laundry.ReviewCount,
-			Image:           laundry.ImageURL,
-			PriceRange:      priceRange,
-			Distance:        distance,
-			IsOpen:          laundry.IsOpen,
-			OperatingHours: OperatingHours{
-				Open:  string(laundry.OperatingHoursOpen),
-				Close: string(laundry.OperatingHoursClose),
-			},
-		})
-	}
-
-	// Sort by distance if lat/lng tersedia, else sort by rating
-	if finalLat != nil && finalLng != nil {
-		// Sort by distance (nearest first)
-		for i := 0; i < len(items)-1; i++ {
-			for j := i + 1; j < len(items); j++ {
-				if items[i].Distance != nil && items[j].Distance != nil {
-					if *items[i].Distance > *items[j].Distance {
-						items[i], items[j] = items[j], items[i]
-					}
-				} else if items[i].Distance == nil && items[j].Distance != nil {
-					// Items without distance go to the end
-					items[i], items[j] = items[j], items[i]
-				}
-			}
-		}
-	} else {
-		// Sort by rating (highest first)
-		for i := 0; i < len(items)-1; i++ {
-			for j := i + 1; j < len(items); j++ {
-				if items[i].Rating < items[j].Rating {
-					items[i], items[j] = items[j], items[i]
-				}
-			}
-		}
+		items = append(items, toLaundryListItem(&laundry, minPrice, maxPrice, imagesByLaundry[laundry.ID], distance, locale))
 	}
 
 	totalPages := int(total) / limit
@@ -197,18 +270,42 @@ func (s *laundryService) GetAll(search string, isOpen *bool, lat, lng *float64,
 	}
 
 	return &LaundryListResponse{
-		Laundries:    items,
+		Laundries: items,
 		Pagination: Pagination{
 			Page:       page,
 			Limit:      limit,
 			Total:      int(total),
 			TotalPages: totalPages,
 		},
+		Facets:       *facets,
 		UserLocation: userLocation,
 	}, nil
 }
 
-func (s *laundryService) GetByID(id string, lat, lng *float64) (*LaundryDetailResponse, error) {
+func (s *laundryService) facets(filter repository.LaundryFilter) (*LaundryFacets, error) {
+	categoryCounts, err := s.laundryRepo.CountCategories(filter)
+	if err != nil {
+		return nil, err
+	}
+	ratingCounts, err := s.laundryRepo.CountMinRatings(filter, ratingFacets)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &LaundryFacets{
+		Categories: make([]CategoryFacet, 0, len(categoryCounts)),
+		Ratings:    make([]RatingFacet, 0, len(ratingFacets)),
+	}
+	for _, count := range categoryCounts {
+		facets.Categories = append(facets.Categories, CategoryFacet{Category: count.Category, Count: int(count.Count)})
+	}
+	for i, minRating := range ratingFacets {
+		facets.Ratings = append(facets.Ratings, RatingFacet{MinRating: minRating, Count: int(ratingCounts[i])})
+	}
+	return facets, nil
+}
+
+func (s *laundryService) GetByID(id string, lat, lng *float64, locale string) (*LaundryDetailResponse, error) {
 	uuid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, errors.New("invalid laundry ID")
@@ -221,7 +318,9 @@ func (s *laundryService) GetByID(id string, lat, lng *float64) (*LaundryDetailRe
 
 	// Get price range
 	minPrice, maxPrice, _ := s.serviceRepo.GetPriceRange(laundry.ID)
-	priceRange := formatPriceRange(minPrice, maxPrice)
+	locale = resolveLocale(locale, nil)
+	currency := currencyOrDefault(laundry.Currency)
+	priceRange := formatPriceRange(minPrice, maxPrice, currency, locale)
 
 	// Calculate distance if lat/lng provided
 	var distance *float64
@@ -234,42 +333,104 @@ func (s *laundryService) GetByID(id string, lat, lng *float64) (*LaundryDetailRe
 	services := make([]ServiceResponse, 0, len(laundry.Services))
 	for _, service := range laundry.Services {
 		services = append(services, ServiceResponse{
-			ID:            service.ID.String(),
-			Name:          service.Name,
-			Description:   service.Description,
-			Price:         service.Price,
-			Unit:          service.Unit,
-			EstimatedTime: service.EstimatedTimeHours,
-			Category:      service.Category,
+			ID:             service.ID.String(),
+			Name:           service.Name,
+			Description:    service.Description,
+			Price:          service.Price,
+			PriceFormatted: service.Price.Format(currency, locale),
+			Unit:           service.Unit,
+			EstimatedTime:  service.EstimatedTimeHours,
+			Category:       service.Category,
 		})
 	}
 
+	images, err := s.imageRepo.FindByLaundryID(laundry.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch laundry images")
+	}
+
+	image := laundry.ImageURL
+	if primary := primaryLaundryImage(images); primary != nil {
+		image = laundryImageURL(primary, ImageVariantMedium)
+	}
+
+	var logo, cover *LaundryImageResponse
+	gallery := make([]LaundryImageResponse, 0, len(images))
+	for i := range images {
+		response := toLaundryImageResponse(&images[i])
+		switch images[i].Kind {
+		case models.LaundryImageLogo:
+			logo = &response
+		case models.LaundryImageCover:
+			cover = &response
+		default:
+			gallery = append(gallery, response)
+		}
+	}
+
 	return &LaundryDetailResponse{
-		ID:              laundry.ID.String(),
-		Name:            laundry.Name,
-		Description:     laundry.Description,
-		Address:         laundry.Address,
-		Rating:          laundry.Rating,
-		ReviewCount:     laundry.ReviewCount,
-		Image:           laundry.ImageURL,
-		PriceRange:      priceRange,
-		Distance:        distance,
-		IsOpen:          laundry.IsOpen,
+		ID:               laundry.ID.String(),
+		Name:             laundry.Name,
+		Description:      laundry.Description,
+		Address:          laundry.Address,
+		Rating:           laundry.Rating,
+		ReviewCount:      laundry.ReviewCount,
+		Image:            image,
+		PriceRange:       priceRange,
+		PriceMin:         minPrice,
+		PriceMax:         maxPrice,
+		Currency:         currency,
+		Distance:         distance,
+		IsOpen:           laundry.IsOpen,
+		OffersDelivery:   laundry.OffersDelivery,
+		DeliveryRadiusKm: laundry.DeliveryRadiusKm,
 		OperatingHours: OperatingHours{
 			Open:  string(laundry.OperatingHoursOpen),
 			Close: string(laundry.OperatingHoursClose),
 		},
 		Services: services,
+		Logo:     logo,
+		Cover:    cover,
+		Gallery:  gallery,
 	}, nil
 }
 
-func formatPriceRange(minPrice, maxPrice float64) string {
-	if minPrice == 0 && maxPrice == 0 {
-		return "Rp 0"
+// toLaundryListItem builds the list entry of a laundry. Uploaded images win
+// over the legacy external URL.
+func toLaundryListItem(laundry *models.Laundry, minPrice, maxPrice models.Money, images []models.LaundryImage, distance *float64, locale string) LaundryListItem {
+	currency := currencyOrDefault(laundry.Currency)
+
+	image := laundry.ImageURL
+	if primary := primaryLaundryImage(images); primary != nil {
+		image = laundryImageURL(primary, ImageVariantThumb)
 	}
-	if minPrice == maxPrice {
-		return fmt.Sprintf("Rp %.0f", minPrice)
+
+	return LaundryListItem{
+		ID:               laundry.ID.String(),
+		Name:             laundry.Name,
+		Description:      laundry.Description,
+		Address:          laundry.Address,
+		Rating:           laundry.Rating,
+		ReviewCount:      laundry.ReviewCount,
+		Image:            image,
+		PriceRange:       formatPriceRange(minPrice, maxPrice, currency, locale),
+		PriceMin:         minPrice,
+		PriceMax:         maxPrice,
+		Currency:         currency,
+		Distance:         distance,
+		IsOpen:           laundry.IsOpen,
+		OffersDelivery:   laundry.OffersDelivery,
+		DeliveryRadiusKm: laundry.DeliveryRadiusKm,
+		OperatingHours: OperatingHours{
+			Open:  string(laundry.OperatingHoursOpen),
+			Close: string(laundry.OperatingHoursClose),
+		},
 	}
-	return fmt.Sprintf("Rp %.0f - Rp %.0f", minPrice, maxPrice)
 }
 
+func formatPriceRange(minPrice, maxPrice models.Money, currency, locale string) string {
+	if minPrice == maxPrice {
+		return minPrice.Format(currency, locale)
+	}
+	return minPrice.Format(currency, locale) + " - " + maxPrice.Format(currency, locale)
+}
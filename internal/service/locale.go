@@ -0,0 +1,27 @@
+package service
+
+import (
+	"laundry-go/internal/models"
+	"laundry-go/internal/utils"
+)
+
+// resolveLocale prefers the locale negotiated from the request, then the
+// user's saved locale, then utils.DefaultLocale.
+func resolveLocale(requested string, user *models.User) string {
+	if requested != "" {
+		return requested
+	}
+	if user != nil {
+		if locale := utils.NormalizeLocale(user.Locale); locale != "" {
+			return locale
+		}
+	}
+	return utils.DefaultLocale
+}
+
+func currencyOrDefault(code string) string {
+	if code == "" {
+		return models.DefaultCurrency
+	}
+	return code
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/config"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LoyaltyService interface {
+	GetSummary(userID string, page, limit int) (*LoyaltySummaryResponse, error)
+}
+
+type loyaltyService struct {
+	loyaltyRepo repository.LoyaltyRepository
+	cfg         config.LoyaltyConfig
+}
+
+type LoyaltySummaryResponse struct {
+	Balance    int64                  `json:"balance"`
+	PointValue models.Money           `json:"point_value"`
+	Entries    []LoyaltyEntryResponse `json:"entries"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+type LoyaltyEntryResponse struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Points          int64      `json:"points"`
+	RemainingPoints int64      `json:"remaining_points"`
+	OrderID         *string    `json:"order_id,omitempty"`
+	Description     string     `json:"description"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func NewLoyaltyService(loyaltyRepo repository.LoyaltyRepository, cfg config.LoyaltyConfig) LoyaltyService {
+	return &loyaltyService{
+		loyaltyRepo: loyaltyRepo,
+		cfg:         cfg,
+	}
+}
+
+func (s *loyaltyService) GetSummary(userID string, page, limit int) (*LoyaltySummaryResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	balance, err := s.loyaltyRepo.GetBalance(userUUID, time.Now())
+	if err != nil {
+		return nil, errors.New("failed to fetch loyalty balance")
+	}
+
+	entries, total, err := s.loyaltyRepo.FindByUserID(userUUID, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch loyalty history")
+	}
+
+	items := make([]LoyaltyEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		var orderID *string
+		if entry.OrderID != nil {
+			id := entry.OrderID.String()
+			orderID = &id
+		}
+		items = append(items, LoyaltyEntryResponse{
+			ID:              entry.ID.String(),
+			Type:            entry.Type,
+			Points:          entry.Points,
+			RemainingPoints: entry.RemainingPoints,
+			OrderID:         orderID,
+			Description:     entry.Description,
+			ExpiresAt:       entry.ExpiresAt,
+			CreatedAt:       entry.CreatedAt,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &LoyaltySummaryResponse{
+		Balance:    balance,
+		PointValue: s.cfg.PointValue,
+		Entries:    items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// earnedPoints converts an amount into points at rate points per 1.000 major
+// units (Rp1.000 for IDR), rounding down. The rate is normalised to 2 decimals.
+func earnedPoints(amount models.Money, rate float64) int64 {
+	if amount <= 0 || rate <= 0 {
+		return 0
+	}
+	rateHundredths := int64(math.Round(rate * 100))
+	// amount is in minor units (x100) and the rate in hundredths (x100)
+	return int64(amount) * rateHundredths / (1000 * 100 * 100)
+}
+
+// awardLoyaltyPoints credits points for a completed order on what is left of
+// its total after refunded claims. It is idempotent: an order earns points
+// at most once.
+func awardLoyaltyPoints(loyaltyRepo repository.LoyaltyRepository, cfg config.LoyaltyConfig, order *models.Order, refunded models.Money, now time.Time) error {
+	if existing, _ := loyaltyRepo.FindByOrderAndType(order.ID, models.LoyaltyEntryEarn); existing != nil {
+		return nil
+	}
+
+	rate := cfg.PointsPerThousand
+	if order.Laundry.LoyaltyPointsPerThousand != nil {
+		rate = *order.Laundry.LoyaltyPointsPerThousand
+	}
+
+	points := earnedPoints(order.TotalPrice-refunded, rate)
+	if points == 0 {
+		return nil
+	}
+
+	orderID := order.ID
+	laundryID := order.LaundryID
+	expiresAt := now.Add(cfg.Expiry)
+	return loyaltyRepo.Create(&models.LoyaltyLedgerEntry{
+		UserID:          order.UserID,
+		OrderID:         &orderID,
+		LaundryID:       &laundryID,
+		Type:            models.LoyaltyEntryEarn,
+		Points:          points,
+		RemainingPoints: points,
+		ExpiresAt:       &expiresAt,
+		Description:     "Points earned for completed order",
+	})
+}
+
+// redeemLoyaltyPoints spends points for an order, consuming the soonest
+// expiring credits first. The credits are locked so concurrent orders cannot
+// spend the same points twice.
+func redeemLoyaltyPoints(loyaltyRepo repository.LoyaltyRepository, order *models.Order, points int64, now time.Time) error {
+	credits, err := loyaltyRepo.FindRedeemableForUpdate(order.UserID, now)
+	if err != nil {
+		return errors.New("failed to redeem loyalty points")
+	}
+
+	var available int64
+	for _, credit := range credits {
+		available += credit.RemainingPoints
+	}
+	if available < points {
+		return invalidOrder("insufficient loyalty points")
+	}
+
+	if _, err := spendLoyaltyCredits(loyaltyRepo, credits, points); err != nil {
+		return errors.New("failed to redeem loyalty points")
+	}
+
+	orderID := order.ID
+	laundryID := order.LaundryID
+	if err := loyaltyRepo.Create(&models.LoyaltyLedgerEntry{
+		UserID:      order.UserID,
+		OrderID:     &orderID,
+		LaundryID:   &laundryID,
+		Type:        models.LoyaltyEntryRedeem,
+		Points:      -points,
+		Description: "Points redeemed for order",
+	}); err != nil {
+		return errors.New("failed to redeem loyalty points")
+	}
+	return nil
+}
+
+// spendLoyaltyCredits takes up to points from locked credits in order and
+// reports how many it took.
+func spendLoyaltyCredits(loyaltyRepo repository.LoyaltyRepository, credits []models.LoyaltyLedgerEntry, points int64) (int64, error) {
+	remaining := points
+	for _, credit := range credits {
+		if remaining == 0 {
+			break
+		}
+		used := credit.RemainingPoints
+		if used > remaining {
+			used = remaining
+		}
+		if err := loyaltyRepo.UpdateRemaining(credit.ID, credit.RemainingPoints-used); err != nil {
+			return points - remaining, err
+		}
+		remaining -= used
+	}
+	return points - remaining, nil
+}
+
+// reverseLoyaltyPoints takes back the points a completed order earned in
+// proportion to refunded, the total refunded on it so far. An order has one
+// reversal entry that grows with later refunds. Points the customer has
+// already spent are not clawed back.
+func reverseLoyaltyPoints(loyaltyRepo repository.LoyaltyRepository, order *models.Order, refunded models.Money, now time.Time) error {
+	earned, err := loyaltyRepo.FindByOrderAndType(order.ID, models.LoyaltyEntryEarn)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if order.TotalPrice <= 0 {
+		return nil
+	}
+	if refunded > order.TotalPrice {
+		refunded = order.TotalPrice
+	}
+
+	reversal, err := loyaltyRepo.FindByOrderAndType(order.ID, models.LoyaltyEntryReversal)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	var reversed int64
+	if reversal != nil {
+		reversed = -reversal.Points
+	}
+	due := earned.Points*int64(refunded)/int64(order.TotalPrice) - reversed
+	if due <= 0 {
+		return nil
+	}
+
+	credits, err := loyaltyRepo.FindRedeemableForUpdate(order.UserID, now)
+	if err != nil {
+		return err
+	}
+	taken, err := spendLoyaltyCredits(loyaltyRepo, credits, due)
+	if err != nil || taken == 0 {
+		return err
+	}
+
+	if reversal != nil {
+		return loyaltyRepo.UpdatePoints(reversal.ID, reversal.Points-taken)
+	}
+	orderID := order.ID
+	laundryID := order.LaundryID
+	return loyaltyRepo.Create(&models.LoyaltyLedgerEntry{
+		UserID:      order.UserID,
+		OrderID:     &orderID,
+		LaundryID:   &laundryID,
+		Type:        models.LoyaltyEntryReversal,
+		Points:      -taken,
+		Description: "Points taken back for refunded order",
+	})
+}
+
+// refundLoyaltyPoints returns the points spent on a cancelled order as a new
+// credit with a fresh expiry.
+func refundLoyaltyPoints(loyaltyRepo repository.LoyaltyRepository, cfg config.LoyaltyConfig, order *models.Order, now time.Time) error {
+	if order.PointsRedeemed == 0 {
+		return nil
+	}
+	if existing, _ := loyaltyRepo.FindByOrderAndType(order.ID, models.LoyaltyEntryRefund); existing != nil {
+		return nil
+	}
+
+	orderID := order.ID
+	laundryID := order.LaundryID
+	expiresAt := now.Add(cfg.Expiry)
+	return loyaltyRepo.Create(&models.LoyaltyLedgerEntry{
+		UserID:          order.UserID,
+		OrderID:         &orderID,
+		LaundryID:       &laundryID,
+		Type:            models.LoyaltyEntryRefund,
+		Points:          order.PointsRedeemed,
+		RemainingPoints: order.PointsRedeemed,
+		ExpiresAt:       &expiresAt,
+		Description:     "Points returned for cancelled order",
+	})
+}
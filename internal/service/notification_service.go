@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/notification"
+	"laundry-go/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	notificationBatchSize   = 50
+	notificationMaxAttempts = 5
+	notificationSendTimeout = 15 * time.Second
+	// notificationLease is how long a claimed batch may take to send before
+	// another dispatcher picks up what is left
+	notificationLease = notificationBatchSize*notificationSendTimeout + time.Minute
+)
+
+type NotificationService interface {
+	GetAll(userID string, page, limit int) (*NotificationListResponse, error)
+	GetPreferences(userID string) (*NotificationPreferenceResponse, error)
+	UpdatePreferences(userID string, req UpdateNotificationPreferenceRequest) (*NotificationPreferenceResponse, error)
+	Dispatch(ctx context.Context) (int, error)
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	transactor       repository.Transactor
+	senders          notification.Senders
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	Email     *bool   `json:"email"`
+	SMS       *bool   `json:"sms"`
+	WhatsApp  *bool   `json:"whatsapp"`
+	Push      *bool   `json:"push"`
+	PushToken *string `json:"push_token"`
+}
+
+type NotificationPreferenceResponse struct {
+	Email     bool   `json:"email"`
+	SMS       bool   `json:"sms"`
+	WhatsApp  bool   `json:"whatsapp"`
+	Push      bool   `json:"push"`
+	PushToken string `json:"push_token,omitempty"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Pagination    Pagination             `json:"pagination"`
+}
+
+type NotificationResponse struct {
+	ID        string     `json:"id"`
+	OrderID   *string    `json:"order_id,omitempty"`
+	Event     string     `json:"event"`
+	Channel   string     `json:"channel"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Status    string     `json:"status"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository, transactor repository.Transactor, senders notification.Senders) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		transactor:       transactor,
+		senders:          senders,
+	}
+}
+
+func (s *notificationService) GetAll(userID string, page, limit int) (*NotificationListResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	notifications, total, err := s.notificationRepo.FindByUserID(userUUID, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch notifications")
+	}
+
+	items := make([]NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		var orderID *string
+		if n.OrderID != nil {
+			id := n.OrderID.String()
+			orderID = &id
+		}
+		items = append(items, NotificationResponse{
+			ID:        n.ID.String(),
+			OrderID:   orderID,
+			Event:     n.Event,
+			Channel:   n.Channel,
+			Subject:   n.Subject,
+			Body:      n.Body,
+			Status:    n.Status,
+			SentAt:    n.SentAt,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &NotificationListResponse{
+		Notifications: items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *notificationService) GetPreferences(userID string) (*NotificationPreferenceResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	preference, err := s.notificationRepo.FindPreference(userUUID)
+	if err != nil {
+		return nil, errors.New("failed to fetch notification preferences")
+	}
+	return toNotificationPreferenceResponse(preference), nil
+}
+
+func (s *notificationService) UpdatePreferences(userID string, req UpdateNotificationPreferenceRequest) (*NotificationPreferenceResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	preference, err := s.notificationRepo.FindPreference(userUUID)
+	if err != nil {
+		return nil, errors.New("failed to fetch notification preferences")
+	}
+
+	if req.Email != nil {
+		preference.Email = *req.Email
+	}
+	if req.SMS != nil {
+		preference.SMS = *req.SMS
+	}
+	if req.WhatsApp != nil {
+		preference.WhatsApp = *req.WhatsApp
+	}
+	if req.Push != nil {
+		preference.Push = *req.Push
+	}
+	if req.PushToken != nil {
+		preference.PushToken = strings.TrimSpace(*req.PushToken)
+	}
+
+	if err := s.notificationRepo.SavePreference(preference); err != nil {
+		return nil, errors.New("failed to update notification preferences")
+	}
+	return toNotificationPreferenceResponse(preference), nil
+}
+
+// Dispatch sends one batch of due notifications and reports how many were
+// processed. The batch is claimed in a short transaction and sent outside
+// it; each result is then saved on its own, so one failed write does not
+// resend the rest. Failed sends are retried with exponential backoff and
+// marked failed after notificationMaxAttempts.
+func (s *notificationService) Dispatch(ctx context.Context) (int, error) {
+	var due []models.Notification
+	err := s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var err error
+		due, err = s.notificationRepo.WithTx(tx).ClaimDue(now, now.Add(notificationLease), notificationBatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	var firstErr error
+	for i := range due {
+		// The rest of the batch is picked up again once its lease runs out
+		if ctx.Err() != nil {
+			break
+		}
+
+		n := &due[i]
+		sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+		err := s.senders.Send(sendCtx, notification.Message{
+			Channel:   n.Channel,
+			Recipient: n.Recipient,
+			Subject:   n.Subject,
+			Body:      n.Body,
+		})
+		cancel()
+
+		now := time.Now()
+		if err == nil {
+			n.Status = models.NotificationStatusSent
+			n.SentAt = &now
+			n.LastError = ""
+		} else {
+			n.LastError = err.Error()
+			if n.Attempts >= notificationMaxAttempts {
+				n.Status = models.NotificationStatusFailed
+			} else {
+				n.Status = models.NotificationStatusPending
+				n.NextAttemptAt = now.Add(time.Duration(1<<uint(n.Attempts)) * 30 * time.Second)
+			}
+		}
+
+		if err := s.notificationRepo.Update(n); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		processed++
+	}
+	return processed, firstErr
+}
+
+// enqueueOrderNotifications renders an order event for the customer and
+// queues one notification per enabled channel. Call it inside the
+// transaction that makes the change, so the notification is sent if and
+// only if the change commits.
+func enqueueOrderNotifications(notificationRepo repository.NotificationRepository, user *models.User, order *models.Order, event string) error {
+	locale := resolveLocale("", user)
+	orderID := order.ID
+	return enqueueNotifications(notificationRepo, user, &orderID, event, notification.OrderData{
+		CustomerName: user.Name,
+		OrderNumber:  orderNumber(order.ID),
+		LaundryName:  order.Laundry.Name,
+		Total:        order.TotalPrice.Format(currencyOrDefault(order.Currency), locale),
+	})
+}
+
+// enqueueNotifications renders event with data in the user's language and
+// queues one notification per enabled channel. orderID is nil for
+// notifications that are not about an existing order.
+func enqueueNotifications(notificationRepo repository.NotificationRepository, user *models.User, orderID *uuid.UUID, event string, data interface{}) error {
+	preference, err := notificationRepo.FindPreference(user.ID)
+	if err != nil {
+		return err
+	}
+
+	subject, body, err := notification.Render(event, resolveLocale("", user), data)
+	if err != nil {
+		return err
+	}
+
+	recipients := map[string]string{}
+	if preference.Email {
+		recipients[models.NotificationChannelEmail] = user.Email
+	}
+	if preference.SMS {
+		recipients[models.NotificationChannelSMS] = user.Phone
+	}
+	if preference.WhatsApp {
+		recipients[models.NotificationChannelWhatsApp] = user.Phone
+	}
+	if preference.Push {
+		recipients[models.NotificationChannelPush] = preference.PushToken
+	}
+
+	now := time.Now()
+	for channel, recipient := range recipients {
+		if recipient == "" {
+			continue
+		}
+		if err := notificationRepo.Create(&models.Notification{
+			UserID:        user.ID,
+			OrderID:       orderID,
+			Event:         event,
+			Channel:       channel,
+			Recipient:     recipient,
+			Subject:       subject,
+			Body:          body,
+			Status:        models.NotificationStatusPending,
+			NextAttemptAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderNumber is the short order reference shown to customers.
+func orderNumber(id uuid.UUID) string {
+	return strings.ToUpper(id.String()[:8])
+}
+
+func toNotificationPreferenceResponse(preference *models.NotificationPreference) *NotificationPreferenceResponse {
+	return &NotificationPreferenceResponse{
+		Email:     preference.Email,
+		SMS:       preference.SMS,
+		WhatsApp:  preference.WhatsApp,
+		Push:      preference.Push,
+		PushToken: preference.PushToken,
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/json"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// orderState is the part of an order that subscribers are told about when
+// it changes.
+type orderState struct {
+	Status              string
+	PaymentStatus       string
+	EstimatedPickupAt   *time.Time
+	EstimatedDeliveryAt *time.Time
+}
+
+func snapshotOrder(order *models.Order) orderState {
+	return orderState{
+		Status:              order.Status,
+		PaymentStatus:       order.PaymentStatus,
+		EstimatedPickupAt:   order.EstimatedPickupAt,
+		EstimatedDeliveryAt: order.EstimatedDeliveryAt,
+	}
+}
+
+// orderEvents lists the events describing the change from before to the
+// order's current state.
+func orderEvents(before orderState, order *models.Order) []events.Event {
+	var list []events.Event
+	add := func(eventType string, extra map[string]interface{}) {
+		data := orderEventData(order)
+		for key, value := range extra {
+			data[key] = value
+		}
+		list = append(list, events.NewEvent(eventType, order.ID, order.UserID, order.LaundryID, data))
+	}
+
+	if order.Status != before.Status {
+		add(events.OrderStatusChanged, map[string]interface{}{"previous_status": before.Status})
+		if order.Status == "cancelled" {
+			add(events.OrderCancelled, nil)
+		}
+	}
+
+	if !sameTime(before.EstimatedPickupAt, order.EstimatedPickupAt) || !sameTime(before.EstimatedDeliveryAt, order.EstimatedDeliveryAt) {
+		add(events.OrderETAChanged, nil)
+	}
+
+	if order.PaymentStatus != before.PaymentStatus {
+		switch order.PaymentStatus {
+		case "paid":
+			add(events.PaymentSucceeded, nil)
+		case "refunded", "refund_pending":
+			add(events.PaymentRefunded, nil)
+		}
+	}
+	return list
+}
+
+func orderEventData(order *models.Order) map[string]interface{} {
+	return map[string]interface{}{
+		"status":             order.Status,
+		"payment_status":     order.PaymentStatus,
+		"payment_method":     order.PaymentMethod,
+		"total_price":        order.TotalPrice,
+		"currency":           currencyOrDefault(order.Currency),
+		"estimated_pickup":   order.EstimatedPickupAt,
+		"estimated_delivery": order.EstimatedDeliveryAt,
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// recordEvents writes events to the outbox. Call it inside the transaction
+// that makes the change, so events are delivered if and only if it commits.
+func recordEvents(outboxRepo repository.OutboxRepository, list []events.Event) error {
+	for _, event := range list {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		eventID, err := uuid.Parse(event.ID)
+		if err != nil {
+			return err
+		}
+		if err := outboxRepo.Create(&models.OutboxEvent{
+			ID:          eventID,
+			Type:        event.Type,
+			AggregateID: event.OrderID,
+			Payload:     string(payload),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
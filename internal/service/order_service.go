@@ -2,19 +2,71 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"laundry-go/internal/config"
+	"laundry-go/internal/events"
 	"laundry-go/internal/models"
+	"laundry-go/internal/notification"
 	"laundry-go/internal/repository"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type OrderService interface {
-	Create(userID string, req CreateOrderRequest) (*OrderResponse, error)
-	GetByUserID(userID, status string, page, limit int) (*OrderListResponse, error)
-	GetByID(userID, orderID string) (*OrderResponse, error)
-	CancelOrder(userID, orderID string) (*OrderResponse, error)
-	UpdateStatus(laundryOwnerID, orderID string, status string) (*OrderResponse, error)
+	Create(userID string, req CreateOrderRequest, locale string) (*OrderResponse, error)
+	GetByUserID(userID, status string, page, limit int, locale string) (*OrderListResponse, error)
+	GetByID(userID, orderID string, locale string) (*OrderResponse, error)
+	CancelOrder(userID, orderID string, req CancelOrderRequest, locale string) (*OrderResponse, error)
+	UpdateStatus(laundryOwnerID, orderID string, status string, locale string) (*OrderResponse, error)
+	MarkPaid(laundryOwnerID, orderID string, locale string) (*OrderResponse, error)
+	GetBoard(laundryOwnerID string, locale string) (*OrderBoardResponse, error)
+	GetHistory(userID, orderID string) ([]OrderStatusHistoryResponse, error)
+	Reorder(userID, orderID string, req ReorderRequest, locale string) (*ReorderResponse, error)
+	ExpireUnconfirmed() (int, error)
+
+	// transition moves an order to a new status inside tx with every side
+	// effect of a status change. It is used by other services in this
+	// package, such as deliveries.
+	transition(tx *gorm.DB, order *models.Order, status string, changedBy *uuid.UUID, reason string) error
+	// create places an order inside tx, for recurring orders placed in the
+	// transaction that schedules them.
+	create(tx *gorm.DB, userID string, req CreateOrderRequest) (*models.Order, error)
+}
+
+// orderValidationError is why create refused an order that retrying will
+// not change, such as an inactive service or an expired promo code.
+type orderValidationError struct {
+	message string
+}
+
+func (e *orderValidationError) Error() string {
+	return e.message
+}
+
+func invalidOrder(message string) error {
+	return &orderValidationError{message: message}
+}
+
+const (
+	// orderExpiryBatchSize caps how many overdue orders one expiry run cancels
+	orderExpiryBatchSize = 100
+	orderExpiredReason   = "not confirmed by the laundry in time"
+)
+
+// orderTransitions lists the statuses an order may move to from each
+// status. Processing steps may be skipped, an order can be cancelled until
+// it is delivered, and nothing leaves completed or cancelled.
+var orderTransitions = map[string][]string{
+	"pending":   {"confirmed", "cancelled"},
+	"confirmed": {"picked-up", "cancelled"},
+	"picked-up": {"washing", "drying", "ironing", "ready", "cancelled"},
+	"washing":   {"drying", "ironing", "ready", "cancelled"},
+	"drying":    {"ironing", "ready", "cancelled"},
+	"ironing":   {"ready", "cancelled"},
+	"ready":     {"delivered", "cancelled"},
+	"delivered": {"completed"},
 }
 
 type orderService struct {
@@ -22,14 +74,72 @@ type orderService struct {
 	orderServiceRepo repository.OrderServiceRepository
 	serviceRepo      repository.ServiceRepository
 	laundryRepo      repository.LaundryRepository
+	userRepo         repository.UserRepository
+	promotionRepo    repository.PromotionRepository
+	loyaltyRepo      repository.LoyaltyRepository
+	walletRepo       repository.WalletRepository
+	subscriptionRepo repository.SubscriptionRepository
+	notificationRepo repository.NotificationRepository
+	outboxRepo       repository.OutboxRepository
+	deliveryRepo     repository.DeliveryRepository
+	locationRepo     repository.CourierLocationRepository
+	garmentRepo      repository.GarmentRepository
+	adjustmentRepo   repository.OrderAdjustmentRepository
+	claimRepo        repository.ClaimRepository
+	transactor       repository.Transactor
+	loyaltyCfg       config.LoyaltyConfig
+	orderCfg         config.OrderConfig
 }
 
 type CreateOrderRequest struct {
-	LaundryID        string                `json:"laundry_id"`
-	Services         []OrderServiceRequest `json:"services"`
-	DeliveryAddress  string                `json:"delivery_address"`
-	Notes            string                `json:"notes"`
-	EstimatedPickupAt *time.Time          `json:"estimated_pickup_at"`
+	LaundryID         string                `json:"laundry_id"`
+	Services          []OrderServiceRequest `json:"services"`
+	DeliveryAddress   string                `json:"delivery_address"`
+	DeliveryLatitude  *float64              `json:"delivery_latitude"` // defaults to the user's saved location
+	DeliveryLongitude *float64              `json:"delivery_longitude"`
+	Notes             string                `json:"notes"`
+	EstimatedPickupAt *time.Time            `json:"estimated_pickup_at"`
+	PromoCode         string                `json:"promo_code"`
+	RedeemPoints      int64                 `json:"redeem_points"`
+	PaymentMethod     string                `json:"payment_method"` // cash (default) or wallet
+
+	// recurringOrderID links an order placed by a recurring order template
+	recurringOrderID *uuid.UUID
+}
+
+type CancelOrderRequest struct {
+	RefundTo string `json:"refund_to"` // original (default) or wallet
+}
+
+// ReorderRequest overrides details of the order being repeated. Empty
+// fields are copied from that order, except the pickup time, promo code and
+// points, which are never carried over.
+type ReorderRequest struct {
+	DeliveryAddress   string     `json:"delivery_address"`
+	DeliveryLatitude  *float64   `json:"delivery_latitude"`
+	DeliveryLongitude *float64   `json:"delivery_longitude"`
+	Notes             string     `json:"notes"`
+	EstimatedPickupAt *time.Time `json:"estimated_pickup_at"`
+	PromoCode         string     `json:"promo_code"`
+	RedeemPoints      int64      `json:"redeem_points"`
+	PaymentMethod     string     `json:"payment_method"`
+}
+
+// ReorderResponse is the new order with the lines of the old one that could
+// not be repeated as they were.
+type ReorderResponse struct {
+	Order       *OrderResponse         `json:"order"`
+	Unavailable []ReorderServiceChange `json:"unavailable"` // left out of the new order
+	Repriced    []ReorderServiceChange `json:"repriced"`    // ordered at the current price
+}
+
+type ReorderServiceChange struct {
+	ServiceID     string        `json:"service_id"`
+	ServiceName   string        `json:"service_name"`
+	Quantity      float64       `json:"quantity"`
+	Reason        string        `json:"reason"` // removed, deactivated or repriced
+	PreviousPrice models.Money  `json:"previous_price"`
+	CurrentPrice  *models.Money `json:"current_price,omitempty"`
 }
 
 type OrderServiceRequest struct {
@@ -43,25 +153,63 @@ type OrderListResponse struct {
 }
 
 type OrderResponse struct {
-	ID                 string                `json:"id"`
-	LaundryID          string                `json:"laundry_id"`
-	LaundryName        string                `json:"laundry_name"`
-	Services           []OrderServiceDetail  `json:"services"`
-	TotalPrice         float64               `json:"total_price"`
-	Status             string                `json:"status"`
-	CreatedAt          time.Time             `json:"created_at"`
-	EstimatedPickup    *time.Time            `json:"estimated_pickup"`
-	EstimatedDelivery  *time.Time            `json:"estimated_delivery"`
-	Address            string                `json:"address"`
-	Notes              string                `json:"notes"`
+	ID                      string                 `json:"id"`
+	LaundryID               string                 `json:"laundry_id"`
+	LaundryName             string                 `json:"laundry_name"`
+	Services                []OrderServiceDetail   `json:"services"`
+	Subtotal                models.Money           `json:"subtotal"`
+	SubtotalFormatted       string                 `json:"subtotal_formatted"`
+	DiscountAmount          models.Money           `json:"discount_amount"`
+	DiscountFormatted       string                 `json:"discount_formatted"`
+	PromoCode               string                 `json:"promo_code,omitempty"`
+	PointsRedeemed          int64                  `json:"points_redeemed"`
+	PointsDiscount          models.Money           `json:"points_discount"`
+	PointsDiscountFormatted string                 `json:"points_discount_formatted"`
+	SubscriptionID          *string                `json:"subscription_id,omitempty"`
+	TotalPrice              models.Money           `json:"total_price"`
+	TotalPriceFormatted     string                 `json:"total_price_formatted"`
+	Currency                string                 `json:"currency"`
+	PaymentMethod           string                 `json:"payment_method"`
+	PaymentStatus           string                 `json:"payment_status"`
+	PaidAt                  *time.Time             `json:"paid_at,omitempty"`
+	Status                  string                 `json:"status"`
+	ConfirmBy               *time.Time             `json:"confirm_by,omitempty"`
+	CreatedAt               time.Time              `json:"created_at"`
+	EstimatedPickup         *time.Time             `json:"estimated_pickup"`
+	EstimatedDelivery       *time.Time             `json:"estimated_delivery"`
+	Address                 string                 `json:"address"`
+	DeliveryLatitude        *float64               `json:"delivery_latitude,omitempty"`
+	DeliveryLongitude       *float64               `json:"delivery_longitude,omitempty"`
+	Notes                   string                 `json:"notes"`
+	RecurringOrderID        *string                `json:"recurring_order_id,omitempty"` // set when placed by a recurring order
+	Tracking                *OrderTrackingResponse `json:"tracking,omitempty"`           // only on GET /orders/:id while a courier is on the road
+	Garments                []GarmentResponse      `json:"garments,omitempty"`           // only on GET /orders/:id once items are tagged
+}
+
+type OrderStatusHistoryResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason,omitempty"`
+	ChangedBy  *string   `json:"changed_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// OrderBoardResponse is the open work across all of an owner's laundries.
+type OrderBoardResponse struct {
+	LaundryIDs []string        `json:"laundry_ids"`
+	Orders     []OrderResponse `json:"orders"`
 }
 
 type OrderServiceDetail struct {
-	ServiceID   string  `json:"service_id"`
-	ServiceName string  `json:"service_name"`
-	Quantity    float64 `json:"quantity"`
-	Price       float64 `json:"price"`
-	Unit        string  `json:"unit"`
+	ServiceID         string       `json:"service_id"`
+	ServiceName       string       `json:"service_name"`
+	Quantity          float64      `json:"quantity"`
+	QuotaQuantity     float64      `json:"quota_quantity"` // kg covered by a subscription
+	Price             models.Money `json:"price"`
+	PriceFormatted    string       `json:"price_formatted"`
+	Unit              string       `json:"unit"`
+	Subtotal          models.Money `json:"subtotal"`
+	SubtotalFormatted string       `json:"subtotal_formatted"`
 }
 
 func NewOrderService(
@@ -69,67 +217,147 @@ func NewOrderService(
 	orderServiceRepo repository.OrderServiceRepository,
 	serviceRepo repository.ServiceRepository,
 	laundryRepo repository.LaundryRepository,
+	userRepo repository.UserRepository,
+	promotionRepo repository.PromotionRepository,
+	loyaltyRepo repository.LoyaltyRepository,
+	walletRepo repository.WalletRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	notificationRepo repository.NotificationRepository,
+	outboxRepo repository.OutboxRepository,
+	deliveryRepo repository.DeliveryRepository,
+	locationRepo repository.CourierLocationRepository,
+	garmentRepo repository.GarmentRepository,
+	adjustmentRepo repository.OrderAdjustmentRepository,
+	claimRepo repository.ClaimRepository,
+	transactor repository.Transactor,
+	loyaltyCfg config.LoyaltyConfig,
+	orderCfg config.OrderConfig,
 ) OrderService {
 	return &orderService{
 		orderRepo:        orderRepo,
 		orderServiceRepo: orderServiceRepo,
 		serviceRepo:      serviceRepo,
 		laundryRepo:      laundryRepo,
+		userRepo:         userRepo,
+		promotionRepo:    promotionRepo,
+		loyaltyRepo:      loyaltyRepo,
+		walletRepo:       walletRepo,
+		subscriptionRepo: subscriptionRepo,
+		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
+		deliveryRepo:     deliveryRepo,
+		locationRepo:     locationRepo,
+		garmentRepo:      garmentRepo,
+		adjustmentRepo:   adjustmentRepo,
+		claimRepo:        claimRepo,
+		transactor:       transactor,
+		loyaltyCfg:       loyaltyCfg,
+		orderCfg:         orderCfg,
 	}
 }
 
-func (s *orderService) Create(userID string, req CreateOrderRequest) (*OrderResponse, error) {
+func (s *orderService) Create(userID string, req CreateOrderRequest, locale string) (*OrderResponse, error) {
+	// The order, its lines, subscription quota, any promo redemption and spent
+	// points are written atomically
+	var order *models.Order
+	err := s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		var err error
+		order, err = s.create(tx, userID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toOrderResponse(order, s.localeFor(order.UserID, locale)), nil
+}
+
+// create validates and writes a new order inside tx. Errors the request
+// can be corrected for are *orderValidationError; callers sharing tx roll
+// back to a savepoint on any error, as the order may be partly written.
+func (s *orderService) create(tx *gorm.DB, userID string, req CreateOrderRequest) (*models.Order, error) {
 	// Validation
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
-		return nil, errors.New("invalid user ID")
+		return nil, invalidOrder("invalid user ID")
 	}
 
 	laundryUUID, err := uuid.Parse(req.LaundryID)
 	if err != nil {
-		return nil, errors.New("invalid laundry ID")
+		return nil, invalidOrder("invalid laundry ID")
 	}
 
 	if len(req.Services) == 0 {
-		return nil, errors.New("at least one service is required")
+		return nil, invalidOrder("at least one service is required")
 	}
 
 	if req.DeliveryAddress == "" {
-		return nil, errors.New("delivery address is required")
+		return nil, invalidOrder("delivery address is required")
+	}
+
+	if req.RedeemPoints < 0 {
+		return nil, invalidOrder("redeem_points cannot be negative")
+	}
+
+	paymentMethod := req.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = "cash"
+	}
+	if paymentMethod != "cash" && paymentMethod != "wallet" {
+		return nil, invalidOrder("payment_method must be cash or wallet")
 	}
 
 	// Verify laundry exists
 	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, invalidOrder("laundry not found")
+	}
 	if err != nil {
-		return nil, errors.New("laundry not found")
+		return nil, errors.New("failed to load laundry")
+	}
+
+	user, err := s.userRepo.FindByID(userUUID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, invalidOrder("user not found")
+	}
+	if err != nil {
+		return nil, errors.New("failed to load user")
 	}
 
-	// Calculate total price and create order services
-	var totalPrice float64
+	// Calculate subtotal and create order services.
+	// Each line is rounded once to the minor unit; the subtotal is the exact sum of lines.
+	var subtotalPrice models.Money
 	orderServices := make([]models.OrderService, 0, len(req.Services))
 	maxEstimatedHours := 0
 
 	for _, svcReq := range req.Services {
 		serviceUUID, err := uuid.Parse(svcReq.ServiceID)
 		if err != nil {
-			return nil, errors.New("invalid service ID")
+			return nil, invalidOrder("invalid service ID")
 		}
 
 		service, err := s.serviceRepo.FindByID(serviceUUID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invalidOrder("service not found")
+		}
 		if err != nil {
-			return nil, errors.New("service not found")
+			return nil, errors.New("failed to load service")
 		}
 
 		if !service.IsActive {
-			return nil, errors.New("service is not active")
+			return nil, invalidOrder("service is not active")
 		}
 
 		if service.LaundryID != laundryUUID {
-			return nil, errors.New("service does not belong to this laundry")
+			return nil, invalidOrder("service does not belong to this laundry")
+		}
+
+		if svcReq.Quantity <= 0 {
+			return nil, invalidOrder("quantity must be greater than zero")
 		}
 
-		subtotal := service.Price * svcReq.Quantity
-		totalPrice += subtotal
+		subtotal := service.Price.MulQuantity(svcReq.Quantity)
+		subtotalPrice += subtotal
 
 		if service.EstimatedTimeHours > maxEstimatedHours {
 			maxEstimatedHours = service.EstimatedTimeHours
@@ -152,59 +380,162 @@ func (s *orderService) Create(userID string, req CreateOrderRequest) (*OrderResp
 		estimatedDeliveryAt = &deliveryTime
 	}
 
-	// Create order
+	deliveryLatitude, deliveryLongitude := req.DeliveryLatitude, req.DeliveryLongitude
+	if (deliveryLatitude == nil) != (deliveryLongitude == nil) {
+		return nil, invalidOrder("delivery_latitude and delivery_longitude must be given together")
+	}
+	if deliveryLatitude == nil {
+		deliveryLatitude, deliveryLongitude = user.Latitude, user.Longitude
+	}
+
+	// The laundry must confirm before the deadline or the order expires
+	confirmWindow := s.orderCfg.ConfirmWindow
+	if laundry.ConfirmWindowMinutes != nil && *laundry.ConfirmWindowMinutes > 0 {
+		confirmWindow = time.Duration(*laundry.ConfirmWindowMinutes) * time.Minute
+	}
+	confirmBy := time.Now().Add(confirmWindow)
+
+	currency := currencyOrDefault(laundry.Currency)
 	order := &models.Order{
-		UserID:            userUUID,
-		LaundryID:         laundryUUID,
-		Status:            "pending",
-		TotalPrice:        totalPrice,
-		DeliveryAddress:   req.DeliveryAddress,
-		Notes:             req.Notes,
-		EstimatedPickupAt: req.EstimatedPickupAt,
+		ID:                  uuid.New(),
+		UserID:              userUUID,
+		LaundryID:           laundryUUID,
+		Status:              "pending",
+		Subtotal:            subtotalPrice,
+		TotalPrice:          subtotalPrice,
+		Currency:            currency,
+		PaymentMethod:       paymentMethod,
+		PaymentStatus:       "unpaid",
+		DeliveryAddress:     req.DeliveryAddress,
+		DeliveryLatitude:    deliveryLatitude,
+		DeliveryLongitude:   deliveryLongitude,
+		Notes:               req.Notes,
+		EstimatedPickupAt:   req.EstimatedPickupAt,
 		EstimatedDeliveryAt: estimatedDeliveryAt,
+		ConfirmBy:           &confirmBy,
+		RecurringOrderID:    req.recurringOrderID,
+	}
+
+	// Subscription quota covers kg lines first; discounts apply to the remainder
+	usages, err := drawSubscriptionQuota(s.subscriptionRepo.WithTx(tx), s.walletRepo.WithTx(tx), order, orderServices, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var redemption *models.PromotionRedemption
+	if promoCode := normalizePromoCode(req.PromoCode); promoCode != "" {
+		redemption, err = s.applyPromotion(tx, promoCode, order)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if err := s.orderRepo.Create(order); err != nil {
+	// Points pay for what is left after the promo; never more than the total
+	if req.RedeemPoints > 0 {
+		points := req.RedeemPoints
+		if maxPoints := int64(order.TotalPrice / s.loyaltyCfg.PointValue); points > maxPoints {
+			points = maxPoints
+		}
+		order.PointsRedeemed = points
+		order.PointsDiscount = s.loyaltyCfg.PointValue * models.Money(points)
+		order.TotalPrice -= order.PointsDiscount
+	}
+
+	// Wallet orders are paid up front, in the same transaction
+	if order.PaymentMethod == "wallet" {
+		paidAt := time.Now()
+		order.PaymentStatus = "paid"
+		order.PaidAt = &paidAt
+	}
+
+	if err := s.orderRepo.WithTx(tx).Create(order); err != nil {
 		return nil, errors.New("failed to create order")
 	}
+	if err := recordStatusChange(s.orderRepo.WithTx(tx), order, "", &userUUID, ""); err != nil {
+		return nil, err
+	}
+
+	if order.PointsRedeemed > 0 {
+		if err := redeemLoyaltyPoints(s.loyaltyRepo.WithTx(tx), order, order.PointsRedeemed, time.Now()); err != nil {
+			return nil, err
+		}
+	}
 
-	// Create order services
 	for i := range orderServices {
 		orderServices[i].OrderID = order.ID
 	}
-
-	if err := s.orderServiceRepo.CreateBatch(orderServices); err != nil {
+	if err := s.orderServiceRepo.WithTx(tx).CreateBatch(orderServices); err != nil {
 		return nil, errors.New("failed to create order services")
 	}
 
-	// Convert to response
-	serviceDetails := make([]OrderServiceDetail, 0, len(orderServices))
-	for _, os := range orderServices {
-		serviceDetails = append(serviceDetails, OrderServiceDetail{
-			ServiceID:   os.ServiceID.String(),
-			ServiceName: os.ServiceName,
-			Quantity:    os.Quantity,
-			Price:       os.UnitPrice,
-			Unit:        os.Unit,
-		})
+	for i := range usages {
+		if err := s.subscriptionRepo.WithTx(tx).CreateUsage(&usages[i]); err != nil {
+			return nil, errors.New("failed to use subscription quota")
+		}
 	}
 
-	return &OrderResponse{
-		ID:                order.ID.String(),
-		LaundryID:         order.LaundryID.String(),
-		LaundryName:       laundry.Name,
-		Services:          serviceDetails,
-		TotalPrice:        order.TotalPrice,
-		Status:            order.Status,
-		CreatedAt:         order.CreatedAt,
-		EstimatedPickup:   order.EstimatedPickupAt,
-		EstimatedDelivery: order.EstimatedDeliveryAt,
-		Address:           order.DeliveryAddress,
-		Notes:             order.Notes,
-	}, nil
+	if redemption != nil {
+		promotionRepo := s.promotionRepo.WithTx(tx)
+		if err := promotionRepo.CreateRedemption(redemption); err != nil {
+			return nil, errors.New("failed to redeem promo code")
+		}
+		if err := promotionRepo.IncrementUsage(redemption.PromotionID); err != nil {
+			return nil, errors.New("failed to redeem promo code")
+		}
+	}
+
+	if order.PaymentMethod == "wallet" && order.TotalPrice > 0 {
+		if err := payOrderFromWallet(s.walletRepo.WithTx(tx), order); err != nil {
+			return nil, walletError(err, "failed to charge wallet")
+		}
+	}
+
+	placed := *order
+	placed.Laundry = *laundry
+	if err := enqueueOrderNotifications(s.notificationRepo.WithTx(tx), user, &placed, notification.EventOrderPlaced); err != nil {
+		return nil, errors.New("failed to queue notifications")
+	}
+	if err := s.enqueueOwnerNotifications(tx, user, &placed); err != nil {
+		return nil, errors.New("failed to queue notifications")
+	}
+
+	created := []events.Event{events.NewEvent(events.OrderCreated, order.ID, order.UserID, order.LaundryID, orderEventData(order))}
+	if order.PaymentStatus == "paid" {
+		created = append(created, events.NewEvent(events.PaymentSucceeded, order.ID, order.UserID, order.LaundryID, orderEventData(order)))
+	}
+	if err := recordEvents(s.outboxRepo.WithTx(tx), created); err != nil {
+		return nil, errors.New("failed to record order events")
+	}
+
+	order.Laundry = *laundry
+	order.OrderServices = orderServices
+	return order, nil
+}
+
+// enqueueOwnerNotifications tells the laundry owner about a new order and
+// the deadline to confirm it. order must have its Laundry loaded.
+func (s *orderService) enqueueOwnerNotifications(tx *gorm.DB, customer *models.User, order *models.Order) error {
+	owner, err := s.userRepo.FindByID(order.Laundry.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	var confirmBy string
+	if order.ConfirmBy != nil {
+		confirmBy = order.ConfirmBy.In(s.orderCfg.Timezone).Format("02/01/2006 15:04")
+	}
+	orderID := order.ID
+	return enqueueNotifications(s.notificationRepo.WithTx(tx), owner, &orderID, notification.EventOrderReceived, notification.OwnerOrderData{
+		OwnerName:    owner.Name,
+		CustomerName: customer.Name,
+		OrderNumber:  orderNumber(order.ID),
+		LaundryName:  order.Laundry.Name,
+		Total:        order.TotalPrice.Format(currencyOrDefault(order.Currency), resolveLocale("", owner)),
+		ConfirmBy:    confirmBy,
+	})
 }
 
-func (s *orderService) GetByUserID(userID, status string, page, limit int) (*OrderListResponse, error) {
+func (s *orderService) GetByUserID(userID, status string, page, limit int, locale string) (*OrderListResponse, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, errors.New("invalid user ID")
@@ -222,9 +553,10 @@ func (s *orderService) GetByUserID(userID, status string, page, limit int) (*Ord
 		return nil, errors.New("failed to fetch orders")
 	}
 
+	locale = s.localeFor(userUUID, locale)
 	orderResponses := make([]OrderResponse, 0, len(orders))
 	for _, order := range orders {
-		orderResponses = append(orderResponses, *s.toOrderResponse(&order))
+		orderResponses = append(orderResponses, *s.toOrderResponse(&order, locale))
 	}
 
 	totalPages := int(total) / limit
@@ -243,7 +575,7 @@ func (s *orderService) GetByUserID(userID, status string, page, limit int) (*Ord
 	}, nil
 }
 
-func (s *orderService) GetByID(userID, orderID string) (*OrderResponse, error) {
+func (s *orderService) GetByID(userID, orderID string, locale string) (*OrderResponse, error) {
 	orderUUID, err := uuid.Parse(orderID)
 	if err != nil {
 		return nil, errors.New("invalid order ID")
@@ -264,10 +596,17 @@ func (s *orderService) GetByID(userID, orderID string) (*OrderResponse, error) {
 		return nil, errors.New("unauthorized")
 	}
 
-	return s.toOrderResponse(order), nil
+	response := s.toOrderResponse(order, s.localeFor(userUUID, locale))
+	if tracking, err := orderTracking(s.deliveryRepo, s.locationRepo, order, s.orderCfg.CourierSpeedKmh); err == nil && tracking.Active {
+		response.Tracking = tracking
+	}
+	if garments, err := s.garmentRepo.FindByOrderID(order.ID); err == nil && len(garments) > 0 {
+		response.Garments = toGarmentResponses(garments)
+	}
+	return response, nil
 }
 
-func (s *orderService) CancelOrder(userID, orderID string) (*OrderResponse, error) {
+func (s *orderService) CancelOrder(userID, orderID string, req CancelOrderRequest, locale string) (*OrderResponse, error) {
 	orderUUID, err := uuid.Parse(orderID)
 	if err != nil {
 		return nil, errors.New("invalid order ID")
@@ -288,20 +627,52 @@ func (s *orderService) CancelOrder(userID, orderID string) (*OrderResponse, erro
 		return nil, errors.New("unauthorized")
 	}
 
-	// Check if order can be cancelled
-	if order.Status != "pending" && order.Status != "confirmed" {
-		return nil, errors.New("order cannot be cancelled at this stage")
+	refundTo := req.RefundTo
+	if refundTo == "" {
+		refundTo = "original"
+	}
+	if refundTo != "original" && refundTo != "wallet" {
+		return nil, errors.New("refund_to must be original or wallet")
 	}
 
-	order.Status = "cancelled"
-	if err := s.orderRepo.Update(order); err != nil {
-		return nil, errors.New("failed to cancel order")
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		// Re-read under lock so an expiry or status change made since is
+		// not overwritten
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(orderUUID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		// Check if order can be cancelled
+		if order.Status != "pending" && order.Status != "confirmed" {
+			return errors.New("order cannot be cancelled at this stage")
+		}
+
+		before := snapshotOrder(order)
+		previousStatus := order.Status
+		order.Status = "cancelled"
+		if err := s.applyStatusChange(tx, order, previousStatus, refundTo); err != nil {
+			return err
+		}
+		if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+			return errors.New("failed to cancel order")
+		}
+		if err := recordStatusChange(s.orderRepo.WithTx(tx), order, previousStatus, &userUUID, "cancelled by customer"); err != nil {
+			return err
+		}
+		if err := recordEvents(s.outboxRepo.WithTx(tx), orderEvents(before, order)); err != nil {
+			return errors.New("failed to record order events")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.toOrderResponse(order), nil
+	return s.toOrderResponse(order, s.localeFor(userUUID, locale)), nil
 }
 
-func (s *orderService) UpdateStatus(laundryOwnerID, orderID string, status string) (*OrderResponse, error) {
+func (s *orderService) UpdateStatus(laundryOwnerID, orderID string, status string, locale string) (*OrderResponse, error) {
 	orderUUID, err := uuid.Parse(orderID)
 	if err != nil {
 		return nil, errors.New("invalid order ID")
@@ -340,43 +711,558 @@ func (s *orderService) UpdateStatus(laundryOwnerID, orderID string, status strin
 		return nil, errors.New("invalid status")
 	}
 
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		// Re-read under lock so an expiry or cancellation made since is
+		// not overwritten
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(orderUUID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		before := snapshotOrder(order)
+		previousStatus := order.Status
+		order.Status = status
+		if err := s.applyStatusChange(tx, order, previousStatus, "original"); err != nil {
+			return err
+		}
+		if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+			return errors.New("failed to update order status")
+		}
+		if err := recordStatusChange(s.orderRepo.WithTx(tx), order, previousStatus, &laundryOwnerUUID, ""); err != nil {
+			return err
+		}
+		if err := recordEvents(s.outboxRepo.WithTx(tx), orderEvents(before, order)); err != nil {
+			return errors.New("failed to record order events")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toOrderResponse(order, s.localeFor(laundryOwnerUUID, locale)), nil
+}
+
+// applyStatusChange runs the side effects of a status transition inside the
+// transaction that persists it. refundTo chooses where a paid, cancelled
+// order is refunded: "original" or "wallet".
+func (s *orderService) applyStatusChange(tx *gorm.DB, order *models.Order, previousStatus, refundTo string) error {
+	if order.Status == previousStatus {
+		return nil
+	}
+	if !canTransition(previousStatus, order.Status) {
+		return fmt.Errorf("order cannot move from %s to %s", previousStatus, order.Status)
+	}
+
+	// Processing waits until the customer has accepted the measured weight
+	if previousStatus == "picked-up" && order.Status != "cancelled" {
+		if err := requireSettledAdjustment(s.adjustmentRepo.WithTx(tx), order.ID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	switch order.Status {
+	case "completed":
+		if err := requireNoUnresolvedClaim(s.claimRepo.WithTx(tx), order.ID); err != nil {
+			return err
+		}
+		refunded, err := s.claimRepo.WithTx(tx).SumRefundedByOrderID(order.ID)
+		if err != nil {
+			return errors.New("failed to award loyalty points")
+		}
+		if err := awardLoyaltyPoints(s.loyaltyRepo.WithTx(tx), s.loyaltyCfg, order, refunded, now); err != nil {
+			return errors.New("failed to award loyalty points")
+		}
+	case "cancelled":
+		if err := refundLoyaltyPoints(s.loyaltyRepo.WithTx(tx), s.loyaltyCfg, order, now); err != nil {
+			return errors.New("failed to return loyalty points")
+		}
+		if err := releaseSubscriptionQuota(s.subscriptionRepo.WithTx(tx), order); err != nil {
+			return errors.New("failed to return subscription quota")
+		}
+		if err := s.promotionRepo.WithTx(tx).ReleaseRedemption(order.ID); err != nil {
+			return errors.New("failed to release promo code")
+		}
+		if err := s.refundPayment(tx, order, refundTo); err != nil {
+			return err
+		}
+		if err := s.deliveryRepo.WithTx(tx).CancelOpenByOrderID(order.ID); err != nil {
+			return errors.New("failed to cancel deliveries")
+		}
+	}
+
+	if event := notification.EventForStatus(order.Status); event != "" {
+		user, err := s.userRepo.FindByID(order.UserID)
+		if err != nil {
+			return errors.New("user not found")
+		}
+		if err := enqueueOrderNotifications(s.notificationRepo.WithTx(tx), user, order, event); err != nil {
+			return errors.New("failed to queue notifications")
+		}
+	}
+	return nil
+}
+
+// canTransition reports whether orderTransitions allows from to to.
+func canTransition(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *orderService) transition(tx *gorm.DB, order *models.Order, status string, changedBy *uuid.UUID, reason string) error {
+	before := snapshotOrder(order)
+	previousStatus := order.Status
 	order.Status = status
-	if err := s.orderRepo.Update(order); err != nil {
-		return nil, errors.New("failed to update order status")
+	if err := s.applyStatusChange(tx, order, previousStatus, "original"); err != nil {
+		return err
+	}
+	if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+		return errors.New("failed to update order status")
+	}
+	if err := recordStatusChange(s.orderRepo.WithTx(tx), order, previousStatus, changedBy, reason); err != nil {
+		return err
+	}
+	if err := recordEvents(s.outboxRepo.WithTx(tx), orderEvents(before, order)); err != nil {
+		return errors.New("failed to record order events")
+	}
+	return nil
+}
+
+// recordStatusChange appends a status transition to the order's history.
+// changedBy is nil for system changes.
+func recordStatusChange(orderRepo repository.OrderRepository, order *models.Order, previousStatus string, changedBy *uuid.UUID, reason string) error {
+	if order.Status == previousStatus {
+		return nil
+	}
+	if err := orderRepo.CreateStatusHistory(&models.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: previousStatus,
+		ToStatus:   order.Status,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+	}); err != nil {
+		return errors.New("failed to record order history")
+	}
+	return nil
+}
+
+// refundPayment refunds a paid order. Wallet payments, and any payment the
+// customer asked to have returned to their wallet, are credited immediately;
+// cash payments refunded to the original method are left for the laundry to
+// settle and marked refund_pending.
+func (s *orderService) refundPayment(tx *gorm.DB, order *models.Order, refundTo string) error {
+	if order.PaymentStatus != "paid" {
+		return nil
+	}
+
+	if order.PaymentMethod == "wallet" || refundTo == "wallet" {
+		if order.TotalPrice > 0 {
+			if err := refundOrderToWallet(s.walletRepo.WithTx(tx), order, "Refund for cancelled order"); err != nil {
+				return errors.New("failed to refund order")
+			}
+		}
+		order.PaymentStatus = "refunded"
+		return nil
 	}
 
-	return s.toOrderResponse(order), nil
+	order.PaymentStatus = "refund_pending"
+	return nil
 }
 
-func (s *orderService) toOrderResponse(order *models.Order) *OrderResponse {
+func (s *orderService) MarkPaid(laundryOwnerID, orderID string, locale string) (*OrderResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	laundryOwnerUUID, err := uuid.Parse(laundryOwnerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if order.Laundry.OwnerID != laundryOwnerUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		// Re-read under lock so a cancellation made since is not overwritten
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(orderUUID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		if order.PaymentMethod != "cash" {
+			return errors.New("only cash orders can be marked as paid")
+		}
+		if order.PaymentStatus != "unpaid" {
+			return errors.New("order is not awaiting payment")
+		}
+		if order.Status == "cancelled" {
+			return errors.New("order is cancelled")
+		}
+
+		before := snapshotOrder(order)
+		paidAt := time.Now()
+		order.PaymentStatus = "paid"
+		order.PaidAt = &paidAt
+		if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+			return errors.New("failed to update payment status")
+		}
+		if err := recordEvents(s.outboxRepo.WithTx(tx), orderEvents(before, order)); err != nil {
+			return errors.New("failed to record order events")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toOrderResponse(order, s.localeFor(laundryOwnerUUID, locale)), nil
+}
+
+func (s *orderService) GetBoard(laundryOwnerID string, locale string) (*OrderBoardResponse, error) {
+	laundryOwnerUUID, err := uuid.Parse(laundryOwnerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundries, err := s.laundryRepo.FindByOwnerID(laundryOwnerUUID)
+	if err != nil {
+		return nil, errors.New("failed to fetch laundries")
+	}
+
+	laundryIDs := make([]uuid.UUID, 0, len(laundries))
+	board := &OrderBoardResponse{LaundryIDs: make([]string, 0, len(laundries))}
+	for _, laundry := range laundries {
+		laundryIDs = append(laundryIDs, laundry.ID)
+		board.LaundryIDs = append(board.LaundryIDs, laundry.ID.String())
+	}
+
+	orders, err := s.orderRepo.FindOpenByLaundryIDs(laundryIDs)
+	if err != nil {
+		return nil, errors.New("failed to fetch orders")
+	}
+
+	locale = s.localeFor(laundryOwnerUUID, locale)
+	board.Orders = make([]OrderResponse, 0, len(orders))
+	for i := range orders {
+		board.Orders = append(board.Orders, *s.toOrderResponse(&orders[i], locale))
+	}
+	return board, nil
+}
+
+// GetHistory returns the order's status changes to its customer or the
+// laundry's owner.
+func (s *orderService) GetHistory(userID, orderID string) ([]OrderStatusHistoryResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if order.UserID != userUUID && order.Laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	history, err := s.orderRepo.FindStatusHistory(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch order history")
+	}
+
+	items := make([]OrderStatusHistoryResponse, 0, len(history))
+	for _, entry := range history {
+		var changedBy *string
+		if entry.ChangedBy != nil {
+			id := entry.ChangedBy.String()
+			changedBy = &id
+		}
+		items = append(items, OrderStatusHistoryResponse{
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			Reason:     entry.Reason,
+			ChangedBy:  changedBy,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+// Reorder places a new order for the customer with the same services and
+// quantities as one of their previous orders, at today's prices. Services
+// the laundry has since removed or deactivated are left out and reported.
+func (s *orderService) Reorder(userID, orderID string, req ReorderRequest, locale string) (*ReorderResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	previous, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if previous.UserID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	response := &ReorderResponse{
+		Unavailable: []ReorderServiceChange{},
+		Repriced:    []ReorderServiceChange{},
+	}
+	services := make([]OrderServiceRequest, 0, len(previous.OrderServices))
+	for _, line := range previous.OrderServices {
+		change := ReorderServiceChange{
+			ServiceID:     line.ServiceID.String(),
+			ServiceName:   line.ServiceName,
+			Quantity:      line.Quantity,
+			PreviousPrice: line.UnitPrice,
+		}
+
+		service, err := s.serviceRepo.FindByID(line.ServiceID)
+		if err != nil || service.LaundryID != previous.LaundryID {
+			change.Reason = "removed"
+			response.Unavailable = append(response.Unavailable, change)
+			continue
+		}
+		if !service.IsActive {
+			change.Reason = "deactivated"
+			response.Unavailable = append(response.Unavailable, change)
+			continue
+		}
+		if service.Price != line.UnitPrice {
+			change.Reason = "repriced"
+			change.CurrentPrice = &service.Price
+			response.Repriced = append(response.Repriced, change)
+		}
+
+		services = append(services, OrderServiceRequest{
+			ServiceID: service.ID.String(),
+			Quantity:  line.Quantity,
+		})
+	}
+	if len(services) == 0 {
+		return nil, errors.New("none of the services in this order are available anymore")
+	}
+
+	create := CreateOrderRequest{
+		LaundryID:         previous.LaundryID.String(),
+		Services:          services,
+		DeliveryAddress:   req.DeliveryAddress,
+		DeliveryLatitude:  req.DeliveryLatitude,
+		DeliveryLongitude: req.DeliveryLongitude,
+		Notes:             req.Notes,
+		EstimatedPickupAt: req.EstimatedPickupAt,
+		PromoCode:         req.PromoCode,
+		RedeemPoints:      req.RedeemPoints,
+		PaymentMethod:     req.PaymentMethod,
+	}
+	if create.DeliveryAddress == "" {
+		create.DeliveryAddress = previous.DeliveryAddress
+		if create.DeliveryLatitude == nil && create.DeliveryLongitude == nil {
+			create.DeliveryLatitude, create.DeliveryLongitude = previous.DeliveryLatitude, previous.DeliveryLongitude
+		}
+	}
+	if create.Notes == "" {
+		create.Notes = previous.Notes
+	}
+	if create.PaymentMethod == "" {
+		create.PaymentMethod = previous.PaymentMethod
+	}
+
+	order, err := s.Create(userID, create, locale)
+	if err != nil {
+		return nil, err
+	}
+	response.Order = order
+	return response, nil
+}
+
+// ExpireUnconfirmed cancels pending orders whose confirmation deadline has
+// passed and reports how many it cancelled. Each order is cancelled in its
+// own transaction with the same side effects as any other cancellation:
+// points and quota are returned, paid orders are refunded and the customer
+// is notified.
+func (s *orderService) ExpireUnconfirmed() (int, error) {
+	expired := 0
+	for expired < orderExpiryBatchSize {
+		found := false
+		err := s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+			order, err := s.orderRepo.WithTx(tx).ClaimExpiredPending(time.Now())
+			if err != nil || order == nil {
+				return err
+			}
+			found = true
+			return s.transition(tx, order, "cancelled", nil, orderExpiredReason)
+		})
+		if err != nil {
+			return expired, err
+		}
+		if !found {
+			break
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// applyPromotion locks the promotion, checks every rule including global and
+// per-user usage limits, and applies the discount to the order totals. It must
+// run inside the transaction that creates the order so the limits hold under
+// concurrent orders.
+func (s *orderService) applyPromotion(tx *gorm.DB, code string, order *models.Order) (*models.PromotionRedemption, error) {
+	promotionRepo := s.promotionRepo.WithTx(tx)
+
+	promotion, err := promotionRepo.FindByCodeForUpdate(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, invalidOrder("promo code not found")
+	}
+	if err != nil {
+		return nil, errors.New("failed to validate promo code")
+	}
+
+	discount, err := promotionDiscount(promotion, order.LaundryID, order.Subtotal, order.Currency, time.Now())
+	if err != nil {
+		return nil, invalidOrder(err.Error())
+	}
+
+	if promotion.UsageLimit != nil && promotion.UsedCount >= *promotion.UsageLimit {
+		return nil, invalidOrder("promo code usage limit reached")
+	}
+
+	if promotion.PerUserLimit != nil {
+		used, err := promotionRepo.CountRedemptionsByUser(promotion.ID, order.UserID)
+		if err != nil {
+			return nil, errors.New("failed to validate promo code")
+		}
+		if used >= int64(*promotion.PerUserLimit) {
+			return nil, invalidOrder("you have already used this promo code")
+		}
+	}
+
+	if promotion.FirstOrderOnly {
+		count, err := s.orderRepo.WithTx(tx).CountActiveByUserID(order.UserID)
+		if err != nil {
+			return nil, errors.New("failed to validate promo code")
+		}
+		if count > 0 {
+			return nil, invalidOrder("promo code is only valid for your first order")
+		}
+	}
+
+	order.PromoCode = promotion.Code
+	order.DiscountAmount = discount
+	order.TotalPrice = order.Subtotal - discount
+
+	return &models.PromotionRedemption{
+		PromotionID:    promotion.ID,
+		UserID:         order.UserID,
+		OrderID:        order.ID,
+		DiscountAmount: discount,
+	}, nil
+}
+
+// localeFor resolves the display locale, falling back to the user's saved locale.
+func (s *orderService) localeFor(userID uuid.UUID, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	user, _ := s.userRepo.FindByID(userID)
+	return resolveLocale("", user)
+}
+
+func (s *orderService) toOrderResponse(order *models.Order, locale string) *OrderResponse {
+	currency := currencyOrDefault(order.Currency)
+
+	// Orders created before promotions existed have no stored subtotal
+	subtotal := order.Subtotal
+	if subtotal == 0 {
+		subtotal = order.TotalPrice + order.DiscountAmount + order.PointsDiscount
+	}
+
 	serviceDetails := make([]OrderServiceDetail, 0, len(order.OrderServices))
 	for _, os := range order.OrderServices {
 		serviceDetails = append(serviceDetails, OrderServiceDetail{
-			ServiceID:   os.ServiceID.String(),
-			ServiceName: os.ServiceName,
-			Quantity:    os.Quantity,
-			Price:       os.UnitPrice,
-			Unit:        os.Unit,
+			ServiceID:         os.ServiceID.String(),
+			ServiceName:       os.ServiceName,
+			Quantity:          os.Quantity,
+			QuotaQuantity:     os.QuotaQuantity,
+			Price:             os.UnitPrice,
+			PriceFormatted:    os.UnitPrice.Format(currency, locale),
+			Unit:              os.Unit,
+			Subtotal:          os.Subtotal,
+			SubtotalFormatted: os.Subtotal.Format(currency, locale),
 		})
 	}
 
+	var subscriptionID *string
+	if order.SubscriptionID != nil {
+		id := order.SubscriptionID.String()
+		subscriptionID = &id
+	}
+
+	var recurringOrderID *string
+	if order.RecurringOrderID != nil {
+		id := order.RecurringOrderID.String()
+		recurringOrderID = &id
+	}
+
 	laundryName := ""
 	if order.Laundry.Name != "" {
 		laundryName = order.Laundry.Name
 	}
 
 	return &OrderResponse{
-		ID:                order.ID.String(),
-		LaundryID:         order.LaundryID.String(),
-		LaundryName:       laundryName,
-		Services:          serviceDetails,
-		TotalPrice:        order.TotalPrice,
-		Status:            order.Status,
-		CreatedAt:         order.CreatedAt,
-		EstimatedPickup:   order.EstimatedPickupAt,
-		EstimatedDelivery: order.EstimatedDeliveryAt,
-		Address:           order.DeliveryAddress,
-		Notes:             order.Notes,
+		ID:                      order.ID.String(),
+		LaundryID:               order.LaundryID.String(),
+		LaundryName:             laundryName,
+		Services:                serviceDetails,
+		Subtotal:                subtotal,
+		SubtotalFormatted:       subtotal.Format(currency, locale),
+		DiscountAmount:          order.DiscountAmount,
+		DiscountFormatted:       order.DiscountAmount.Format(currency, locale),
+		PromoCode:               order.PromoCode,
+		PointsRedeemed:          order.PointsRedeemed,
+		PointsDiscount:          order.PointsDiscount,
+		PointsDiscountFormatted: order.PointsDiscount.Format(currency, locale),
+		SubscriptionID:          subscriptionID,
+		TotalPrice:              order.TotalPrice,
+		TotalPriceFormatted:     order.TotalPrice.Format(currency, locale),
+		Currency:                currency,
+		PaymentMethod:           order.PaymentMethod,
+		PaymentStatus:           order.PaymentStatus,
+		PaidAt:                  order.PaidAt,
+		Status:                  order.Status,
+		ConfirmBy:               order.ConfirmBy,
+		CreatedAt:               order.CreatedAt,
+		EstimatedPickup:         order.EstimatedPickupAt,
+		EstimatedDelivery:       order.EstimatedDeliveryAt,
+		Address:                 order.DeliveryAddress,
+		DeliveryLatitude:        order.DeliveryLatitude,
+		DeliveryLongitude:       order.DeliveryLongitude,
+		Notes:                   order.Notes,
+		RecurringOrderID:        recurringOrderID,
 	}
 }
-
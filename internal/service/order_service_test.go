@@ -0,0 +1,62 @@
+package service
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"pending", "confirmed", true},
+		{"pending", "cancelled", true},
+		{"confirmed", "picked-up", true},
+		{"picked-up", "washing", true},
+		{"picked-up", "ready", true},
+		{"washing", "ironing", true},
+		{"ready", "delivered", true},
+		{"ready", "cancelled", true},
+		{"delivered", "completed", true},
+
+		{"pending", "ready", false},
+		{"pending", "completed", false},
+		{"confirmed", "pending", false},
+		{"ironing", "washing", false},
+		{"delivered", "cancelled", false},
+		{"delivered", "ready", false},
+		{"pending", "pending", false},
+		{"completed", "cancelled", false},
+		{"cancelled", "pending", false},
+		{"cancelled", "confirmed", false},
+		{"unknown", "confirmed", false},
+		{"pending", "unknown", false},
+	}
+
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestOrderTransitionsAreClosed(t *testing.T) {
+	known := map[string]bool{
+		"pending": true, "confirmed": true, "picked-up": true, "washing": true, "drying": true,
+		"ironing": true, "ready": true, "delivered": true, "completed": true, "cancelled": true,
+	}
+
+	for from, targets := range orderTransitions {
+		if !known[from] {
+			t.Errorf("transitions from unknown status %q", from)
+		}
+		for _, to := range targets {
+			if !known[to] {
+				t.Errorf("transition %q -> unknown status %q", from, to)
+			}
+		}
+	}
+	for _, terminal := range []string{"completed", "cancelled"} {
+		if len(orderTransitions[terminal]) > 0 {
+			t.Errorf("terminal status %q has transitions %v", terminal, orderTransitions[terminal])
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	outboxBatchSize       = 100
+	defaultJobMaxAttempts = 8
+)
+
+// eventConsumers are the job types every domain event is fanned out to.
+// Each consumer gets its own job so it retries independently.
+var eventConsumers = []string{models.JobPublishEvent, models.JobWebhookDispatch}
+
+type OutboxService interface {
+	Relay() (int, error)
+}
+
+type outboxService struct {
+	outboxRepo repository.OutboxRepository
+	jobRepo    repository.JobRepository
+	transactor repository.Transactor
+}
+
+func NewOutboxService(outboxRepo repository.OutboxRepository, jobRepo repository.JobRepository, transactor repository.Transactor) OutboxService {
+	return &outboxService{
+		outboxRepo: outboxRepo,
+		jobRepo:    jobRepo,
+		transactor: transactor,
+	}
+}
+
+// Relay turns one batch of undispatched outbox events into consumer jobs
+// and reports how many events it relayed. Jobs are created in the same
+// transaction that marks the events dispatched, so each event is relayed
+// exactly once.
+func (s *outboxService) Relay() (int, error) {
+	relayed := 0
+	err := s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		outboxRepo := s.outboxRepo.WithTx(tx)
+		jobRepo := s.jobRepo.WithTx(tx)
+
+		pending, err := outboxRepo.ClaimUndispatched(outboxBatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ids := make([]uuid.UUID, 0, len(pending))
+		for _, event := range pending {
+			for _, jobType := range eventConsumers {
+				if err := jobRepo.Create(newJob(jobType, event.Payload, now)); err != nil {
+					return err
+				}
+			}
+			ids = append(ids, event.ID)
+		}
+
+		if err := outboxRepo.MarkDispatched(ids, now); err != nil {
+			return err
+		}
+		relayed = len(ids)
+		return nil
+	})
+	return relayed, err
+}
+
+func newJob(jobType, payload string, runAt time.Time) *models.Job {
+	return &models.Job{
+		Type:        jobType,
+		Payload:     payload,
+		Status:      models.JobStatusPending,
+		MaxAttempts: defaultJobMaxAttempts,
+		RunAt:       runAt,
+	}
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromotionService interface {
+	Create(userID, role string, req CreatePromotionRequest) (*PromotionResponse, error)
+	GetAll(userID, role string) ([]PromotionResponse, error)
+	Deactivate(userID, role, promotionID string) (*PromotionResponse, error)
+}
+
+type promotionService struct {
+	promotionRepo repository.PromotionRepository
+	laundryRepo   repository.LaundryRepository
+}
+
+type CreatePromotionRequest struct {
+	Code           string       `json:"code"`
+	Description    string       `json:"description"`
+	LaundryID      *string      `json:"laundry_id"`
+	DiscountType   string       `json:"discount_type"`
+	PercentOff     float64      `json:"percent_off"`
+	AmountOff      models.Money `json:"amount_off"`
+	MaxDiscount    models.Money `json:"max_discount"`
+	MinOrderValue  models.Money `json:"min_order_value"`
+	Currency       string       `json:"currency"`
+	StartsAt       *time.Time   `json:"starts_at"`
+	EndsAt         *time.Time   `json:"ends_at"`
+	UsageLimit     *int         `json:"usage_limit"`
+	PerUserLimit   *int         `json:"per_user_limit"`
+	FirstOrderOnly bool         `json:"first_order_only"`
+}
+
+type PromotionResponse struct {
+	ID             string       `json:"id"`
+	Code           string       `json:"code"`
+	Description    string       `json:"description"`
+	LaundryID      *string      `json:"laundry_id"`
+	DiscountType   string       `json:"discount_type"`
+	PercentOff     float64      `json:"percent_off,omitempty"`
+	AmountOff      models.Money `json:"amount_off,omitempty"`
+	MaxDiscount    models.Money `json:"max_discount,omitempty"`
+	MinOrderValue  models.Money `json:"min_order_value"`
+	Currency       string       `json:"currency"`
+	StartsAt       *time.Time   `json:"starts_at"`
+	EndsAt         *time.Time   `json:"ends_at"`
+	UsageLimit     *int         `json:"usage_limit"`
+	PerUserLimit   *int         `json:"per_user_limit"`
+	FirstOrderOnly bool         `json:"first_order_only"`
+	UsedCount      int          `json:"used_count"`
+	IsActive       bool         `json:"is_active"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+func NewPromotionService(promotionRepo repository.PromotionRepository, laundryRepo repository.LaundryRepository) PromotionService {
+	return &promotionService{
+		promotionRepo: promotionRepo,
+		laundryRepo:   laundryRepo,
+	}
+}
+
+func (s *promotionService) Create(userID, role string, req CreatePromotionRequest) (*PromotionResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	code := normalizePromoCode(req.Code)
+	if code == "" {
+		return nil, errors.New("code is required")
+	}
+
+	promotion := &models.Promotion{
+		Code:           code,
+		Description:    req.Description,
+		DiscountType:   req.DiscountType,
+		MinOrderValue:  req.MinOrderValue,
+		Currency:       strings.ToUpper(currencyOrDefault(req.Currency)),
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		UsageLimit:     req.UsageLimit,
+		PerUserLimit:   req.PerUserLimit,
+		FirstOrderOnly: req.FirstOrderOnly,
+		IsActive:       true,
+		CreatedBy:      userUUID,
+	}
+
+	switch req.DiscountType {
+	case models.DiscountTypePercentage:
+		if req.PercentOff <= 0 || req.PercentOff > 100 {
+			return nil, errors.New("percent_off must be between 0 and 100")
+		}
+		if req.MaxDiscount < 0 {
+			return nil, errors.New("max_discount cannot be negative")
+		}
+		promotion.PercentOff = req.PercentOff
+		promotion.MaxDiscount = req.MaxDiscount
+	case models.DiscountTypeFixed:
+		if req.AmountOff <= 0 {
+			return nil, errors.New("amount_off must be greater than zero")
+		}
+		promotion.AmountOff = req.AmountOff
+	default:
+		return nil, errors.New("discount_type must be percentage or fixed")
+	}
+
+	if !models.IsSupportedCurrency(promotion.Currency) {
+		return nil, errors.New("unsupported currency")
+	}
+	if req.MinOrderValue < 0 {
+		return nil, errors.New("min_order_value cannot be negative")
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, errors.New("ends_at must be after starts_at")
+	}
+	if req.UsageLimit != nil && *req.UsageLimit < 1 {
+		return nil, errors.New("usage_limit must be at least 1")
+	}
+	if req.PerUserLimit != nil && *req.PerUserLimit < 1 {
+		return nil, errors.New("per_user_limit must be at least 1")
+	}
+
+	// Platform-wide promotions are admin only; owners scope promotions to their laundries
+	if req.LaundryID == nil || *req.LaundryID == "" {
+		if role != "admin" {
+			return nil, errors.New("only admins can create platform-wide promotions")
+		}
+	} else {
+		laundryUUID, err := uuid.Parse(*req.LaundryID)
+		if err != nil {
+			return nil, errors.New("invalid laundry ID")
+		}
+		laundry, err := s.laundryRepo.FindByID(laundryUUID)
+		if err != nil {
+			return nil, errors.New("laundry not found")
+		}
+		if role != "admin" && laundry.OwnerID != userUUID {
+			return nil, errors.New("unauthorized")
+		}
+		promotion.LaundryID = &laundryUUID
+	}
+
+	if existing, _ := s.promotionRepo.FindByCode(code); existing != nil {
+		return nil, errors.New("promo code already exists")
+	}
+
+	if err := s.promotionRepo.Create(promotion); err != nil {
+		return nil, errors.New("failed to create promotion")
+	}
+
+	return toPromotionResponse(promotion), nil
+}
+
+func (s *promotionService) GetAll(userID, role string) ([]PromotionResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	var promotions []models.Promotion
+	if role == "admin" {
+		promotions, err = s.promotionRepo.FindAll(nil, true)
+	} else {
+		laundries, findErr := s.laundryRepo.FindByOwnerID(userUUID)
+		if findErr != nil {
+			return nil, errors.New("failed to fetch laundries")
+		}
+		laundryIDs := make([]uuid.UUID, 0, len(laundries))
+		for _, laundry := range laundries {
+			laundryIDs = append(laundryIDs, laundry.ID)
+		}
+		promotions, err = s.promotionRepo.FindAll(laundryIDs, false)
+	}
+	if err != nil {
+		return nil, errors.New("failed to fetch promotions")
+	}
+
+	responses := make([]PromotionResponse, 0, len(promotions))
+	for i := range promotions {
+		responses = append(responses, *toPromotionResponse(&promotions[i]))
+	}
+	return responses, nil
+}
+
+func (s *promotionService) Deactivate(userID, role, promotionID string) (*PromotionResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	promotionUUID, err := uuid.Parse(promotionID)
+	if err != nil {
+		return nil, errors.New("invalid promotion ID")
+	}
+
+	promotion, err := s.promotionRepo.FindByID(promotionUUID)
+	if err != nil {
+		return nil, errors.New("promotion not found")
+	}
+
+	if role != "admin" {
+		if promotion.LaundryID == nil {
+			return nil, errors.New("unauthorized")
+		}
+		laundry, err := s.laundryRepo.FindByID(*promotion.LaundryID)
+		if err != nil || laundry.OwnerID != userUUID {
+			return nil, errors.New("unauthorized")
+		}
+	}
+
+	promotion.IsActive = false
+	if err := s.promotionRepo.Update(promotion); err != nil {
+		return nil, errors.New("failed to deactivate promotion")
+	}
+
+	return toPromotionResponse(promotion), nil
+}
+
+// promotionDiscount applies the rules that only depend on the promotion and
+// the order being placed. Usage limits are checked by the caller while the
+// promotion row is locked.
+func promotionDiscount(promotion *models.Promotion, laundryID uuid.UUID, subtotal models.Money, currency string, now time.Time) (models.Money, error) {
+	if !promotion.IsActive {
+		return 0, errors.New("promo code is not active")
+	}
+	if promotion.StartsAt != nil && now.Before(*promotion.StartsAt) {
+		return 0, errors.New("promo code is not valid yet")
+	}
+	if promotion.EndsAt != nil && !now.Before(*promotion.EndsAt) {
+		return 0, errors.New("promo code has expired")
+	}
+	if promotion.LaundryID != nil && *promotion.LaundryID != laundryID {
+		return 0, errors.New("promo code is not valid for this laundry")
+	}
+	if !strings.EqualFold(promotion.Currency, currency) {
+		return 0, errors.New("promo code is not valid for this currency")
+	}
+	if subtotal < promotion.MinOrderValue {
+		return 0, errors.New("order does not meet the promo minimum order value")
+	}
+
+	var discount models.Money
+	switch promotion.DiscountType {
+	case models.DiscountTypePercentage:
+		discount = subtotal.Percent(promotion.PercentOff)
+		if promotion.MaxDiscount > 0 && discount > promotion.MaxDiscount {
+			discount = promotion.MaxDiscount
+		}
+	case models.DiscountTypeFixed:
+		discount = promotion.AmountOff
+	}
+
+	if discount > subtotal {
+		discount = subtotal
+	}
+	return discount, nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func toPromotionResponse(promotion *models.Promotion) *PromotionResponse {
+	var laundryID *string
+	if promotion.LaundryID != nil {
+		id := promotion.LaundryID.String()
+		laundryID = &id
+	}
+
+	return &PromotionResponse{
+		ID:             promotion.ID.String(),
+		Code:           promotion.Code,
+		Description:    promotion.Description,
+		LaundryID:      laundryID,
+		DiscountType:   promotion.DiscountType,
+		PercentOff:     promotion.PercentOff,
+		AmountOff:      promotion.AmountOff,
+		MaxDiscount:    promotion.MaxDiscount,
+		MinOrderValue:  promotion.MinOrderValue,
+		Currency:       promotion.Currency,
+		StartsAt:       promotion.StartsAt,
+		EndsAt:         promotion.EndsAt,
+		UsageLimit:     promotion.UsageLimit,
+		PerUserLimit:   promotion.PerUserLimit,
+		FirstOrderOnly: promotion.FirstOrderOnly,
+		UsedCount:      promotion.UsedCount,
+		IsActive:       promotion.IsActive,
+		CreatedAt:      promotion.CreatedAt,
+	}
+}
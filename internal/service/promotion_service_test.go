@@ -0,0 +1,76 @@
+package service
+
+import (
+	"laundry-go/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPromotionDiscount(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+	laundryID := uuid.New()
+	otherLaundryID := uuid.New()
+
+	percentage := func(pct float64, max models.Money) models.Promotion {
+		return models.Promotion{IsActive: true, Currency: "IDR", DiscountType: models.DiscountTypePercentage, PercentOff: pct, MaxDiscount: max}
+	}
+	fixed := func(amount models.Money) models.Promotion {
+		return models.Promotion{IsActive: true, Currency: "IDR", DiscountType: models.DiscountTypeFixed, AmountOff: amount}
+	}
+	with := func(p models.Promotion, change func(*models.Promotion)) models.Promotion {
+		change(&p)
+		return p
+	}
+
+	tests := []struct {
+		name      string
+		promotion models.Promotion
+		subtotal  models.Money
+		currency  string
+		want      models.Money
+		wantErr   string
+	}{
+		{name: "percentage", promotion: percentage(10, 0), subtotal: models.NewMoney(50000), want: models.NewMoney(5000)},
+		{name: "percentage rounds half away from zero", promotion: percentage(12.5, 0), subtotal: 333, want: 42},
+		{name: "percentage capped", promotion: percentage(50, models.NewMoney(10000)), subtotal: models.NewMoney(50000), want: models.NewMoney(10000)},
+		{name: "fixed", promotion: fixed(models.NewMoney(5000)), subtotal: models.NewMoney(50000), want: models.NewMoney(5000)},
+		{name: "fixed capped at subtotal", promotion: fixed(models.NewMoney(5000)), subtotal: models.NewMoney(3000), want: models.NewMoney(3000)},
+		{name: "currency is case-insensitive", promotion: fixed(models.NewMoney(5000)), subtotal: models.NewMoney(50000), currency: "idr", want: models.NewMoney(5000)},
+		{name: "for this laundry", promotion: with(fixed(100), func(p *models.Promotion) { p.LaundryID = &laundryID }), subtotal: 1000, want: 100},
+		{name: "started and not ended", promotion: with(fixed(100), func(p *models.Promotion) { p.StartsAt, p.EndsAt = &before, &after }), subtotal: 1000, want: 100},
+		{name: "minimum met exactly", promotion: with(fixed(100), func(p *models.Promotion) { p.MinOrderValue = 1000 }), subtotal: 1000, want: 100},
+
+		{name: "inactive", promotion: with(fixed(100), func(p *models.Promotion) { p.IsActive = false }), subtotal: 1000, wantErr: "promo code is not active"},
+		{name: "not started", promotion: with(fixed(100), func(p *models.Promotion) { p.StartsAt = &after }), subtotal: 1000, wantErr: "promo code is not valid yet"},
+		{name: "ends now", promotion: with(fixed(100), func(p *models.Promotion) { p.EndsAt = &now }), subtotal: 1000, wantErr: "promo code has expired"},
+		{name: "other laundry", promotion: with(fixed(100), func(p *models.Promotion) { p.LaundryID = &otherLaundryID }), subtotal: 1000, wantErr: "promo code is not valid for this laundry"},
+		{name: "other currency", promotion: fixed(100), subtotal: 1000, currency: "USD", wantErr: "promo code is not valid for this currency"},
+		{name: "below minimum", promotion: with(fixed(100), func(p *models.Promotion) { p.MinOrderValue = 1001 }), subtotal: 1000, wantErr: "order does not meet the promo minimum order value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency := tt.currency
+			if currency == "" {
+				currency = "IDR"
+			}
+			got, err := promotionDiscount(&tt.promotion, laundryID, tt.subtotal, currency, now)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("discount = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
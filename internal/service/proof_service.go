@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/storage"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxProofFiles is the most photos accepted in one upload.
+const MaxProofFiles = 10
+
+// proofExtensions maps the accepted image types, sniffed from the file
+// content, to the extension used in the storage key.
+var proofExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type ProofService interface {
+	Upload(ctx context.Context, userID, orderID string, req UploadProofRequest) ([]ProofResponse, error)
+	GetByOrder(userID, orderID string) ([]ProofResponse, error)
+	Open(ctx context.Context, userID, orderID, proofID string) (*models.OrderProof, io.ReadCloser, error)
+}
+
+type proofService struct {
+	proofRepo     repository.OrderProofRepository
+	orderRepo     repository.OrderRepository
+	deliveryRepo  repository.DeliveryRepository
+	transactor    repository.Transactor
+	store         storage.BlobStore
+	maxUploadSize int64
+}
+
+// UploadProofRequest carries the files of a multipart upload. A signature
+// upload takes exactly one file.
+type UploadProofRequest struct {
+	Leg   string
+	Kind  string
+	Files [][]byte
+}
+
+type ProofResponse struct {
+	ID           string    `json:"id"`
+	OrderID      string    `json:"order_id"`
+	DeliveryID   string    `json:"delivery_id,omitempty"`
+	Leg          string    `json:"leg"`
+	Kind         string    `json:"kind"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	UploadedBy   string    `json:"uploaded_by"`
+	UploaderName string    `json:"uploader_name"`
+	URL          string    `json:"url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewProofService(proofRepo repository.OrderProofRepository, orderRepo repository.OrderRepository, deliveryRepo repository.DeliveryRepository, transactor repository.Transactor, store storage.BlobStore, maxUploadSize int64) ProofService {
+	return &proofService{
+		proofRepo:     proofRepo,
+		orderRepo:     orderRepo,
+		deliveryRepo:  deliveryRepo,
+		transactor:    transactor,
+		store:         store,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// Upload stores proof for an order leg. The courier assigned to the leg or
+// the laundry's owner may upload. Either every file is saved or none is.
+func (s *proofService) Upload(ctx context.Context, userID, orderID string, req UploadProofRequest) ([]ProofResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	if req.Leg != models.DeliveryLegPickup && req.Leg != models.DeliveryLegDropoff {
+		return nil, errors.New("leg must be pickup or dropoff")
+	}
+
+	switch req.Kind {
+	case models.ProofKindPhoto:
+		if len(req.Files) == 0 {
+			return nil, errors.New("at least one file is required")
+		}
+		if len(req.Files) > MaxProofFiles {
+			return nil, fmt.Errorf("at most %d photos can be uploaded at once", MaxProofFiles)
+		}
+	case models.ProofKindSignature:
+		if len(req.Files) != 1 {
+			return nil, errors.New("a signature upload takes exactly one file")
+		}
+	default:
+		return nil, errors.New("kind must be photo or signature")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	deliveries, err := s.deliveryRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch deliveries")
+	}
+	var delivery *models.Delivery
+	for i := range deliveries {
+		if deliveries[i].Leg == req.Leg {
+			delivery = &deliveries[i]
+		}
+	}
+
+	isCourier := delivery != nil && delivery.CourierID == userUUID && delivery.Status != models.DeliveryStatusCancelled
+	if order.Laundry.OwnerID != userUUID && !isCourier {
+		return nil, errors.New("unauthorized")
+	}
+
+	if req.Kind == models.ProofKindSignature {
+		exists, err := s.proofRepo.HasSignature(order.ID, req.Leg)
+		if err != nil {
+			return nil, errors.New("failed to upload proof")
+		}
+		if exists {
+			return nil, errors.New("signature already uploaded for this leg")
+		}
+	}
+
+	proofs := make([]models.OrderProof, 0, len(req.Files))
+	for _, data := range req.Files {
+		if int64(len(data)) > s.maxUploadSize {
+			return nil, fmt.Errorf("files cannot be larger than %d MB", s.maxUploadSize>>20)
+		}
+		contentType := http.DetectContentType(data)
+		extension, ok := proofExtensions[contentType]
+		if !ok {
+			return nil, errors.New("files must be JPEG, PNG or WebP images")
+		}
+
+		id := uuid.New()
+		proof := models.OrderProof{
+			ID:          id,
+			OrderID:     order.ID,
+			Leg:         req.Leg,
+			Kind:        req.Kind,
+			StorageKey:  fmt.Sprintf("orders/%s/%s/%s%s", order.ID, req.Leg, id, extension),
+			ContentType: contentType,
+			Size:        int64(len(data)),
+			UploadedBy:  userUUID,
+		}
+		if delivery != nil {
+			proof.DeliveryID = &delivery.ID
+		}
+		proofs = append(proofs, proof)
+	}
+
+	stored := make([]string, 0, len(proofs))
+	for i := range proofs {
+		if err := s.store.Put(ctx, proofs[i].StorageKey, req.Files[i], proofs[i].ContentType); err != nil {
+			log.Printf("proof: failed to store %s: %v", proofs[i].StorageKey, err)
+			deleteBlobs(s.store, stored)
+			return nil, errors.New("failed to store file")
+		}
+		stored = append(stored, proofs[i].StorageKey)
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		proofRepo := s.proofRepo.WithTx(tx)
+		for i := range proofs {
+			if err := proofRepo.Create(&proofs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		deleteBlobs(s.store, stored)
+		if req.Kind == models.ProofKindSignature {
+			// A concurrent upload may have won the unique index.
+			if exists, _ := s.proofRepo.HasSignature(order.ID, req.Leg); exists {
+				return nil, errors.New("signature already uploaded for this leg")
+			}
+		}
+		return nil, errors.New("failed to upload proof")
+	}
+
+	return s.findResponses(proofs)
+}
+
+// GetByOrder lists an order's proofs for its customer or the laundry's
+// owner.
+func (s *proofService) GetByOrder(userID, orderID string) ([]ProofResponse, error) {
+	order, err := s.viewableOrder(userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	proofs, err := s.proofRepo.FindByOrderID(order.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch proofs")
+	}
+
+	items := make([]ProofResponse, 0, len(proofs))
+	for i := range proofs {
+		items = append(items, toProofResponse(&proofs[i]))
+	}
+	return items, nil
+}
+
+// Open returns a proof's file to the order's customer or the laundry's
+// owner. The caller must close the reader.
+func (s *proofService) Open(ctx context.Context, userID, orderID, proofID string) (*models.OrderProof, io.ReadCloser, error) {
+	order, err := s.viewableOrder(userID, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proofUUID, err := uuid.Parse(proofID)
+	if err != nil {
+		return nil, nil, errors.New("invalid proof ID")
+	}
+
+	proof, err := s.proofRepo.FindByID(proofUUID)
+	if err != nil || proof.OrderID != order.ID {
+		return nil, nil, errors.New("proof not found")
+	}
+
+	reader, err := s.store.Get(ctx, proof.StorageKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("proof: failed to read %s: %v", proof.StorageKey, err)
+		}
+		return nil, nil, errors.New("proof file not found")
+	}
+	return proof, reader, nil
+}
+
+func (s *proofService) viewableOrder(userID, orderID string) (*models.Order, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if order.UserID != userUUID && order.Laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+	return order, nil
+}
+
+func (s *proofService) findResponses(proofs []models.OrderProof) ([]ProofResponse, error) {
+	items := make([]ProofResponse, 0, len(proofs))
+	for i := range proofs {
+		proof, err := s.proofRepo.FindByID(proofs[i].ID)
+		if err != nil {
+			return nil, errors.New("proof not found")
+		}
+		items = append(items, toProofResponse(proof))
+	}
+	return items, nil
+}
+
+func toProofResponse(proof *models.OrderProof) ProofResponse {
+	response := ProofResponse{
+		ID:           proof.ID.String(),
+		OrderID:      proof.OrderID.String(),
+		Leg:          proof.Leg,
+		Kind:         proof.Kind,
+		ContentType:  proof.ContentType,
+		Size:         proof.Size,
+		UploadedBy:   proof.UploadedBy.String(),
+		UploaderName: proof.Uploader.Name,
+		URL:          fmt.Sprintf("/api/v1/orders/%s/proofs/%s/file", proof.OrderID, proof.ID),
+		CreatedAt:    proof.CreatedAt,
+	}
+	if proof.DeliveryID != nil {
+		response.DeliveryID = proof.DeliveryID.String()
+	}
+	return response
+}
@@ -0,0 +1,609 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"laundry-go/internal/config"
+	"laundry-go/internal/models"
+	"laundry-go/internal/notification"
+	"laundry-go/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// recurringOrderBatchSize caps how many templates one scheduler run
+	// handles.
+	recurringOrderBatchSize = 100
+	recurringDateLayout     = "2006-01-02"
+	recurringTimeLayout     = "15:04"
+)
+
+var recurringWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+type RecurringOrderService interface {
+	Create(userID string, req CreateRecurringOrderRequest) (*RecurringOrderResponse, error)
+	GetMine(userID, status string, page, limit int) (*RecurringOrderListResponse, error)
+	GetByID(userID, recurringOrderID string) (*RecurringOrderResponse, error)
+	Pause(userID, recurringOrderID string) (*RecurringOrderResponse, error)
+	Resume(userID, recurringOrderID string) (*RecurringOrderResponse, error)
+	SkipNext(userID, recurringOrderID string) (*RecurringOrderResponse, error)
+	Cancel(userID, recurringOrderID string) (*RecurringOrderResponse, error)
+	PlaceDue() (int, error)
+}
+
+type recurringOrderService struct {
+	recurringRepo    repository.RecurringOrderRepository
+	orderRepo        repository.OrderRepository
+	laundryRepo      repository.LaundryRepository
+	serviceRepo      repository.ServiceRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	orderService     OrderService
+	transactor       repository.Transactor
+	orderCfg         config.OrderConfig
+}
+
+type CreateRecurringOrderRequest struct {
+	LaundryID         string                `json:"laundry_id"`
+	Services          []OrderServiceRequest `json:"services"`
+	Frequency         string                `json:"frequency"`   // weekly or biweekly
+	Weekday           string                `json:"weekday"`     // monday ... sunday
+	PickupTime        string                `json:"pickup_time"` // HH:MM
+	EndDate           string                `json:"end_date"`    // optional, YYYY-MM-DD
+	DeliveryAddress   string                `json:"delivery_address"`
+	DeliveryLatitude  *float64              `json:"delivery_latitude"`
+	DeliveryLongitude *float64              `json:"delivery_longitude"`
+	Notes             string                `json:"notes"`
+	PaymentMethod     string                `json:"payment_method"` // cash (default) or wallet
+}
+
+type RecurringOrderListResponse struct {
+	RecurringOrders []RecurringOrderResponse `json:"recurring_orders"`
+	Pagination      Pagination               `json:"pagination"`
+}
+
+type RecurringOrderResponse struct {
+	ID                string                          `json:"id"`
+	LaundryID         string                          `json:"laundry_id"`
+	LaundryName       string                          `json:"laundry_name"`
+	Status            string                          `json:"status"`
+	Frequency         string                          `json:"frequency"`
+	Weekday           string                          `json:"weekday"`
+	PickupTime        string                          `json:"pickup_time"`
+	Timezone          string                          `json:"timezone"`
+	EndDate           string                          `json:"end_date,omitempty"`
+	Services          []RecurringOrderServiceResponse `json:"services"`
+	DeliveryAddress   string                          `json:"delivery_address"`
+	DeliveryLatitude  *float64                        `json:"delivery_latitude,omitempty"`
+	DeliveryLongitude *float64                        `json:"delivery_longitude,omitempty"`
+	Notes             string                          `json:"notes"`
+	PaymentMethod     string                          `json:"payment_method"`
+	NextPickupAt      *time.Time                      `json:"next_pickup_at,omitempty"`
+	LastOrderID       string                          `json:"last_order_id,omitempty"`
+	LastFailure       string                          `json:"last_failure,omitempty"`
+	LastFailedAt      *time.Time                      `json:"last_failed_at,omitempty"`
+	CreatedAt         time.Time                       `json:"created_at"`
+}
+
+type RecurringOrderServiceResponse struct {
+	ServiceID   string       `json:"service_id"`
+	ServiceName string       `json:"service_name"`
+	Quantity    float64      `json:"quantity"`
+	Unit        string       `json:"unit"`
+	Price       models.Money `json:"price"`     // current price
+	Available   bool         `json:"available"` // false once the laundry deactivates the service
+}
+
+func NewRecurringOrderService(
+	recurringRepo repository.RecurringOrderRepository,
+	orderRepo repository.OrderRepository,
+	laundryRepo repository.LaundryRepository,
+	serviceRepo repository.ServiceRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	orderService OrderService,
+	transactor repository.Transactor,
+	orderCfg config.OrderConfig,
+) RecurringOrderService {
+	return &recurringOrderService{
+		recurringRepo:    recurringRepo,
+		orderRepo:        orderRepo,
+		laundryRepo:      laundryRepo,
+		serviceRepo:      serviceRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		orderService:     orderService,
+		transactor:       transactor,
+		orderCfg:         orderCfg,
+	}
+}
+
+// Create saves a recurring order. The first pickup is the next time the
+// weekday and pickup time come round.
+func (s *recurringOrderService) Create(userID string, req CreateRecurringOrderRequest) (*RecurringOrderResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(req.LaundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	if len(req.Services) == 0 {
+		return nil, errors.New("at least one service is required")
+	}
+	if req.DeliveryAddress == "" {
+		return nil, errors.New("delivery address is required")
+	}
+	if (req.DeliveryLatitude == nil) != (req.DeliveryLongitude == nil) {
+		return nil, errors.New("delivery_latitude and delivery_longitude must be given together")
+	}
+
+	paymentMethod := req.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = "cash"
+	}
+	if paymentMethod != "cash" && paymentMethod != "wallet" {
+		return nil, errors.New("payment_method must be cash or wallet")
+	}
+
+	if req.Frequency != models.RecurringFrequencyWeekly && req.Frequency != models.RecurringFrequencyBiweekly {
+		return nil, errors.New("frequency must be weekly or biweekly")
+	}
+	weekday, ok := recurringWeekdays[strings.ToLower(req.Weekday)]
+	if !ok {
+		return nil, errors.New("weekday must be a day name such as monday")
+	}
+	pickupTime, err := time.Parse(recurringTimeLayout, req.PickupTime)
+	if err != nil {
+		return nil, errors.New("pickup_time must be in HH:MM format")
+	}
+
+	recurring := &models.RecurringOrder{
+		UserID:            userUUID,
+		LaundryID:         laundryUUID,
+		Status:            models.RecurringOrderActive,
+		Frequency:         req.Frequency,
+		Weekday:           int(weekday),
+		PickupTime:        pickupTime.Format(recurringTimeLayout),
+		DeliveryAddress:   req.DeliveryAddress,
+		DeliveryLatitude:  req.DeliveryLatitude,
+		DeliveryLongitude: req.DeliveryLongitude,
+		Notes:             req.Notes,
+		PaymentMethod:     paymentMethod,
+	}
+
+	if req.EndDate != "" {
+		endDate, err := time.Parse(recurringDateLayout, req.EndDate)
+		if err != nil {
+			return nil, errors.New("end_date must be in YYYY-MM-DD format")
+		}
+		recurring.EndDate = &endDate
+	}
+
+	if _, err := s.laundryRepo.FindByID(laundryUUID); err != nil {
+		return nil, errors.New("laundry not found")
+	}
+
+	for _, svcReq := range req.Services {
+		serviceUUID, err := uuid.Parse(svcReq.ServiceID)
+		if err != nil {
+			return nil, errors.New("invalid service ID")
+		}
+		service, err := s.serviceRepo.FindByID(serviceUUID)
+		if err != nil {
+			return nil, errors.New("service not found")
+		}
+		if !service.IsActive {
+			return nil, errors.New("service is not active")
+		}
+		if service.LaundryID != laundryUUID {
+			return nil, errors.New("service does not belong to this laundry")
+		}
+		if svcReq.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than zero")
+		}
+		recurring.Lines = append(recurring.Lines, models.RecurringOrderLine{
+			ServiceID: service.ID,
+			Quantity:  svcReq.Quantity,
+		})
+	}
+
+	s.scheduleFrom(recurring, firstPickup(time.Now(), weekday, pickupTime, s.orderCfg.Timezone))
+	if recurring.Status == models.RecurringOrderEnded {
+		return nil, errors.New("end_date is before the first pickup")
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		return s.recurringRepo.WithTx(tx).Create(recurring)
+	})
+	if err != nil {
+		return nil, errors.New("failed to create recurring order")
+	}
+
+	return s.findResponse(recurring.ID)
+}
+
+// GetMine lists the customer's recurring orders.
+func (s *recurringOrderService) GetMine(userID, status string, page, limit int) (*RecurringOrderListResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	recurring, total, err := s.recurringRepo.FindByUserID(userUUID, status, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch recurring orders")
+	}
+
+	items := make([]RecurringOrderResponse, 0, len(recurring))
+	for i := range recurring {
+		items = append(items, *s.toResponse(&recurring[i]))
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &RecurringOrderListResponse{
+		RecurringOrders: items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *recurringOrderService) GetByID(userID, recurringOrderID string) (*RecurringOrderResponse, error) {
+	recurring, err := s.ownRecurringOrder(userID, recurringOrderID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(recurring), nil
+}
+
+// Pause stops placing orders until the recurring order is resumed.
+func (s *recurringOrderService) Pause(userID, recurringOrderID string) (*RecurringOrderResponse, error) {
+	return s.update(userID, recurringOrderID, func(recurring *models.RecurringOrder) error {
+		if recurring.Status != models.RecurringOrderActive {
+			return errors.New("only active recurring orders can be paused")
+		}
+		recurring.Status = models.RecurringOrderPaused
+		return nil
+	})
+}
+
+// Resume restarts a paused recurring order from its next pickup that has
+// not passed yet.
+func (s *recurringOrderService) Resume(userID, recurringOrderID string) (*RecurringOrderResponse, error) {
+	return s.update(userID, recurringOrderID, func(recurring *models.RecurringOrder) error {
+		if recurring.Status != models.RecurringOrderPaused {
+			return errors.New("only paused recurring orders can be resumed")
+		}
+		recurring.Status = models.RecurringOrderActive
+		s.scheduleFrom(recurring, s.nextPickupAfter(recurring, *recurring.NextPickupAt, time.Now()))
+		if recurring.Status == models.RecurringOrderEnded {
+			return errors.New("no pickups are left before the end date")
+		}
+		return nil
+	})
+}
+
+// SkipNext moves the next pickup that has not been ordered yet one period
+// later. Orders already placed are not touched; cancel those directly.
+func (s *recurringOrderService) SkipNext(userID, recurringOrderID string) (*RecurringOrderResponse, error) {
+	return s.update(userID, recurringOrderID, func(recurring *models.RecurringOrder) error {
+		if recurring.Status != models.RecurringOrderActive && recurring.Status != models.RecurringOrderPaused {
+			return errors.New("only active or paused recurring orders can skip a pickup")
+		}
+		s.scheduleFrom(recurring, s.advance(recurring, *recurring.NextPickupAt))
+		return nil
+	})
+}
+
+// Cancel stops the recurring order for good. Orders it already placed are
+// not cancelled.
+func (s *recurringOrderService) Cancel(userID, recurringOrderID string) (*RecurringOrderResponse, error) {
+	return s.update(userID, recurringOrderID, func(recurring *models.RecurringOrder) error {
+		if recurring.Status == models.RecurringOrderCancelled || recurring.Status == models.RecurringOrderEnded {
+			return errors.New("recurring order has already stopped")
+		}
+		recurring.Status = models.RecurringOrderCancelled
+		recurring.NextPickupAt = nil
+		return nil
+	})
+}
+
+// PlaceDue places the orders of active templates whose next pickup falls
+// within the lead time and reports how many templates it handled. Each
+// order goes through the same path as one the customer placed. When an
+// order is refused, the pickup is skipped and the customer is notified.
+// Other errors leave the template due so it is retried on the next run;
+// they do not hold up the templates behind it, and the first is returned.
+func (s *recurringOrderService) PlaceDue() (int, error) {
+	handled := 0
+	var failed []uuid.UUID
+	var firstErr error
+	for handled+len(failed) < recurringOrderBatchSize {
+		var recurring *models.RecurringOrder
+		err := s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+			var err error
+			recurring, err = s.recurringRepo.WithTx(tx).ClaimDue(time.Now().Add(s.orderCfg.RecurringLeadTime), failed)
+			if err != nil || recurring == nil {
+				return err
+			}
+			return s.place(tx, recurring)
+		})
+		if recurring == nil {
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+		if err != nil {
+			failed = append(failed, recurring.ID)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		handled++
+	}
+	return handled, firstErr
+}
+
+// place orders the template's next pickup and schedules the one after. The
+// order is written in tx, so it commits with the template's new schedule;
+// the check before it and the unique index on (recurring_order_id,
+// estimated_pickup_at) keep the same pickup from being ordered twice.
+func (s *recurringOrderService) place(tx *gorm.DB, recurring *models.RecurringOrder) error {
+	now := time.Now()
+	pickupAt := recurring.NextPickupAt.UTC()
+	next := s.advance(recurring, pickupAt)
+
+	var failure string
+	if !pickupAt.After(now) {
+		// The scheduler was not running in time; catch up in one step
+		failure = "the pickup time passed before the order could be placed"
+		next = s.nextPickupAfter(recurring, pickupAt, now)
+	} else {
+		exists, err := s.orderRepo.WithTx(tx).ExistsForRecurrence(recurring.ID, pickupAt)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// A savepoint, so a refused order leaves nothing behind while
+			// the skip is still recorded
+			var order *models.Order
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				var err error
+				order, err = s.placeOrder(tx, recurring, pickupAt)
+				return err
+			})
+			var invalid *orderValidationError
+			switch {
+			case errors.As(err, &invalid):
+				failure = invalid.message
+			case errors.Is(err, errInsufficientWalletBalance):
+				failure = "your wallet balance is too low to pay for the order"
+			case err != nil:
+				return err
+			default:
+				recurring.LastOrderID = &order.ID
+			}
+		}
+	}
+
+	if failure != "" {
+		recurring.LastFailure = failure
+		recurring.LastFailedAt = &now
+
+		user, err := s.userRepo.FindByID(recurring.UserID)
+		if err != nil {
+			return err
+		}
+		if err := enqueueNotifications(s.notificationRepo.WithTx(tx), user, nil, notification.EventRecurringOrderFailed, notification.RecurringOrderData{
+			CustomerName: user.Name,
+			LaundryName:  recurring.Laundry.Name,
+			PickupAt:     pickupAt.In(s.orderCfg.Timezone).Format("02/01/2006 15:04"),
+			Reason:       failure,
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.scheduleFrom(recurring, next)
+	return s.recurringRepo.WithTx(tx).Update(recurring)
+}
+
+// placeOrder creates the order for one pickup of the template inside tx.
+func (s *recurringOrderService) placeOrder(tx *gorm.DB, recurring *models.RecurringOrder, pickupAt time.Time) (*models.Order, error) {
+	if !recurring.Laundry.IsOpen {
+		return nil, invalidOrder("the laundry is closed")
+	}
+
+	services := make([]OrderServiceRequest, 0, len(recurring.Lines))
+	for _, line := range recurring.Lines {
+		if !line.Service.IsActive {
+			return nil, invalidOrder(fmt.Sprintf("%s is no longer offered", line.Service.Name))
+		}
+		services = append(services, OrderServiceRequest{
+			ServiceID: line.ServiceID.String(),
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return s.orderService.create(tx, recurring.UserID.String(), CreateOrderRequest{
+		LaundryID:         recurring.LaundryID.String(),
+		Services:          services,
+		DeliveryAddress:   recurring.DeliveryAddress,
+		DeliveryLatitude:  recurring.DeliveryLatitude,
+		DeliveryLongitude: recurring.DeliveryLongitude,
+		Notes:             recurring.Notes,
+		EstimatedPickupAt: &pickupAt,
+		PaymentMethod:     recurring.PaymentMethod,
+		recurringOrderID:  &recurring.ID,
+	})
+}
+
+// update locks the customer's recurring order and saves it after fn.
+func (s *recurringOrderService) update(userID, recurringOrderID string, fn func(recurring *models.RecurringOrder) error) (*RecurringOrderResponse, error) {
+	owned, err := s.ownRecurringOrder(userID, recurringOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		recurringRepo := s.recurringRepo.WithTx(tx)
+		recurring, err := recurringRepo.FindByIDForUpdate(owned.ID)
+		if err != nil {
+			return errors.New("recurring order not found")
+		}
+		if err := fn(recurring); err != nil {
+			return err
+		}
+		if err := recurringRepo.Update(recurring); err != nil {
+			return errors.New("failed to update recurring order")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findResponse(owned.ID)
+}
+
+func (s *recurringOrderService) ownRecurringOrder(userID, recurringOrderID string) (*models.RecurringOrder, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	recurringUUID, err := uuid.Parse(recurringOrderID)
+	if err != nil {
+		return nil, errors.New("invalid recurring order ID")
+	}
+
+	recurring, err := s.recurringRepo.FindByID(recurringUUID)
+	if err != nil || recurring.UserID != userUUID {
+		return nil, errors.New("recurring order not found")
+	}
+	return recurring, nil
+}
+
+func (s *recurringOrderService) findResponse(id uuid.UUID) (*RecurringOrderResponse, error) {
+	recurring, err := s.recurringRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("recurring order not found")
+	}
+	return s.toResponse(recurring), nil
+}
+
+// scheduleFrom sets the next pickup, or ends the template when the pickup
+// falls after its end date. Pickups are stored in UTC.
+func (s *recurringOrderService) scheduleFrom(recurring *models.RecurringOrder, pickupAt time.Time) {
+	if recurring.EndDate != nil && pickupAt.In(s.orderCfg.Timezone).Format(recurringDateLayout) > recurring.EndDate.Format(recurringDateLayout) {
+		recurring.Status = models.RecurringOrderEnded
+		recurring.NextPickupAt = nil
+		return
+	}
+	next := pickupAt.UTC()
+	recurring.NextPickupAt = &next
+}
+
+// advance returns the pickup one period after pickupAt, at the same local
+// time even across daylight saving changes.
+func (s *recurringOrderService) advance(recurring *models.RecurringOrder, pickupAt time.Time) time.Time {
+	weeks := 1
+	if recurring.Frequency == models.RecurringFrequencyBiweekly {
+		weeks = 2
+	}
+	local := pickupAt.In(s.orderCfg.Timezone)
+	return time.Date(local.Year(), local.Month(), local.Day()+7*weeks, local.Hour(), local.Minute(), 0, 0, s.orderCfg.Timezone)
+}
+
+// nextPickupAfter advances pickupAt period by period until it is after
+// now, keeping the template's rhythm.
+func (s *recurringOrderService) nextPickupAfter(recurring *models.RecurringOrder, pickupAt, now time.Time) time.Time {
+	for !pickupAt.After(now) {
+		pickupAt = s.advance(recurring, pickupAt)
+	}
+	return pickupAt
+}
+
+// firstPickup is the first time after now that falls on weekday at the
+// clock time of pickupTime in loc.
+func firstPickup(now time.Time, weekday time.Weekday, pickupTime time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	days := (int(weekday) - int(local.Weekday()) + 7) % 7
+	pickupAt := time.Date(local.Year(), local.Month(), local.Day()+days, pickupTime.Hour(), pickupTime.Minute(), 0, 0, loc)
+	if !pickupAt.After(now) {
+		pickupAt = time.Date(local.Year(), local.Month(), local.Day()+days+7, pickupTime.Hour(), pickupTime.Minute(), 0, 0, loc)
+	}
+	return pickupAt
+}
+
+func (s *recurringOrderService) toResponse(recurring *models.RecurringOrder) *RecurringOrderResponse {
+	response := &RecurringOrderResponse{
+		ID:                recurring.ID.String(),
+		LaundryID:         recurring.LaundryID.String(),
+		LaundryName:       recurring.Laundry.Name,
+		Status:            recurring.Status,
+		Frequency:         recurring.Frequency,
+		Weekday:           strings.ToLower(time.Weekday(recurring.Weekday).String()),
+		PickupTime:        recurring.PickupTime,
+		Timezone:          s.orderCfg.Timezone.String(),
+		Services:          make([]RecurringOrderServiceResponse, 0, len(recurring.Lines)),
+		DeliveryAddress:   recurring.DeliveryAddress,
+		DeliveryLatitude:  recurring.DeliveryLatitude,
+		DeliveryLongitude: recurring.DeliveryLongitude,
+		Notes:             recurring.Notes,
+		PaymentMethod:     recurring.PaymentMethod,
+		NextPickupAt:      recurring.NextPickupAt,
+		LastFailure:       recurring.LastFailure,
+		LastFailedAt:      recurring.LastFailedAt,
+		CreatedAt:         recurring.CreatedAt,
+	}
+	if recurring.EndDate != nil {
+		response.EndDate = recurring.EndDate.Format(recurringDateLayout)
+	}
+	if recurring.LastOrderID != nil {
+		response.LastOrderID = recurring.LastOrderID.String()
+	}
+	for _, line := range recurring.Lines {
+		response.Services = append(response.Services, RecurringOrderServiceResponse{
+			ServiceID:   line.ServiceID.String(),
+			ServiceName: line.Service.Name,
+			Quantity:    line.Quantity,
+			Unit:        line.Service.Unit,
+			Price:       line.Service.Price,
+			Available:   line.Service.IsActive,
+		})
+	}
+	return response
+}
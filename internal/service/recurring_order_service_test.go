@@ -0,0 +1,120 @@
+package service
+
+import (
+	"laundry-go/internal/config"
+	"laundry-go/internal/models"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestRecurringAdvance(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &recurringOrderService{orderCfg: config.OrderConfig{Timezone: amsterdam}}
+
+	tests := []struct {
+		name      string
+		frequency string
+		pickupAt  time.Time
+		want      time.Time
+	}{
+		{
+			name:      "weekly",
+			frequency: models.RecurringFrequencyWeekly,
+			pickupAt:  time.Date(2024, 3, 7, 9, 0, 0, 0, amsterdam),
+			want:      time.Date(2024, 3, 14, 9, 0, 0, 0, amsterdam),
+		},
+		{
+			name:      "biweekly",
+			frequency: models.RecurringFrequencyBiweekly,
+			pickupAt:  time.Date(2024, 3, 7, 9, 0, 0, 0, amsterdam),
+			want:      time.Date(2024, 3, 21, 9, 0, 0, 0, amsterdam),
+		},
+		{
+			// Clocks go forward on 31 March; the pickup stays at 09:00 local
+			name:      "into summer time",
+			frequency: models.RecurringFrequencyWeekly,
+			pickupAt:  time.Date(2024, 3, 28, 8, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 4, 4, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			// Clocks go back on 27 October
+			name:      "out of summer time",
+			frequency: models.RecurringFrequencyBiweekly,
+			pickupAt:  time.Date(2024, 10, 17, 7, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 10, 31, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "across a month end",
+			frequency: models.RecurringFrequencyWeekly,
+			pickupAt:  time.Date(2024, 2, 26, 18, 30, 0, 0, amsterdam),
+			want:      time.Date(2024, 3, 4, 18, 30, 0, 0, amsterdam),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurring := &models.RecurringOrder{Frequency: tt.frequency}
+			if got := s.advance(recurring, tt.pickupAt); !got.Equal(tt.want) {
+				t.Errorf("advance(%s) = %s, want %s", tt.pickupAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurringNextPickupAfter(t *testing.T) {
+	s := &recurringOrderService{orderCfg: config.OrderConfig{Timezone: time.UTC}}
+	recurring := &models.RecurringOrder{Frequency: models.RecurringFrequencyBiweekly}
+	pickupAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{now: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), want: pickupAt},
+		{now: pickupAt, want: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{now: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2024, 2, 12, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := s.nextPickupAfter(recurring, pickupAt, tt.now); !got.Equal(tt.want) {
+			t.Errorf("nextPickupAfter(now=%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestFirstPickup(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wednesday 27 March 2024, 10:00 local
+	now := time.Date(2024, 3, 27, 10, 0, 0, 0, amsterdam)
+	clock := func(hour, minute int) time.Time {
+		return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		weekday    time.Weekday
+		pickupTime time.Time
+		want       time.Time
+	}{
+		{name: "later this week", weekday: time.Thursday, pickupTime: clock(9, 0), want: time.Date(2024, 3, 28, 9, 0, 0, 0, amsterdam)},
+		{name: "later today", weekday: time.Wednesday, pickupTime: clock(11, 0), want: time.Date(2024, 3, 27, 11, 0, 0, 0, amsterdam)},
+		{name: "earlier today", weekday: time.Wednesday, pickupTime: clock(9, 0), want: time.Date(2024, 4, 3, 9, 0, 0, 0, amsterdam)},
+		{name: "right now", weekday: time.Wednesday, pickupTime: clock(10, 0), want: time.Date(2024, 4, 3, 10, 0, 0, 0, amsterdam)},
+		{name: "after the clock change", weekday: time.Monday, pickupTime: clock(8, 15), want: time.Date(2024, 4, 1, 8, 15, 0, 0, amsterdam)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstPickup(now, tt.weekday, tt.pickupTime, amsterdam); !got.Equal(tt.want) {
+				t.Errorf("firstPickup = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
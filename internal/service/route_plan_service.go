@@ -0,0 +1,555 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/config"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/routing"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRouteCapacity = 10
+	maxRouteCapacity     = 50
+	maxRouteCouriers     = 20
+	defaultRouteSlack    = 60 * time.Minute
+	maxRouteWindow       = 24 * time.Hour
+	// routeServiceTime is the time a courier spends at each stop.
+	routeServiceTime = 5 * time.Minute
+)
+
+// Notes on stops that could not be planned.
+const (
+	routeNoteNoCoordinates = "order has no delivery coordinates"
+	routeNoteUnreachable   = "no courier can reach it within its window or capacity"
+)
+
+type RoutePlanService interface {
+	Create(ownerID, laundryID string, req CreateRoutePlanRequest) (*RoutePlanResponse, error)
+	GetByLaundry(ownerID, laundryID string, page, limit int) (*RoutePlanListResponse, error)
+	GetByID(userID, planID string) (*RoutePlanResponse, error)
+	GetMine(courierID string) ([]RouteRunResponse, error)
+}
+
+type routePlanService struct {
+	routePlanRepo repository.RoutePlanRepository
+	orderRepo     repository.OrderRepository
+	deliveryRepo  repository.DeliveryRepository
+	laundryRepo   repository.LaundryRepository
+	userRepo      repository.UserRepository
+	outboxRepo    repository.OutboxRepository
+	transactor    repository.Transactor
+	orderCfg      config.OrderConfig
+}
+
+type CreateRoutePlanRequest struct {
+	From          *time.Time `json:"from"` // defaults to now
+	To            *time.Time `json:"to"`   // defaults to 12 hours after from
+	CourierIDs    []string   `json:"courier_ids"`
+	CourierEmails []string   `json:"courier_emails"` // alternative to courier_ids
+	Capacity      int        `json:"capacity"`       // max stops per courier
+	SlackMinutes  *int       `json:"slack_minutes"`  // tolerance around estimated pickup/delivery times
+}
+
+type RoutePlanListResponse struct {
+	Plans      []RoutePlanSummary `json:"plans"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+type RoutePlanSummary struct {
+	ID          string    `json:"id"`
+	LaundryID   string    `json:"laundry_id"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	Capacity    int       `json:"capacity"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RoutePlanResponse struct {
+	RoutePlanSummary
+	Runs       []RouteRunResponse  `json:"runs"`
+	Unassigned []RouteStopResponse `json:"unassigned"`
+}
+
+// RouteRunResponse is one courier's run. DistanceKm excludes the return to
+// the laundry.
+type RouteRunResponse struct {
+	PlanID      string              `json:"plan_id"`
+	LaundryID   string              `json:"laundry_id"`
+	LaundryName string              `json:"laundry_name,omitempty"`
+	CourierID   string              `json:"courier_id"`
+	CourierName string              `json:"courier_name"`
+	DistanceKm  float64             `json:"distance_km"`
+	Stops       []RouteStopResponse `json:"stops"`
+}
+
+type RouteStopResponse struct {
+	Sequence       int        `json:"sequence,omitempty"`
+	OrderID        string     `json:"order_id"`
+	DeliveryID     string     `json:"delivery_id,omitempty"`
+	Leg            string     `json:"leg"`
+	CustomerName   string     `json:"customer_name"`
+	CustomerPhone  string     `json:"customer_phone"`
+	Address        string     `json:"address"`
+	Latitude       *float64   `json:"latitude,omitempty"`
+	Longitude      *float64   `json:"longitude,omitempty"`
+	WindowStart    time.Time  `json:"window_start"`
+	WindowEnd      time.Time  `json:"window_end"`
+	PlannedArrival *time.Time `json:"planned_arrival,omitempty"`
+	DistanceKm     float64    `json:"distance_km"`
+	Note           string     `json:"note,omitempty"`
+}
+
+func NewRoutePlanService(routePlanRepo repository.RoutePlanRepository, orderRepo repository.OrderRepository, deliveryRepo repository.DeliveryRepository, laundryRepo repository.LaundryRepository, userRepo repository.UserRepository, outboxRepo repository.OutboxRepository, transactor repository.Transactor, orderCfg config.OrderConfig) RoutePlanService {
+	return &routePlanService{
+		routePlanRepo: routePlanRepo,
+		orderRepo:     orderRepo,
+		deliveryRepo:  deliveryRepo,
+		laundryRepo:   laundryRepo,
+		userRepo:      userRepo,
+		outboxRepo:    outboxRepo,
+		transactor:    transactor,
+		orderCfg:      orderCfg,
+	}
+}
+
+// Create plans the laundry's pickups and drop-offs due in the window across
+// the given couriers and saves the plan. Each planned leg is assigned to its
+// courier, replacing any earlier assignment that has not started.
+func (s *routePlanService) Create(ownerID, laundryID string, req CreateRoutePlanRequest) (*RoutePlanResponse, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+	if laundry.OwnerID != ownerUUID {
+		return nil, errors.New("unauthorized")
+	}
+	if laundry.Latitude == nil || laundry.Longitude == nil {
+		return nil, errors.New("laundry has no coordinates")
+	}
+
+	now := time.Now()
+	from := now
+	if req.From != nil {
+		from = *req.From
+	}
+	to := from.Add(12 * time.Hour)
+	if req.To != nil {
+		to = *req.To
+	}
+	if !to.After(from) {
+		return nil, errors.New("to must be after from")
+	}
+	if to.Sub(from) > maxRouteWindow {
+		return nil, errors.New("window cannot be longer than 24 hours")
+	}
+
+	capacity := req.Capacity
+	if capacity == 0 {
+		capacity = defaultRouteCapacity
+	}
+	if capacity < 1 || capacity > maxRouteCapacity {
+		return nil, errors.New("capacity must be between 1 and 50")
+	}
+
+	slack := defaultRouteSlack
+	if req.SlackMinutes != nil {
+		if *req.SlackMinutes < 0 {
+			return nil, errors.New("slack_minutes cannot be negative")
+		}
+		slack = time.Duration(*req.SlackMinutes) * time.Minute
+	}
+
+	couriers, err := s.findCouriers(req)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.orderRepo.FindRoutable(laundry.ID, from, to)
+	if err != nil {
+		return nil, errors.New("failed to fetch orders")
+	}
+
+	orderIDs := make([]uuid.UUID, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+	deliveries, err := s.deliveryRepo.FindByOrderIDs(orderIDs)
+	if err != nil {
+		return nil, errors.New("failed to fetch deliveries")
+	}
+	started := make(map[string]bool, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.Status != models.DeliveryStatusAssigned && delivery.Status != models.DeliveryStatusCancelled {
+			started[delivery.OrderID.String()+":"+delivery.Leg] = true
+		}
+	}
+
+	plan := &models.RoutePlan{
+		LaundryID:   laundry.ID,
+		CreatedBy:   ownerUUID,
+		WindowStart: from,
+		WindowEnd:   to,
+		Capacity:    capacity,
+	}
+
+	ordersByStop := make(map[string]*models.Order, len(orders))
+	stopsByID := make(map[string]models.RoutePlanStop, len(orders))
+	var candidates []routing.Stop
+	for i := range orders {
+		order := &orders[i]
+		leg := models.DeliveryLegPickup
+		estimate := order.EstimatedPickupAt
+		if order.Status == "ready" {
+			leg = models.DeliveryLegDropoff
+			estimate = order.EstimatedDeliveryAt
+		}
+
+		id := order.ID.String() + ":" + leg
+		if started[id] {
+			continue
+		}
+
+		windowStart, windowEnd := from, to
+		if estimate != nil {
+			windowStart, windowEnd = estimate.Add(-slack), estimate.Add(slack)
+		}
+
+		stop := models.RoutePlanStop{
+			OrderID:     order.ID,
+			Leg:         leg,
+			Latitude:    order.DeliveryLatitude,
+			Longitude:   order.DeliveryLongitude,
+			WindowStart: windowStart,
+			WindowEnd:   windowEnd,
+		}
+		if order.DeliveryLatitude == nil || order.DeliveryLongitude == nil {
+			stop.Note = routeNoteNoCoordinates
+			plan.Stops = append(plan.Stops, stop)
+			continue
+		}
+
+		ordersByStop[id] = order
+		stopsByID[id] = stop
+		candidates = append(candidates, routing.Stop{
+			ID:          id,
+			Point:       routing.Point{Latitude: *order.DeliveryLatitude, Longitude: *order.DeliveryLongitude},
+			WindowStart: windowStart,
+			WindowEnd:   windowEnd,
+		})
+	}
+
+	vehicles := make([]routing.Vehicle, 0, len(couriers))
+	for _, courier := range couriers {
+		vehicles = append(vehicles, routing.Vehicle{ID: courier.ID.String(), Capacity: capacity})
+	}
+
+	start := from
+	if start.Before(now) {
+		start = now
+	}
+	result := routing.Plan(
+		routing.Point{Latitude: *laundry.Latitude, Longitude: *laundry.Longitude},
+		candidates,
+		vehicles,
+		routing.Options{
+			Start:        start,
+			SpeedKmh:     s.orderCfg.CourierSpeedKmh,
+			ServiceTime:  routeServiceTime,
+			DetourFactor: routeDetourFactor,
+		},
+	)
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		deliveryRepo := s.deliveryRepo.WithTx(tx)
+		outboxRepo := s.outboxRepo.WithTx(tx)
+
+		for _, route := range result.Routes {
+			courierID := uuid.MustParse(route.VehicleID)
+			for i, visit := range route.Visits {
+				stop := stopsByID[visit.Stop.ID]
+				delivery, err := assignLeg(deliveryRepo, outboxRepo, ordersByStop[visit.Stop.ID], courierID, stop.Leg, "")
+				if err != nil {
+					return err
+				}
+
+				arrival := visit.Start
+				stop.CourierID = &courierID
+				stop.Sequence = i + 1
+				stop.DeliveryID = &delivery.ID
+				stop.PlannedArrival = &arrival
+				stop.DistanceKm = math.Round(visit.DistanceKm*100) / 100
+				plan.Stops = append(plan.Stops, stop)
+			}
+		}
+
+		for _, unassigned := range result.Unassigned {
+			stop := stopsByID[unassigned.ID]
+			stop.Note = routeNoteUnreachable
+			plan.Stops = append(plan.Stops, stop)
+		}
+
+		if err := s.routePlanRepo.WithTx(tx).Create(plan); err != nil {
+			return errors.New("failed to save route plan")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.routePlanRepo.FindByID(plan.ID)
+	if err != nil {
+		return nil, errors.New("route plan not found")
+	}
+	return toRoutePlanResponse(saved, laundry.Name), nil
+}
+
+func (s *routePlanService) GetByLaundry(ownerID, laundryID string, page, limit int) (*RoutePlanListResponse, error) {
+	ownerUUID, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+	if laundry.OwnerID != ownerUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	plans, total, err := s.routePlanRepo.FindByLaundryID(laundry.ID, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch route plans")
+	}
+
+	items := make([]RoutePlanSummary, 0, len(plans))
+	for i := range plans {
+		items = append(items, toRoutePlanSummary(&plans[i]))
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &RoutePlanListResponse{
+		Plans: items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// GetByID returns a plan to the laundry's owner or a courier with a run in
+// it.
+func (s *routePlanService) GetByID(userID, planID string) (*RoutePlanResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	planUUID, err := uuid.Parse(planID)
+	if err != nil {
+		return nil, errors.New("invalid route plan ID")
+	}
+
+	plan, err := s.routePlanRepo.FindByID(planUUID)
+	if err != nil {
+		return nil, errors.New("route plan not found")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(plan.LaundryID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+
+	allowed := laundry.OwnerID == userUUID
+	for _, stop := range plan.Stops {
+		if stop.CourierID != nil && *stop.CourierID == userUUID {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, errors.New("unauthorized")
+	}
+
+	return toRoutePlanResponse(plan, laundry.Name), nil
+}
+
+// GetMine returns the courier's runs from the latest plan of each laundry
+// whose window has not ended.
+func (s *routePlanService) GetMine(courierID string) ([]RouteRunResponse, error) {
+	courierUUID, err := uuid.Parse(courierID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	stops, err := s.routePlanRepo.FindStopsForCourier(courierUUID, time.Now())
+	if err != nil {
+		return nil, errors.New("failed to fetch route plans")
+	}
+
+	runs := make([]RouteRunResponse, 0)
+	for i := range stops {
+		stop := &stops[i]
+		if len(runs) == 0 || runs[len(runs)-1].PlanID != stop.PlanID.String() {
+			runs = append(runs, RouteRunResponse{
+				PlanID:      stop.PlanID.String(),
+				LaundryID:   stop.Order.LaundryID.String(),
+				LaundryName: stop.Order.Laundry.Name,
+				CourierID:   courierUUID.String(),
+				Stops:       []RouteStopResponse{},
+			})
+		}
+		run := &runs[len(runs)-1]
+		run.Stops = append(run.Stops, toRouteStopResponse(stop))
+		run.DistanceKm = math.Round((run.DistanceKm+stop.DistanceKm)*100) / 100
+	}
+
+	if len(runs) > 0 {
+		courier, err := s.userRepo.FindByID(courierUUID)
+		if err == nil {
+			for i := range runs {
+				runs[i].CourierName = courier.Name
+			}
+		}
+	}
+	return runs, nil
+}
+
+// findCouriers resolves the request's couriers, dropping duplicates.
+func (s *routePlanService) findCouriers(req CreateRoutePlanRequest) ([]*models.User, error) {
+	var couriers []*models.User
+	seen := make(map[uuid.UUID]bool)
+	add := func(courierID, courierEmail string) error {
+		courier, err := findCourier(s.userRepo, courierID, courierEmail)
+		if err != nil {
+			return err
+		}
+		if !seen[courier.ID] {
+			seen[courier.ID] = true
+			couriers = append(couriers, courier)
+		}
+		return nil
+	}
+
+	for _, id := range req.CourierIDs {
+		if err := add(id, ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, email := range req.CourierEmails {
+		if err := add("", email); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(couriers) == 0 {
+		return nil, errors.New("at least one courier is required")
+	}
+	if len(couriers) > maxRouteCouriers {
+		return nil, errors.New("at most 20 couriers can be planned at once")
+	}
+	return couriers, nil
+}
+
+func toRoutePlanSummary(plan *models.RoutePlan) RoutePlanSummary {
+	return RoutePlanSummary{
+		ID:          plan.ID.String(),
+		LaundryID:   plan.LaundryID.String(),
+		WindowStart: plan.WindowStart,
+		WindowEnd:   plan.WindowEnd,
+		Capacity:    plan.Capacity,
+		CreatedAt:   plan.CreatedAt,
+	}
+}
+
+// toRoutePlanResponse groups the plan's stops into runs. Stops must be
+// ordered by courier and sequence, as FindByID returns them.
+func toRoutePlanResponse(plan *models.RoutePlan, laundryName string) *RoutePlanResponse {
+	response := &RoutePlanResponse{
+		RoutePlanSummary: toRoutePlanSummary(plan),
+		Runs:             []RouteRunResponse{},
+		Unassigned:       []RouteStopResponse{},
+	}
+
+	for i := range plan.Stops {
+		stop := &plan.Stops[i]
+		if stop.CourierID == nil {
+			response.Unassigned = append(response.Unassigned, toRouteStopResponse(stop))
+			continue
+		}
+
+		if len(response.Runs) == 0 || response.Runs[len(response.Runs)-1].CourierID != stop.CourierID.String() {
+			run := RouteRunResponse{
+				PlanID:      plan.ID.String(),
+				LaundryID:   plan.LaundryID.String(),
+				LaundryName: laundryName,
+				CourierID:   stop.CourierID.String(),
+				Stops:       []RouteStopResponse{},
+			}
+			if stop.Courier != nil {
+				run.CourierName = stop.Courier.Name
+			}
+			response.Runs = append(response.Runs, run)
+		}
+		run := &response.Runs[len(response.Runs)-1]
+		run.Stops = append(run.Stops, toRouteStopResponse(stop))
+		run.DistanceKm = math.Round((run.DistanceKm+stop.DistanceKm)*100) / 100
+	}
+	return response
+}
+
+func toRouteStopResponse(stop *models.RoutePlanStop) RouteStopResponse {
+	response := RouteStopResponse{
+		Sequence:       stop.Sequence,
+		OrderID:        stop.OrderID.String(),
+		Leg:            stop.Leg,
+		CustomerName:   stop.Order.User.Name,
+		CustomerPhone:  stop.Order.User.Phone,
+		Address:        stop.Order.DeliveryAddress,
+		Latitude:       stop.Latitude,
+		Longitude:      stop.Longitude,
+		WindowStart:    stop.WindowStart,
+		WindowEnd:      stop.WindowEnd,
+		PlannedArrival: stop.PlannedArrival,
+		DistanceKm:     stop.DistanceKm,
+		Note:           stop.Note,
+	}
+	if stop.DeliveryID != nil {
+		response.DeliveryID = stop.DeliveryID.String()
+	}
+	return response
+}
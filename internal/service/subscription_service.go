@@ -0,0 +1,687 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SubscriptionService interface {
+	CreatePackage(userID, laundryID string, req CreatePackageRequest, locale string) (*PackageResponse, error)
+	GetPackages(laundryID, locale string) ([]PackageResponse, error)
+	Subscribe(userID string, req SubscribeRequest, locale string) (*SubscriptionResponse, error)
+	GetMine(userID, locale string) ([]SubscriptionResponse, error)
+	Renew(userID, subscriptionID, locale string) (*SubscriptionResponse, error)
+	Cancel(userID, subscriptionID, locale string) (*SubscriptionResponse, error)
+	GetUsage(userID, subscriptionID, locale string) (*SubscriptionUsageResponse, error)
+	SettleDue() (int, error)
+}
+
+// subscriptionSettleBatchSize caps how many ended periods one run settles.
+const subscriptionSettleBatchSize = 100
+
+type subscriptionService struct {
+	subscriptionRepo repository.SubscriptionRepository
+	laundryRepo      repository.LaundryRepository
+	walletRepo       repository.WalletRepository
+	userRepo         repository.UserRepository
+	transactor       repository.Transactor
+}
+
+type CreatePackageRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	QuotaKg     float64      `json:"quota_kg"`
+	Price       models.Money `json:"price"`
+	PeriodDays  int          `json:"period_days"`
+}
+
+type SubscribeRequest struct {
+	PackageID string `json:"package_id"`
+	AutoRenew *bool  `json:"auto_renew"`
+}
+
+type PackageResponse struct {
+	ID             string       `json:"id"`
+	LaundryID      string       `json:"laundry_id"`
+	Name           string       `json:"name"`
+	Description    string       `json:"description"`
+	QuotaKg        float64      `json:"quota_kg"`
+	Price          models.Money `json:"price"`
+	PriceFormatted string       `json:"price_formatted"`
+	Currency       string       `json:"currency"`
+	PeriodDays     int          `json:"period_days"`
+	IsActive       bool         `json:"is_active"`
+}
+
+type SubscriptionResponse struct {
+	ID             string    `json:"id"`
+	PackageID      string    `json:"package_id"`
+	PackageName    string    `json:"package_name"`
+	LaundryID      string    `json:"laundry_id"`
+	Status         string    `json:"status"`
+	PeriodStart    time.Time `json:"period_start"`
+	PeriodEnd      time.Time `json:"period_end"`
+	QuotaTotal     float64   `json:"quota_total"`
+	QuotaUsed      float64   `json:"quota_used"`
+	QuotaRemaining float64   `json:"quota_remaining"`
+	AutoRenew      bool      `json:"auto_renew"`
+	RenewalPrice   string    `json:"renewal_price"`
+}
+
+type SubscriptionUsageResponse struct {
+	Subscription SubscriptionResponse    `json:"subscription"`
+	Usage        []SubscriptionUsageItem `json:"usage"`
+}
+
+type SubscriptionUsageItem struct {
+	OrderID   string    `json:"order_id"`
+	Quantity  float64   `json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSubscriptionService(
+	subscriptionRepo repository.SubscriptionRepository,
+	laundryRepo repository.LaundryRepository,
+	walletRepo repository.WalletRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+) SubscriptionService {
+	return &subscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		laundryRepo:      laundryRepo,
+		walletRepo:       walletRepo,
+		userRepo:         userRepo,
+		transactor:       transactor,
+	}
+}
+
+func (s *subscriptionService) CreatePackage(userID, laundryID string, req CreatePackageRequest, locale string) (*PackageResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+	if laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+	if kgHundredths(req.QuotaKg) <= 0 {
+		return nil, errors.New("quota_kg must be greater than zero")
+	}
+	if req.Price <= 0 {
+		return nil, errors.New("price must be greater than zero")
+	}
+	if req.PeriodDays == 0 {
+		req.PeriodDays = 30
+	}
+	if req.PeriodDays < 1 {
+		return nil, errors.New("period_days must be at least 1")
+	}
+
+	pkg := &models.SubscriptionPackage{
+		LaundryID:   laundryUUID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		QuotaKg:     hundredthsKg(kgHundredths(req.QuotaKg)),
+		Price:       req.Price,
+		Currency:    currencyOrDefault(laundry.Currency),
+		PeriodDays:  req.PeriodDays,
+		IsActive:    true,
+	}
+	if err := s.subscriptionRepo.CreatePackage(pkg); err != nil {
+		return nil, errors.New("failed to create package")
+	}
+
+	return toPackageResponse(pkg, s.localeFor(userUUID, locale)), nil
+}
+
+func (s *subscriptionService) GetPackages(laundryID, locale string) ([]PackageResponse, error) {
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	packages, err := s.subscriptionRepo.FindPackagesByLaundryID(laundryUUID, true)
+	if err != nil {
+		return nil, errors.New("failed to fetch packages")
+	}
+
+	locale = resolveLocale(locale, nil)
+	responses := make([]PackageResponse, 0, len(packages))
+	for i := range packages {
+		responses = append(responses, *toPackageResponse(&packages[i], locale))
+	}
+	return responses, nil
+}
+
+// Subscribe buys a package for the current user. The first period is paid
+// from the customer's wallet straight away.
+func (s *subscriptionService) Subscribe(userID string, req SubscribeRequest, locale string) (*SubscriptionResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	packageUUID, err := uuid.Parse(req.PackageID)
+	if err != nil {
+		return nil, errors.New("invalid package ID")
+	}
+
+	pkg, err := s.subscriptionRepo.FindPackageByID(packageUUID)
+	if err != nil {
+		return nil, errors.New("package not found")
+	}
+	if !pkg.IsActive {
+		return nil, errors.New("package is not available")
+	}
+
+	autoRenew := true
+	if req.AutoRenew != nil {
+		autoRenew = *req.AutoRenew
+	}
+
+	now := time.Now()
+	subscription := &models.Subscription{
+		UserID:    userUUID,
+		PackageID: pkg.ID,
+		LaundryID: pkg.LaundryID,
+		Status:    models.SubscriptionStatusActive,
+		AutoRenew: autoRenew,
+	}
+	startSubscriptionPeriod(subscription, pkg, now)
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		subscriptionRepo := s.subscriptionRepo.WithTx(tx)
+
+		// One membership per laundry; an existing one must lapse first
+		if existing, _ := subscriptionRepo.FindActiveForUpdate(userUUID, pkg.LaundryID); existing != nil {
+			if err := settleSubscription(subscriptionRepo, s.walletRepo.WithTx(tx), existing, now); err != nil {
+				return err
+			}
+			if existing.Status == models.SubscriptionStatusActive {
+				return errors.New("you already have an active subscription at this laundry")
+			}
+		}
+
+		if err := subscriptionRepo.Create(subscription); err != nil {
+			return errors.New("failed to create subscription")
+		}
+		if err := chargeSubscription(s.walletRepo.WithTx(tx), subscription, pkg); err != nil {
+			return walletError(err, "failed to charge wallet")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Package = *pkg
+	return toSubscriptionResponse(subscription, s.localeFor(userUUID, locale)), nil
+}
+
+func (s *subscriptionService) GetMine(userID, locale string) ([]SubscriptionResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	subscriptions, err := s.subscriptionRepo.FindByUserID(userUUID)
+	if err != nil {
+		return nil, errors.New("failed to fetch subscriptions")
+	}
+
+	locale = s.localeFor(userUUID, locale)
+	responses := make([]SubscriptionResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		responses = append(responses, *toSubscriptionResponse(&subscriptions[i], locale))
+	}
+	return responses, nil
+}
+
+// Renew starts a new period for a subscription whose period has ended or
+// which has expired, charging the current package price.
+func (s *subscriptionService) Renew(userID, subscriptionID, locale string) (*SubscriptionResponse, error) {
+	userUUID, subscriptionUUID, err := parseSubscriptionIDs(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription *models.Subscription
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		subscriptionRepo := s.subscriptionRepo.WithTx(tx)
+
+		var err error
+		subscription, err = subscriptionRepo.FindByIDForUpdate(subscriptionUUID)
+		if err != nil || subscription.UserID != userUUID {
+			return errors.New("subscription not found")
+		}
+
+		now := time.Now()
+		if subscription.Status == models.SubscriptionStatusActive && now.Before(subscription.PeriodEnd) {
+			return errors.New("subscription period has not ended yet")
+		}
+
+		pkg, err := subscriptionRepo.FindPackageByID(subscription.PackageID)
+		if err != nil || !pkg.IsActive {
+			return errors.New("package is no longer available")
+		}
+
+		if subscription.Status == models.SubscriptionStatusExpired {
+			if other, _ := subscriptionRepo.FindActiveForUpdate(userUUID, subscription.LaundryID); other != nil && other.ID != subscription.ID {
+				return errors.New("you already have an active subscription at this laundry")
+			}
+		}
+
+		subscription.Status = models.SubscriptionStatusActive
+		startSubscriptionPeriod(subscription, pkg, now)
+		if err := chargeSubscription(s.walletRepo.WithTx(tx), subscription, pkg); err != nil {
+			return walletError(err, "failed to charge wallet")
+		}
+		if err := subscriptionRepo.Update(subscription); err != nil {
+			return errors.New("failed to renew subscription")
+		}
+		subscription.Package = *pkg
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSubscriptionResponse(subscription, s.localeFor(userUUID, locale)), nil
+}
+
+// Cancel turns off auto-renewal. The remaining quota stays usable until the
+// end of the current period, after which the subscription expires.
+func (s *subscriptionService) Cancel(userID, subscriptionID, locale string) (*SubscriptionResponse, error) {
+	userUUID, subscriptionUUID, err := parseSubscriptionIDs(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptionRepo.FindByID(subscriptionUUID)
+	if err != nil || subscription.UserID != userUUID {
+		return nil, errors.New("subscription not found")
+	}
+	if subscription.Status != models.SubscriptionStatusActive {
+		return nil, errors.New("subscription is not active")
+	}
+
+	subscription.AutoRenew = false
+	if err := s.subscriptionRepo.Update(subscription); err != nil {
+		return nil, errors.New("failed to cancel subscription")
+	}
+
+	return toSubscriptionResponse(subscription, s.localeFor(userUUID, locale)), nil
+}
+
+// GetUsage lists the quota drawn in the current period.
+func (s *subscriptionService) GetUsage(userID, subscriptionID, locale string) (*SubscriptionUsageResponse, error) {
+	userUUID, subscriptionUUID, err := parseSubscriptionIDs(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.subscriptionRepo.FindByID(subscriptionUUID)
+	if err != nil || subscription.UserID != userUUID {
+		return nil, errors.New("subscription not found")
+	}
+
+	usages, err := s.subscriptionRepo.FindUsageBySubscriptionID(subscription.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch subscription usage")
+	}
+
+	items := make([]SubscriptionUsageItem, 0, len(usages))
+	for _, usage := range usages {
+		if usage.CreatedAt.Before(subscription.PeriodStart) {
+			continue
+		}
+		items = append(items, SubscriptionUsageItem{
+			OrderID:   usage.OrderID.String(),
+			Quantity:  usage.Quantity,
+			CreatedAt: usage.CreatedAt,
+		})
+	}
+
+	return &SubscriptionUsageResponse{
+		Subscription: *toSubscriptionResponse(subscription, s.localeFor(userUUID, locale)),
+		Usage:        items,
+	}, nil
+}
+
+// SettleDue renews or expires active subscriptions whose period has ended
+// and reports how many it settled. Each is settled in its own transaction.
+func (s *subscriptionService) SettleDue() (int, error) {
+	settled := 0
+	for settled < subscriptionSettleBatchSize {
+		found := false
+		err := s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			subscriptionRepo := s.subscriptionRepo.WithTx(tx)
+			subscription, err := subscriptionRepo.ClaimEnded(now)
+			if err != nil || subscription == nil {
+				return err
+			}
+			found = true
+			return settleSubscription(subscriptionRepo, s.walletRepo.WithTx(tx), subscription, now)
+		})
+		if err != nil {
+			return settled, err
+		}
+		if !found {
+			break
+		}
+		settled++
+	}
+	return settled, nil
+}
+
+func (s *subscriptionService) localeFor(userID uuid.UUID, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	user, _ := s.userRepo.FindByID(userID)
+	return resolveLocale("", user)
+}
+
+// settleSubscription renews or expires a locked, active subscription whose
+// period has ended. Auto-renewal charges the customer's wallet; if the
+// package is gone or the wallet cannot cover it, the subscription expires.
+// SettleDue settles ended periods in the background; orders settle the
+// subscription they draw quota from first, in case it has not run yet.
+func settleSubscription(subscriptionRepo repository.SubscriptionRepository, walletRepo repository.WalletRepository, subscription *models.Subscription, now time.Time) error {
+	if subscription.Status != models.SubscriptionStatusActive || now.Before(subscription.PeriodEnd) {
+		return nil
+	}
+
+	renewed := false
+	if subscription.AutoRenew {
+		pkg, err := subscriptionRepo.FindPackageByID(subscription.PackageID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("failed to renew subscription")
+		}
+		if err == nil && pkg.IsActive {
+			// Charged before the period moves, so a failed renewal leaves
+			// the ended period as it was
+			err = chargeSubscription(walletRepo, subscription, pkg)
+			if err != nil && !errors.Is(err, errInsufficientWalletBalance) {
+				return errors.New("failed to renew subscription")
+			}
+			if err == nil {
+				startSubscriptionPeriod(subscription, pkg, nextPeriodStart(subscription.PeriodEnd, pkg.PeriodDays, now))
+				renewed = true
+			}
+		}
+	}
+	if !renewed {
+		subscription.Status = models.SubscriptionStatusExpired
+		subscription.QuotaRemaining = 0
+	}
+
+	if err := subscriptionRepo.Update(subscription); err != nil {
+		return errors.New("failed to update subscription")
+	}
+	return nil
+}
+
+func startSubscriptionPeriod(subscription *models.Subscription, pkg *models.SubscriptionPackage, start time.Time) {
+	subscription.PeriodStart = start
+	subscription.PeriodEnd = start.AddDate(0, 0, pkg.PeriodDays)
+	subscription.QuotaTotal = pkg.QuotaKg
+	subscription.QuotaRemaining = pkg.QuotaKg
+}
+
+// nextPeriodStart is where a renewed period begins: at the end of the last
+// one, so periods stay back to back. Periods that ended entirely before now,
+// while renewals were not running, are skipped rather than charged.
+func nextPeriodStart(periodEnd time.Time, periodDays int, now time.Time) time.Time {
+	start := periodEnd
+	for periodDays > 0 && !now.Before(start.AddDate(0, 0, periodDays)) {
+		start = start.AddDate(0, 0, periodDays)
+	}
+	return start
+}
+
+func chargeSubscription(walletRepo repository.WalletRepository, subscription *models.Subscription, pkg *models.SubscriptionPackage) error {
+	currency := currencyOrDefault(pkg.Currency)
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxSubscription,
+		Reference:   subscription.ID.String(),
+		Description: "Subscription: " + pkg.Name,
+		CreatedBy:   &subscription.UserID,
+	}
+	return transferWallet(walletRepo, transaction,
+		customerWalletAccount(subscription.UserID, currency),
+		systemWalletAccount(models.WalletAccountRevenue, currency),
+		pkg.Price)
+}
+
+// drawSubscriptionQuota covers the order's kg lines from the customer's
+// subscription at the laundry, if any, and reprices each line so only the
+// uncovered weight is charged. Quota is counted in hundredths of a kg to
+// avoid float drift. It returns the usage rows to record once the lines are
+// written; the subscription stays locked until the transaction ends.
+func drawSubscriptionQuota(subscriptionRepo repository.SubscriptionRepository, walletRepo repository.WalletRepository, order *models.Order, lines []models.OrderService, now time.Time) ([]models.SubscriptionUsage, error) {
+	subscription, err := subscriptionRepo.FindActiveForUpdate(order.UserID, order.LaundryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.New("failed to use subscription quota")
+	}
+	if err := settleSubscription(subscriptionRepo, walletRepo, subscription, now); err != nil {
+		return nil, err
+	}
+	if subscription.Status != models.SubscriptionStatusActive {
+		return nil, nil
+	}
+
+	remaining := kgHundredths(subscription.QuotaRemaining)
+	var usages []models.SubscriptionUsage
+	var subtotal models.Money
+	for i := range lines {
+		line := &lines[i]
+		if remaining > 0 && strings.EqualFold(line.Unit, "kg") {
+			covered := kgHundredths(line.Quantity)
+			if covered > remaining {
+				covered = remaining
+			}
+			remaining -= covered
+			line.QuotaQuantity = hundredthsKg(covered)
+			line.Subtotal = line.UnitPrice.MulQuantity(hundredthsKg(kgHundredths(line.Quantity) - covered))
+			if line.ID == uuid.Nil {
+				line.ID = uuid.New()
+			}
+			usages = append(usages, models.SubscriptionUsage{
+				SubscriptionID: subscription.ID,
+				OrderID:        order.ID,
+				OrderServiceID: line.ID,
+				Quantity:       line.QuotaQuantity,
+			})
+		}
+		subtotal += line.Subtotal
+	}
+
+	if len(usages) == 0 {
+		return nil, nil
+	}
+
+	subscription.QuotaRemaining = hundredthsKg(remaining)
+	if err := subscriptionRepo.Update(subscription); err != nil {
+		return nil, errors.New("failed to use subscription quota")
+	}
+
+	subscriptionID := subscription.ID
+	order.SubscriptionID = &subscriptionID
+	order.Subtotal = subtotal
+	order.TotalPrice = subtotal
+	return usages, nil
+}
+
+// releaseSubscriptionQuota returns the quota a cancelled order drew, as long
+// as it was drawn in the subscription's current period.
+func releaseSubscriptionQuota(subscriptionRepo repository.SubscriptionRepository, order *models.Order) error {
+	if order.SubscriptionID == nil {
+		return nil
+	}
+
+	subscription, err := subscriptionRepo.FindByIDForUpdate(*order.SubscriptionID)
+	if err != nil {
+		return nil
+	}
+
+	usages, err := subscriptionRepo.FindUsageByOrderID(order.ID)
+	if err != nil {
+		return err
+	}
+	if len(usages) == 0 {
+		return nil
+	}
+
+	var released int64
+	for _, usage := range usages {
+		if !usage.CreatedAt.Before(subscription.PeriodStart) {
+			released += kgHundredths(usage.Quantity)
+		}
+	}
+
+	if released > 0 && subscription.Status == models.SubscriptionStatusActive {
+		remaining := kgHundredths(subscription.QuotaRemaining) + released
+		if total := kgHundredths(subscription.QuotaTotal); remaining > total {
+			remaining = total
+		}
+		subscription.QuotaRemaining = hundredthsKg(remaining)
+		if err := subscriptionRepo.Update(subscription); err != nil {
+			return err
+		}
+	}
+
+	return subscriptionRepo.DeleteUsageByOrderID(order.ID)
+}
+
+// returnAdjustedQuota gives back quota an order no longer needs after its
+// kg lines were weighed lighter than the quota they drew. Usage rows are
+// trimmed either way; the subscription only gets the quota back if it was
+// drawn in the current period.
+func returnAdjustedQuota(subscriptionRepo repository.SubscriptionRepository, order *models.Order, lines []models.OrderAdjustmentLine) error {
+	if order.SubscriptionID == nil {
+		return nil
+	}
+
+	subscription, err := subscriptionRepo.FindByIDForUpdate(*order.SubscriptionID)
+	if err != nil {
+		return nil
+	}
+
+	usages, err := subscriptionRepo.FindUsageByOrderID(order.ID)
+	if err != nil {
+		return err
+	}
+	byLine := make(map[uuid.UUID]*models.SubscriptionUsage, len(usages))
+	for i := range usages {
+		byLine[usages[i].OrderServiceID] = &usages[i]
+	}
+
+	var released int64
+	for _, line := range lines {
+		freed := kgHundredths(line.QuotaBefore) - kgHundredths(line.QuotaAfter)
+		usage := byLine[line.OrderServiceID]
+		if freed <= 0 || usage == nil {
+			continue
+		}
+		usage.Quantity = line.QuotaAfter
+		if err := subscriptionRepo.UpdateUsage(usage); err != nil {
+			return err
+		}
+		if !usage.CreatedAt.Before(subscription.PeriodStart) {
+			released += freed
+		}
+	}
+
+	if released > 0 && subscription.Status == models.SubscriptionStatusActive {
+		remaining := kgHundredths(subscription.QuotaRemaining) + released
+		if total := kgHundredths(subscription.QuotaTotal); remaining > total {
+			remaining = total
+		}
+		subscription.QuotaRemaining = hundredthsKg(remaining)
+		return subscriptionRepo.Update(subscription)
+	}
+	return nil
+}
+
+func kgHundredths(kg float64) int64 {
+	return int64(math.Round(kg * 100))
+}
+
+func hundredthsKg(hundredths int64) float64 {
+	return float64(hundredths) / 100
+}
+
+func parseSubscriptionIDs(userID, subscriptionID string) (uuid.UUID, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid user ID")
+	}
+	subscriptionUUID, err := uuid.Parse(subscriptionID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid subscription ID")
+	}
+	return userUUID, subscriptionUUID, nil
+}
+
+func toPackageResponse(pkg *models.SubscriptionPackage, locale string) *PackageResponse {
+	currency := currencyOrDefault(pkg.Currency)
+	return &PackageResponse{
+		ID:             pkg.ID.String(),
+		LaundryID:      pkg.LaundryID.String(),
+		Name:           pkg.Name,
+		Description:    pkg.Description,
+		QuotaKg:        pkg.QuotaKg,
+		Price:          pkg.Price,
+		PriceFormatted: pkg.Price.Format(currency, locale),
+		Currency:       currency,
+		PeriodDays:     pkg.PeriodDays,
+		IsActive:       pkg.IsActive,
+	}
+}
+
+func toSubscriptionResponse(subscription *models.Subscription, locale string) *SubscriptionResponse {
+	renewalPrice := ""
+	if subscription.Package.ID != uuid.Nil {
+		renewalPrice = subscription.Package.Price.Format(currencyOrDefault(subscription.Package.Currency), locale)
+	}
+
+	return &SubscriptionResponse{
+		ID:             subscription.ID.String(),
+		PackageID:      subscription.PackageID.String(),
+		PackageName:    subscription.Package.Name,
+		LaundryID:      subscription.LaundryID.String(),
+		Status:         subscription.Status,
+		PeriodStart:    subscription.PeriodStart,
+		PeriodEnd:      subscription.PeriodEnd,
+		QuotaTotal:     subscription.QuotaTotal,
+		QuotaUsed:      hundredthsKg(kgHundredths(subscription.QuotaTotal) - kgHundredths(subscription.QuotaRemaining)),
+		QuotaRemaining: subscription.QuotaRemaining,
+		AutoRenew:      subscription.AutoRenew,
+		RenewalPrice:   renewalPrice,
+	}
+}
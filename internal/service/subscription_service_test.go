@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextPeriodStart(t *testing.T) {
+	periodEnd := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		periodDays int
+		now        time.Time
+		want       time.Time
+	}{
+		{name: "renewed on time", periodDays: 30, now: periodEnd, want: periodEnd},
+		{name: "renewed late in the next period", periodDays: 30, now: periodEnd.AddDate(0, 0, 29), want: periodEnd},
+		{name: "one whole period missed", periodDays: 30, now: periodEnd.AddDate(0, 0, 30), want: periodEnd.AddDate(0, 0, 30)},
+		{name: "several periods missed", periodDays: 7, now: periodEnd.AddDate(0, 0, 22), want: periodEnd.AddDate(0, 0, 21)},
+		{name: "no period length", periodDays: 0, now: periodEnd.AddDate(1, 0, 0), want: periodEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPeriodStart(periodEnd, tt.periodDays, tt.now); !got.Equal(tt.want) {
+				t.Errorf("nextPeriodStart = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/config"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/utils"
+	"log"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// routeDetourFactor converts straight-line distance to an estimate of
+	// the distance by road.
+	routeDetourFactor = 1.3
+	trailWindow       = 30 * time.Minute
+	trailMaxPoints    = 50
+	// trailRetention is how long location pings are kept before pruning.
+	trailRetention = 24 * time.Hour
+)
+
+type TrackingService interface {
+	RecordLocation(courierID string, req LocationPingRequest) error
+	GetTracking(userID, orderID string) (*OrderTrackingResponse, error)
+	PruneTrail() (int64, error)
+}
+
+type trackingService struct {
+	deliveryRepo repository.DeliveryRepository
+	locationRepo repository.CourierLocationRepository
+	orderRepo    repository.OrderRepository
+	transactor   repository.Transactor
+	bus          events.Bus
+	orderCfg     config.OrderConfig
+}
+
+type LocationPingRequest struct {
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	Accuracy   *float64   `json:"accuracy"`
+	Heading    *float64   `json:"heading"`
+	RecordedAt *time.Time `json:"recorded_at"` // defaults to now
+}
+
+// OrderTrackingResponse is the live position of the courier moving an
+// order. Active is false when no courier is on the road for it.
+type OrderTrackingResponse struct {
+	OrderID           string          `json:"order_id"`
+	Active            bool            `json:"active"`
+	DeliveryID        string          `json:"delivery_id,omitempty"`
+	Leg               string          `json:"leg,omitempty"`
+	DeliveryStatus    string          `json:"delivery_status,omitempty"`
+	CourierName       string          `json:"courier_name,omitempty"`
+	Latitude          *float64        `json:"latitude,omitempty"`
+	Longitude         *float64        `json:"longitude,omitempty"`
+	LocationUpdatedAt *time.Time      `json:"location_updated_at,omitempty"`
+	Trail             []TrackingPoint `json:"trail,omitempty"`
+	DistanceKm        *float64        `json:"distance_km,omitempty"`
+	ETAMinutes        *int            `json:"eta_minutes,omitempty"`
+	ETA               *time.Time      `json:"eta,omitempty"`
+}
+
+type TrackingPoint struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+func NewTrackingService(deliveryRepo repository.DeliveryRepository, locationRepo repository.CourierLocationRepository, orderRepo repository.OrderRepository, transactor repository.Transactor, bus events.Bus, orderCfg config.OrderConfig) TrackingService {
+	return &trackingService{
+		deliveryRepo: deliveryRepo,
+		locationRepo: locationRepo,
+		orderRepo:    orderRepo,
+		transactor:   transactor,
+		bus:          bus,
+		orderCfg:     orderCfg,
+	}
+}
+
+// RecordLocation stores a courier's position and pushes the updated ETA to
+// everyone following the orders the courier is moving. Positions are
+// ephemeral, so they go straight to the bus rather than through the outbox.
+func (s *trackingService) RecordLocation(courierID string, req LocationPingRequest) error {
+	courierUUID, err := uuid.Parse(courierID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+		return errors.New("invalid coordinates")
+	}
+
+	now := time.Now()
+	recordedAt := now
+	if req.RecordedAt != nil && req.RecordedAt.Before(now) && now.Sub(*req.RecordedAt) < trailWindow {
+		recordedAt = *req.RecordedAt
+	}
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		locationRepo := s.locationRepo.WithTx(tx)
+		if err := locationRepo.SaveLatest(&models.CourierLocation{
+			CourierID:  courierUUID,
+			Latitude:   req.Latitude,
+			Longitude:  req.Longitude,
+			Accuracy:   req.Accuracy,
+			Heading:    req.Heading,
+			RecordedAt: recordedAt,
+		}); err != nil {
+			return err
+		}
+		return locationRepo.CreatePing(&models.CourierLocationPing{
+			CourierID:  courierUUID,
+			Latitude:   req.Latitude,
+			Longitude:  req.Longitude,
+			RecordedAt: recordedAt,
+		})
+	})
+	if err != nil {
+		return errors.New("failed to record location")
+	}
+
+	deliveries, err := s.deliveryRepo.FindActiveByCourierID(courierUUID)
+	if err != nil {
+		log.Printf("tracking: failed to find deliveries for courier %s: %v", courierUUID, err)
+		return nil
+	}
+
+	for i := range deliveries {
+		order := &deliveries[i].Order
+		distance, minutes := estimateArrival(req.Latitude, req.Longitude, order, s.orderCfg.CourierSpeedKmh)
+		data := map[string]interface{}{
+			"delivery_id": deliveries[i].ID.String(),
+			"leg":         deliveries[i].Leg,
+			"latitude":    req.Latitude,
+			"longitude":   req.Longitude,
+			"recorded_at": recordedAt,
+		}
+		if distance != nil {
+			data["distance_km"] = *distance
+			data["eta_minutes"] = *minutes
+			data["eta"] = now.Add(time.Duration(*minutes) * time.Minute)
+		}
+		if err := s.bus.Publish(events.NewEvent(events.CourierLocation, order.ID, order.UserID, order.LaundryID, data)); err != nil {
+			log.Printf("tracking: failed to publish location for order %s: %v", order.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetTracking returns the live tracking of an order to its customer or the
+// laundry's owner.
+func (s *trackingService) GetTracking(userID, orderID string) (*OrderTrackingResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID")
+	}
+
+	order, err := s.orderRepo.FindByID(orderUUID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+	if order.UserID != userUUID && order.Laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+
+	tracking, err := orderTracking(s.deliveryRepo, s.locationRepo, order, s.orderCfg.CourierSpeedKmh)
+	if err != nil {
+		return nil, errors.New("failed to fetch tracking")
+	}
+	return tracking, nil
+}
+
+// PruneTrail deletes location pings older than trailRetention.
+func (s *trackingService) PruneTrail() (int64, error) {
+	return s.locationRepo.DeletePingsBefore(time.Now().Add(-trailRetention))
+}
+
+// orderTracking builds the tracking view of an order from its active
+// delivery and the courier's latest position.
+func orderTracking(deliveryRepo repository.DeliveryRepository, locationRepo repository.CourierLocationRepository, order *models.Order, speedKmh float64) (*OrderTrackingResponse, error) {
+	tracking := &OrderTrackingResponse{OrderID: order.ID.String()}
+
+	delivery, err := deliveryRepo.FindActiveByOrderID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return tracking, nil
+	}
+
+	tracking.Active = true
+	tracking.DeliveryID = delivery.ID.String()
+	tracking.Leg = delivery.Leg
+	tracking.DeliveryStatus = delivery.Status
+	tracking.CourierName = delivery.Courier.Name
+
+	location, err := locationRepo.FindLatest(delivery.CourierID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tracking, nil
+		}
+		return nil, err
+	}
+
+	tracking.Latitude = &location.Latitude
+	tracking.Longitude = &location.Longitude
+	tracking.LocationUpdatedAt = &location.RecordedAt
+
+	trail, err := locationRepo.FindTrail(delivery.CourierID, time.Now().Add(-trailWindow), trailMaxPoints)
+	if err != nil {
+		return nil, err
+	}
+	tracking.Trail = make([]TrackingPoint, 0, len(trail))
+	for _, ping := range trail {
+		tracking.Trail = append(tracking.Trail, TrackingPoint{
+			Latitude:   ping.Latitude,
+			Longitude:  ping.Longitude,
+			RecordedAt: ping.RecordedAt,
+		})
+	}
+
+	distance, minutes := estimateArrival(location.Latitude, location.Longitude, order, speedKmh)
+	if distance != nil {
+		eta := time.Now().Add(time.Duration(*minutes) * time.Minute)
+		tracking.DistanceKm = distance
+		tracking.ETAMinutes = minutes
+		tracking.ETA = &eta
+	}
+	return tracking, nil
+}
+
+// estimateArrival estimates the road distance and minutes from a position
+// to the order's delivery coordinates at the average courier speed. Both
+// are nil when the order has no coordinates.
+func estimateArrival(lat, lng float64, order *models.Order, speedKmh float64) (*float64, *int) {
+	if order.DeliveryLatitude == nil || order.DeliveryLongitude == nil {
+		return nil, nil
+	}
+
+	distance := utils.CalculateDistance(lat, lng, *order.DeliveryLatitude, *order.DeliveryLongitude) * routeDetourFactor
+	distance = math.Round(distance*100) / 100
+	minutes := int(math.Ceil(distance / speedKmh * 60))
+	return &distance, &minutes
+}
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errInsufficientWalletBalance = errors.New("insufficient wallet balance")
+
+type WalletService interface {
+	GetBalances(userID, locale string) ([]WalletBalanceResponse, error)
+	GetHistory(userID, currency string, page, limit int, locale string) (*WalletHistoryResponse, error)
+	TopUp(adminID string, req WalletTopUpRequest) (*WalletTransactionResponse, error)
+	Adjust(adminID string, req WalletAdjustmentRequest) (*WalletTransactionResponse, error)
+}
+
+type walletService struct {
+	walletRepo repository.WalletRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+}
+
+type WalletTopUpRequest struct {
+	UserID    string       `json:"user_id"`
+	Amount    models.Money `json:"amount"`
+	Currency  string       `json:"currency"`
+	Reference string       `json:"reference"`
+}
+
+type WalletAdjustmentRequest struct {
+	UserID      string       `json:"user_id"`
+	Amount      models.Money `json:"amount"` // positive credits the wallet, negative debits it
+	Currency    string       `json:"currency"`
+	Description string       `json:"description"`
+}
+
+type WalletBalanceResponse struct {
+	Currency         string       `json:"currency"`
+	Balance          models.Money `json:"balance"`
+	BalanceFormatted string       `json:"balance_formatted"`
+}
+
+type WalletHistoryResponse struct {
+	Currency   string                `json:"currency"`
+	Entries    []WalletEntryResponse `json:"entries"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+type WalletEntryResponse struct {
+	ID                    string       `json:"id"`
+	TransactionID         string       `json:"transaction_id"`
+	Type                  string       `json:"type"`
+	OrderID               *string      `json:"order_id,omitempty"`
+	Description           string       `json:"description"`
+	Amount                models.Money `json:"amount"`
+	AmountFormatted       string       `json:"amount_formatted"`
+	BalanceAfter          models.Money `json:"balance_after"`
+	BalanceAfterFormatted string       `json:"balance_after_formatted"`
+	CreatedAt             time.Time    `json:"created_at"`
+}
+
+type WalletTransactionResponse struct {
+	ID          string       `json:"id"`
+	Type        string       `json:"type"`
+	UserID      string       `json:"user_id"`
+	Currency    string       `json:"currency"`
+	Amount      models.Money `json:"amount"`
+	Balance     models.Money `json:"balance"`
+	Reference   string       `json:"reference,omitempty"`
+	Description string       `json:"description"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+func NewWalletService(walletRepo repository.WalletRepository, userRepo repository.UserRepository, transactor repository.Transactor) WalletService {
+	return &walletService{
+		walletRepo: walletRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+	}
+}
+
+func (s *walletService) GetBalances(userID, locale string) ([]WalletBalanceResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	accounts, err := s.walletRepo.FindAccountsByUserID(userUUID)
+	if err != nil {
+		return nil, errors.New("failed to fetch wallet")
+	}
+
+	user, _ := s.userRepo.FindByID(userUUID)
+	locale = resolveLocale(locale, user)
+
+	balances := make([]WalletBalanceResponse, 0, len(accounts))
+	for _, account := range accounts {
+		balances = append(balances, WalletBalanceResponse{
+			Currency:         account.Currency,
+			Balance:          account.Balance,
+			BalanceFormatted: account.Balance.Format(account.Currency, locale),
+		})
+	}
+
+	// A user without any wallet activity still has an empty default wallet
+	if len(balances) == 0 {
+		balances = append(balances, WalletBalanceResponse{
+			Currency:         models.DefaultCurrency,
+			BalanceFormatted: models.Money(0).Format(models.DefaultCurrency, locale),
+		})
+	}
+
+	return balances, nil
+}
+
+func (s *walletService) GetHistory(userID, currency string, page, limit int, locale string) (*WalletHistoryResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	currency = strings.ToUpper(currencyOrDefault(currency))
+
+	entries, total, err := s.walletRepo.FindEntriesByAccountID(models.CustomerWalletAccountID(userUUID, currency), page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch wallet history")
+	}
+
+	user, _ := s.userRepo.FindByID(userUUID)
+	locale = resolveLocale(locale, user)
+
+	items := make([]WalletEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		var orderID *string
+		if entry.Transaction.OrderID != nil {
+			id := entry.Transaction.OrderID.String()
+			orderID = &id
+		}
+		items = append(items, WalletEntryResponse{
+			ID:                    entry.ID.String(),
+			TransactionID:         entry.TransactionID.String(),
+			Type:                  entry.Transaction.Type,
+			OrderID:               orderID,
+			Description:           entry.Transaction.Description,
+			Amount:                entry.Amount,
+			AmountFormatted:       entry.Amount.Format(currency, locale),
+			BalanceAfter:          entry.BalanceAfter,
+			BalanceAfterFormatted: entry.BalanceAfter.Format(currency, locale),
+			CreatedAt:             entry.CreatedAt,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &WalletHistoryResponse{
+		Currency: currency,
+		Entries:  items,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *walletService) TopUp(adminID string, req WalletTopUpRequest) (*WalletTransactionResponse, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be greater than zero")
+	}
+
+	user, currency, err := s.validateTarget(req.UserID, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxTopUp,
+		Reference:   req.Reference,
+		Description: "Wallet top-up",
+		CreatedBy:   parseOptionalUUID(adminID),
+	}
+	customer := customerWalletAccount(user.ID, currency)
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		return transferWallet(s.walletRepo.WithTx(tx), transaction, systemWalletAccount(models.WalletAccountFunding, currency), customer, req.Amount)
+	})
+	if err != nil {
+		return nil, walletError(err, "failed to top up wallet")
+	}
+
+	return s.toTransactionResponse(transaction, user.ID, currency, req.Amount)
+}
+
+func (s *walletService) Adjust(adminID string, req WalletAdjustmentRequest) (*WalletTransactionResponse, error) {
+	if req.Amount == 0 {
+		return nil, errors.New("amount cannot be zero")
+	}
+	if utils.IsEmpty(req.Description) {
+		return nil, errors.New("description is required")
+	}
+
+	user, currency, err := s.validateTarget(req.UserID, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxAdjustment,
+		Description: req.Description,
+		CreatedBy:   parseOptionalUUID(adminID),
+	}
+	customer := customerWalletAccount(user.ID, currency)
+	system := systemWalletAccount(models.WalletAccountAdjustment, currency)
+
+	err = s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		if req.Amount > 0 {
+			return transferWallet(s.walletRepo.WithTx(tx), transaction, system, customer, req.Amount)
+		}
+		return transferWallet(s.walletRepo.WithTx(tx), transaction, customer, system, -req.Amount)
+	})
+	if err != nil {
+		return nil, walletError(err, "failed to adjust wallet")
+	}
+
+	return s.toTransactionResponse(transaction, user.ID, currency, req.Amount)
+}
+
+func (s *walletService) validateTarget(userID, currency string) (*models.User, string, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, "", errors.New("invalid user ID")
+	}
+
+	user, err := s.userRepo.FindByID(userUUID)
+	if err != nil {
+		return nil, "", errors.New("user not found")
+	}
+
+	currency = strings.ToUpper(currencyOrDefault(currency))
+	if !models.IsSupportedCurrency(currency) {
+		return nil, "", errors.New("unsupported currency")
+	}
+
+	return user, currency, nil
+}
+
+func (s *walletService) toTransactionResponse(transaction *models.WalletTransaction, userID uuid.UUID, currency string, amount models.Money) (*WalletTransactionResponse, error) {
+	account, err := s.walletRepo.FindAccountByID(models.CustomerWalletAccountID(userID, currency))
+	if err != nil {
+		return nil, errors.New("failed to fetch wallet")
+	}
+
+	return &WalletTransactionResponse{
+		ID:          transaction.ID.String(),
+		Type:        transaction.Type,
+		UserID:      userID.String(),
+		Currency:    currency,
+		Amount:      amount,
+		Balance:     account.Balance,
+		Reference:   transaction.Reference,
+		Description: transaction.Description,
+		CreatedAt:   transaction.CreatedAt,
+	}, nil
+}
+
+func customerWalletAccount(userID uuid.UUID, currency string) *models.WalletAccount {
+	return &models.WalletAccount{
+		ID:       models.CustomerWalletAccountID(userID, currency),
+		UserID:   &userID,
+		Kind:     models.WalletAccountCustomer,
+		Currency: currency,
+	}
+}
+
+func systemWalletAccount(kind, currency string) *models.WalletAccount {
+	return &models.WalletAccount{
+		ID:       models.SystemWalletAccountID(kind, currency),
+		Kind:     kind,
+		Currency: currency,
+	}
+}
+
+// transferWallet posts transaction as a balanced pair of entries moving amount
+// from one account to the other. Both accounts are created on first use and
+// locked in ID order, so concurrent spends serialise without deadlocking and a
+// customer account can never go below zero.
+func transferWallet(walletRepo repository.WalletRepository, transaction *models.WalletTransaction, from, to *models.WalletAccount, amount models.Money) error {
+	if amount <= 0 {
+		return errors.New("transfer amount must be greater than zero")
+	}
+
+	for _, account := range []*models.WalletAccount{from, to} {
+		if err := walletRepo.EnsureAccount(account); err != nil {
+			return err
+		}
+	}
+
+	ordered := []*models.WalletAccount{from, to}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].ID.String() < ordered[j].ID.String()
+	})
+
+	locked := make(map[uuid.UUID]*models.WalletAccount, 2)
+	for _, account := range ordered {
+		current, err := walletRepo.FindAccountForUpdate(account.ID)
+		if err != nil {
+			return err
+		}
+		locked[account.ID] = current
+	}
+
+	source, destination := locked[from.ID], locked[to.ID]
+	if source.Kind == models.WalletAccountCustomer && source.Balance < amount {
+		return errInsufficientWalletBalance
+	}
+
+	source.Balance -= amount
+	destination.Balance += amount
+
+	if err := walletRepo.UpdateBalance(source.ID, source.Balance); err != nil {
+		return err
+	}
+	if err := walletRepo.UpdateBalance(destination.ID, destination.Balance); err != nil {
+		return err
+	}
+
+	if err := walletRepo.CreateTransaction(transaction); err != nil {
+		return err
+	}
+
+	return walletRepo.CreateEntries([]models.WalletEntry{
+		{TransactionID: transaction.ID, AccountID: source.ID, Amount: -amount, BalanceAfter: source.Balance},
+		{TransactionID: transaction.ID, AccountID: destination.ID, Amount: amount, BalanceAfter: destination.Balance},
+	})
+}
+
+// payOrderFromWallet charges the order total to the customer's wallet.
+func payOrderFromWallet(walletRepo repository.WalletRepository, order *models.Order) error {
+	orderID := order.ID
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxOrderPayment,
+		OrderID:     &orderID,
+		Description: "Order payment",
+		CreatedBy:   &order.UserID,
+	}
+	return transferWallet(walletRepo, transaction,
+		customerWalletAccount(order.UserID, order.Currency),
+		systemWalletAccount(models.WalletAccountRevenue, order.Currency),
+		order.TotalPrice)
+}
+
+// refundOrderToWallet credits the order total back to the customer's wallet.
+// An order is refunded at most once.
+func refundOrderToWallet(walletRepo repository.WalletRepository, order *models.Order, description string) error {
+	if existing, _ := walletRepo.FindTransactionByOrder(order.ID, models.WalletTxRefund); existing != nil {
+		return nil
+	}
+
+	orderID := order.ID
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxRefund,
+		OrderID:     &orderID,
+		Description: description,
+	}
+	return transferWallet(walletRepo, transaction,
+		systemWalletAccount(models.WalletAccountRevenue, order.Currency),
+		customerWalletAccount(order.UserID, order.Currency),
+		order.TotalPrice)
+}
+
+// settleOrderAdjustment moves the price difference of an adjusted wallet
+// order: a positive amount is charged to the customer, a negative amount
+// refunded.
+func settleOrderAdjustment(walletRepo repository.WalletRepository, order *models.Order, amount models.Money) error {
+	orderID := order.ID
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxOrderAdjustment,
+		OrderID:     &orderID,
+		Description: "Order weight adjustment",
+		CreatedBy:   &order.UserID,
+	}
+	customer := customerWalletAccount(order.UserID, order.Currency)
+	revenue := systemWalletAccount(models.WalletAccountRevenue, order.Currency)
+	if amount > 0 {
+		return transferWallet(walletRepo, transaction, customer, revenue, amount)
+	}
+	return transferWallet(walletRepo, transaction, revenue, customer, -amount)
+}
+
+// refundClaimToWallet credits a claim refund to the customer's wallet.
+func refundClaimToWallet(walletRepo repository.WalletRepository, order *models.Order, claimID uuid.UUID, amount models.Money, refundedBy uuid.UUID) error {
+	orderID := order.ID
+	transaction := &models.WalletTransaction{
+		Type:        models.WalletTxClaimRefund,
+		OrderID:     &orderID,
+		Reference:   claimID.String(),
+		Description: "Refund for claim",
+		CreatedBy:   &refundedBy,
+	}
+	return transferWallet(walletRepo, transaction,
+		systemWalletAccount(models.WalletAccountRevenue, order.Currency),
+		customerWalletAccount(order.UserID, order.Currency),
+		amount)
+}
+
+// walletError keeps the insufficient balance error visible to clients and
+// hides database errors behind a generic message.
+func walletError(err error, fallback string) error {
+	if errors.Is(err, errInsufficientWalletBalance) {
+		return err
+	}
+	return errors.New(fallback)
+}
+
+func parseOptionalUUID(id string) *uuid.UUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openWalletTestTx returns a transaction on TEST_DATABASE_URL with the wallet
+// tables in place, rolled back when the test ends. Tests that need Postgres
+// are skipped when it is not set.
+func openWalletTestTx(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.WalletAccount{}, &models.WalletTransaction{}, &models.WalletEntry{}); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	tx := db.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// assertLedgerBalanced checks the double-entry invariants for accounts:
+// every transaction touching them sums to zero, and each balance equals the
+// sum of its entries and the balance_after of its latest entry.
+func assertLedgerBalanced(t *testing.T, tx *gorm.DB, accounts ...*models.WalletAccount) {
+	t.Helper()
+	ids := make([]uuid.UUID, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+
+	var unbalanced int64
+	err := tx.Raw(`SELECT COUNT(*) FROM (
+		SELECT transaction_id FROM wallet_entries
+		WHERE transaction_id IN (SELECT transaction_id FROM wallet_entries WHERE account_id IN ?)
+		GROUP BY transaction_id HAVING SUM(amount) <> 0
+	) unbalanced`, ids).Scan(&unbalanced).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unbalanced > 0 {
+		t.Errorf("%d wallet transactions do not sum to zero", unbalanced)
+	}
+
+	for _, id := range ids {
+		var account models.WalletAccount
+		if err := tx.Where("id = ?", id).First(&account).Error; err != nil {
+			t.Fatal(err)
+		}
+		var sum models.Money
+		if err := tx.Raw("SELECT COALESCE(SUM(amount), 0) FROM wallet_entries WHERE account_id = ?", id).Scan(&sum).Error; err != nil {
+			t.Fatal(err)
+		}
+		if sum != account.Balance {
+			t.Errorf("account %s: balance %s, entries sum to %s", account.Kind, account.Balance, sum)
+		}
+		var latest models.WalletEntry
+		err := tx.Where("account_id = ?", id).Order("created_at DESC, balance_after").Limit(1).Find(&latest).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latest.ID != uuid.Nil && latest.BalanceAfter != account.Balance {
+			t.Errorf("account %s: balance %s, latest balance_after %s", account.Kind, account.Balance, latest.BalanceAfter)
+		}
+	}
+}
+
+func TestWalletLedgerStaysBalanced(t *testing.T) {
+	tx := openWalletTestTx(t)
+	walletRepo := repository.NewWalletRepository(tx)
+
+	userID := uuid.New()
+	customer := customerWalletAccount(userID, "IDR")
+	revenue := systemWalletAccount(models.WalletAccountRevenue, "IDR")
+	funding := systemWalletAccount(models.WalletAccountFunding, "IDR")
+	order := &models.Order{ID: uuid.New(), UserID: userID, Currency: "IDR", TotalPrice: models.NewMoney(30000)}
+
+	topUp := &models.WalletTransaction{Type: models.WalletTxTopUp}
+	if err := transferWallet(walletRepo, topUp, funding, customer, models.NewMoney(50000)); err != nil {
+		t.Fatalf("top-up: %v", err)
+	}
+	if err := payOrderFromWallet(walletRepo, order); err != nil {
+		t.Fatalf("payment: %v", err)
+	}
+	if err := refundOrderToWallet(walletRepo, order, "Order cancelled"); err != nil {
+		t.Fatalf("refund: %v", err)
+	}
+	// A second refund of the same order is a no-op
+	if err := refundOrderToWallet(walletRepo, order, "Order cancelled"); err != nil {
+		t.Fatalf("repeated refund: %v", err)
+	}
+
+	stored, err := walletRepo.FindAccountByID(customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Balance != models.NewMoney(50000) {
+		t.Errorf("customer balance = %s, want 50000.00", stored.Balance)
+	}
+	assertLedgerBalanced(t, tx, customer, revenue, funding)
+}
+
+func TestWalletTransferRejectsOverspend(t *testing.T) {
+	tx := openWalletTestTx(t)
+	walletRepo := repository.NewWalletRepository(tx)
+
+	userID := uuid.New()
+	customer := customerWalletAccount(userID, "IDR")
+	revenue := systemWalletAccount(models.WalletAccountRevenue, "IDR")
+	funding := systemWalletAccount(models.WalletAccountFunding, "IDR")
+
+	if err := transferWallet(walletRepo, &models.WalletTransaction{Type: models.WalletTxTopUp}, funding, customer, models.NewMoney(10000)); err != nil {
+		t.Fatalf("top-up: %v", err)
+	}
+
+	order := &models.Order{ID: uuid.New(), UserID: userID, Currency: "IDR", TotalPrice: models.NewMoney(10000) + 1}
+	if err := payOrderFromWallet(walletRepo, order); !errors.Is(err, errInsufficientWalletBalance) {
+		t.Fatalf("overspend error = %v, want %v", err, errInsufficientWalletBalance)
+	}
+	if _, err := walletRepo.FindTransactionByOrder(order.ID, models.WalletTxOrderPayment); err == nil {
+		t.Error("a rejected payment left a transaction behind")
+	}
+
+	for _, amount := range []models.Money{0, -1} {
+		if err := transferWallet(walletRepo, &models.WalletTransaction{Type: models.WalletTxAdjustment}, customer, revenue, amount); err == nil {
+			t.Errorf("transfer of %s was accepted", amount)
+		}
+	}
+	assertLedgerBalanced(t, tx, customer, revenue, funding)
+}
@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"laundry-go/internal/config"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"laundry-go/internal/webhook"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// webhookPingEvent is sent by POST /webhooks/:id/ping to test an endpoint.
+const webhookPingEvent = "webhook.ping"
+
+// webhookEvents are the event types laundries can subscribe to.
+var webhookEvents = []string{
+	events.OrderCreated,
+	events.OrderStatusChanged,
+	events.OrderCancelled,
+	events.PaymentSucceeded,
+}
+
+type WebhookService interface {
+	Create(userID, laundryID string, req CreateWebhookRequest) (*WebhookResponse, error)
+	GetByLaundry(userID, laundryID string) ([]WebhookResponse, error)
+	Update(userID, webhookID string, req UpdateWebhookRequest) (*WebhookResponse, error)
+	Delete(userID, webhookID string) error
+	Ping(userID, webhookID string) (*models.WebhookDelivery, error)
+	GetDeliveries(userID, webhookID string, page, limit int) (*WebhookDeliveryListResponse, error)
+	Replay(userID, webhookID, deliveryID string) (*models.WebhookDelivery, error)
+	Fanout(event events.Event) error
+	Deliver(ctx context.Context, deliveryID string, final bool) error
+}
+
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+	laundryRepo repository.LaundryRepository
+	jobRepo     repository.JobRepository
+	transactor  repository.Transactor
+	client      *webhook.Client
+	cfg         config.WebhookConfig
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"` // defaults to every supported event
+}
+
+type UpdateWebhookRequest struct {
+	URL          *string  `json:"url"`
+	Events       []string `json:"events"`
+	IsActive     *bool    `json:"is_active"`
+	RotateSecret bool     `json:"rotate_secret"`
+}
+
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	LaundryID string    `json:"laundry_id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	Secret    string    `json:"secret,omitempty"` // only returned when created or rotated
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+	Pagination Pagination               `json:"pagination"`
+}
+
+// webhookPayload is the JSON body posted to webhook endpoints. It leaves
+// out the customer's ID, which third parties have no use for.
+type webhookPayload struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	LaundryID  string                 `json:"laundry_id"`
+	OrderID    string                 `json:"order_id,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+type webhookJobPayload struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+func NewWebhookService(webhookRepo repository.WebhookRepository, laundryRepo repository.LaundryRepository, jobRepo repository.JobRepository, transactor repository.Transactor, cfg config.WebhookConfig) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		laundryRepo: laundryRepo,
+		jobRepo:     jobRepo,
+		transactor:  transactor,
+		client:      webhook.NewClient(cfg.Timeout, cfg.AllowPrivate),
+		cfg:         cfg,
+	}
+}
+
+func (s *webhookService) Create(userID, laundryID string, req CreateWebhookRequest) (*WebhookResponse, error) {
+	laundry, err := s.ownedLaundry(userID, laundryID)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSpace(req.URL)
+	if err := webhook.ValidateURL(url, s.cfg.AllowPrivate); err != nil {
+		return nil, err
+	}
+
+	subscribed, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, errors.New("failed to generate webhook secret")
+	}
+
+	hook := &models.Webhook{
+		LaundryID: laundry.ID,
+		URL:       url,
+		Secret:    secret,
+		Events:    strings.Join(subscribed, ","),
+		IsActive:  true,
+	}
+	if err := s.webhookRepo.Create(hook); err != nil {
+		return nil, errors.New("failed to create webhook")
+	}
+
+	response := toWebhookResponse(hook)
+	response.Secret = secret
+	return response, nil
+}
+
+func (s *webhookService) GetByLaundry(userID, laundryID string) ([]WebhookResponse, error) {
+	laundry, err := s.ownedLaundry(userID, laundryID)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks, err := s.webhookRepo.FindByLaundryID(laundry.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch webhooks")
+	}
+
+	items := make([]WebhookResponse, 0, len(hooks))
+	for i := range hooks {
+		items = append(items, *toWebhookResponse(&hooks[i]))
+	}
+	return items, nil
+}
+
+func (s *webhookService) Update(userID, webhookID string, req UpdateWebhookRequest) (*WebhookResponse, error) {
+	hook, err := s.ownedWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		url := strings.TrimSpace(*req.URL)
+		if err := webhook.ValidateURL(url, s.cfg.AllowPrivate); err != nil {
+			return nil, err
+		}
+		hook.URL = url
+	}
+	if req.Events != nil {
+		subscribed, err := normalizeWebhookEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		hook.Events = strings.Join(subscribed, ",")
+	}
+	if req.IsActive != nil {
+		hook.IsActive = *req.IsActive
+	}
+
+	secret := ""
+	if req.RotateSecret {
+		secret, err = webhook.NewSecret()
+		if err != nil {
+			return nil, errors.New("failed to generate webhook secret")
+		}
+		hook.Secret = secret
+	}
+
+	if err := s.webhookRepo.Update(hook); err != nil {
+		return nil, errors.New("failed to update webhook")
+	}
+
+	response := toWebhookResponse(hook)
+	response.Secret = secret
+	return response, nil
+}
+
+func (s *webhookService) Delete(userID, webhookID string) error {
+	hook, err := s.ownedWebhook(userID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(hook.ID); err != nil {
+		return errors.New("failed to delete webhook")
+	}
+	return nil
+}
+
+// Ping queues a webhook.ping delivery so owners can check their endpoint
+// and signature verification before real events arrive.
+func (s *webhookService) Ping(userID, webhookID string) (*models.WebhookDelivery, error) {
+	hook, err := s.ownedWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := webhookPayload{
+		ID:         uuid.New().String(),
+		Type:       webhookPingEvent,
+		LaundryID:  hook.LaundryID.String(),
+		OccurredAt: time.Now().UTC(),
+		Data:       map[string]interface{}{"webhook_id": hook.ID.String()},
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, errors.New("failed to queue ping")
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID: hook.ID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   string(body),
+		Status:    models.WebhookDeliveryPending,
+	}
+	if err := s.queueDelivery(delivery); err != nil {
+		return nil, errors.New("failed to queue ping")
+	}
+	return delivery, nil
+}
+
+func (s *webhookService) GetDeliveries(userID, webhookID string, page, limit int) (*WebhookDeliveryListResponse, error) {
+	hook, err := s.ownedWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	deliveries, total, err := s.webhookRepo.FindDeliveriesByWebhookID(hook.ID, page, limit)
+	if err != nil {
+		return nil, errors.New("failed to fetch deliveries")
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	return &WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Pagination: Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// Replay sends a past delivery's payload again as a new delivery, signed
+// with the webhook's current secret. The event ID is unchanged so receivers
+// can deduplicate.
+func (s *webhookService) Replay(userID, webhookID, deliveryID string) (*models.WebhookDelivery, error) {
+	hook, err := s.ownedWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryUUID, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, errors.New("invalid delivery ID")
+	}
+
+	original, err := s.webhookRepo.FindDeliveryByID(deliveryUUID)
+	if err != nil || original.WebhookID != hook.ID {
+		return nil, errors.New("delivery not found")
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID: hook.ID,
+		EventID:   original.EventID,
+		EventType: original.EventType,
+		Payload:   original.Payload,
+		Status:    models.WebhookDeliveryPending,
+		ReplayOf:  &original.ID,
+	}
+	if err := s.queueDelivery(delivery); err != nil {
+		return nil, errors.New("failed to queue replay")
+	}
+	return delivery, nil
+}
+
+// Fanout creates a delivery for every active webhook of the event's laundry
+// that subscribes to its type. It is safe to run more than once for the
+// same event.
+func (s *webhookService) Fanout(event events.Event) error {
+	if !supportedWebhookEvent(event.Type) {
+		return nil
+	}
+
+	hooks, err := s.webhookRepo.FindActiveByLaundryID(event.LaundryID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		LaundryID:  event.LaundryID.String(),
+		OrderID:    event.OrderID.String(),
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		webhookRepo := s.webhookRepo.WithTx(tx)
+		jobRepo := s.jobRepo.WithTx(tx)
+
+		for i := range hooks {
+			if !subscribesTo(&hooks[i], event.Type) {
+				continue
+			}
+
+			delivery := &models.WebhookDelivery{
+				WebhookID: hooks[i].ID,
+				EventID:   event.ID,
+				EventType: event.Type,
+				Payload:   string(body),
+				Status:    models.WebhookDeliveryPending,
+			}
+			created, err := webhookRepo.CreateDeliveryOnce(delivery)
+			if err != nil {
+				return err
+			}
+			if !created {
+				continue
+			}
+			if err := createDeliveryJob(jobRepo, delivery.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Deliver sends one delivery and logs the outcome on it. A failed send
+// returns an error so the job is retried; final marks the job's last
+// attempt, after which the delivery is recorded as failed.
+func (s *webhookService) Deliver(ctx context.Context, deliveryID string, final bool) error {
+	deliveryUUID, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := s.webhookRepo.FindDeliveryByID(deliveryUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The webhook was deleted along with its log
+			return nil
+		}
+		return err
+	}
+	if delivery.Status == models.WebhookDeliverySucceeded {
+		return nil
+	}
+
+	hook, err := s.webhookRepo.FindByID(delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	delivery.Attempts++
+	if !hook.IsActive && delivery.EventType != webhookPingEvent {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = "webhook is disabled"
+		return s.webhookRepo.UpdateDelivery(delivery)
+	}
+
+	response, sendErr := s.client.Send(ctx, webhook.Request{
+		URL:        hook.URL,
+		Secret:     hook.Secret,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID.String(),
+		Body:       []byte(delivery.Payload),
+	})
+
+	delivery.ResponseStatus = response.Status
+	delivery.ResponseBody = response.Body
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = sendErr.Error()
+		if final {
+			delivery.Status = models.WebhookDeliveryFailed
+		}
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// queueDelivery creates a delivery and the job that sends it.
+func (s *webhookService) queueDelivery(delivery *models.WebhookDelivery) error {
+	return s.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		if err := s.webhookRepo.WithTx(tx).CreateDelivery(delivery); err != nil {
+			return err
+		}
+		return createDeliveryJob(s.jobRepo.WithTx(tx), delivery.ID)
+	})
+}
+
+func createDeliveryJob(jobRepo repository.JobRepository, deliveryID uuid.UUID) error {
+	payload, err := json.Marshal(webhookJobPayload{DeliveryID: deliveryID.String()})
+	if err != nil {
+		return err
+	}
+	return jobRepo.Create(newJob(models.JobWebhookDeliver, string(payload), time.Now()))
+}
+
+func (s *webhookService) ownedLaundry(userID, laundryID string) (*models.Laundry, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	laundryUUID, err := uuid.Parse(laundryID)
+	if err != nil {
+		return nil, errors.New("invalid laundry ID")
+	}
+
+	laundry, err := s.laundryRepo.FindByID(laundryUUID)
+	if err != nil {
+		return nil, errors.New("laundry not found")
+	}
+	if laundry.OwnerID != userUUID {
+		return nil, errors.New("unauthorized")
+	}
+	return laundry, nil
+}
+
+func (s *webhookService) ownedWebhook(userID, webhookID string) (*models.Webhook, error) {
+	webhookUUID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, errors.New("invalid webhook ID")
+	}
+
+	hook, err := s.webhookRepo.FindByID(webhookUUID)
+	if err != nil {
+		return nil, errors.New("webhook not found")
+	}
+
+	if _, err := s.ownedLaundry(userID, hook.LaundryID.String()); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// normalizeWebhookEvents validates the requested event types, dropping
+// duplicates. An empty list subscribes to every supported event.
+func normalizeWebhookEvents(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return webhookEvents, nil
+	}
+
+	seen := make(map[string]bool, len(requested))
+	subscribed := make([]string, 0, len(requested))
+	for _, eventType := range requested {
+		eventType = strings.TrimSpace(eventType)
+		if !supportedWebhookEvent(eventType) {
+			return nil, errors.New("unsupported webhook event: " + eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			subscribed = append(subscribed, eventType)
+		}
+	}
+	return subscribed, nil
+}
+
+func supportedWebhookEvent(eventType string) bool {
+	for _, known := range webhookEvents {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+func subscribesTo(hook *models.Webhook, eventType string) bool {
+	for _, subscribed := range strings.Split(hook.Events, ",") {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func toWebhookResponse(hook *models.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:        hook.ID.String(),
+		LaundryID: hook.LaundryID.String(),
+		URL:       hook.URL,
+		Events:    strings.Split(hook.Events, ","),
+		IsActive:  hook.IsActive,
+		CreatedAt: hook.CreatedAt,
+	}
+}
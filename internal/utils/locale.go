@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when neither the request nor the user specifies one.
+const DefaultLocale = "id-ID"
+
+var supportedLocales = map[string]string{
+	"id": "id-ID",
+	"in": "id-ID",
+	"en": "en-US",
+}
+
+// NormalizeLocale maps a language tag such as "en", "en-GB" or "id_ID" to a
+// supported locale. It returns an empty string if the language is not supported.
+func NormalizeLocale(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return ""
+	}
+	lang := strings.ToLower(tag)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return supportedLocales[lang]
+}
+
+// NegotiateLocale picks the best supported locale from an Accept-Language
+// header value, honouring q-weights. It returns an empty string if none match.
+func NegotiateLocale(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if fields[0] == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: fields[0], q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, c := range candidates {
+		if locale := NormalizeLocale(c.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"laundry-go/internal/config"
+	"laundry-go/internal/events"
+	"laundry-go/internal/models"
+	"laundry-go/internal/service"
+	"time"
+)
+
+const (
+	// outboxRelayInterval is how often new outbox events are turned into jobs.
+	outboxRelayInterval = time.Second
+	trailPruneInterval  = time.Hour
+	// subscriptionSettleInterval is how often ended subscription periods
+	// are renewed or expired.
+	subscriptionSettleInterval = time.Minute
+)
+
+// Services are the services background jobs call into.
+type Services struct {
+	Outbox       service.OutboxService
+	Notification service.NotificationService
+	Order        service.OrderService
+	Webhook      service.WebhookService
+	Tracking     service.TrackingService
+	Recurring    service.RecurringOrderService
+	Subscription service.SubscriptionService
+}
+
+// RegisterDefaults wires the standard job handlers and periodic tasks used
+// by both the API server and cmd/worker.
+func RegisterDefaults(w *Worker, bus events.Bus, services Services, cfg *config.Config) {
+	w.Handle(models.JobPublishEvent, func(ctx context.Context, job *models.Job) error {
+		var event events.Event
+		if err := json.Unmarshal([]byte(job.Payload), &event); err != nil {
+			return err
+		}
+		return bus.Publish(event)
+	})
+
+	w.Handle(models.JobWebhookDispatch, func(ctx context.Context, job *models.Job) error {
+		var event events.Event
+		if err := json.Unmarshal([]byte(job.Payload), &event); err != nil {
+			return err
+		}
+		return services.Webhook.Fanout(event)
+	})
+
+	w.Handle(models.JobWebhookDeliver, func(ctx context.Context, job *models.Job) error {
+		var payload struct {
+			DeliveryID string `json:"delivery_id"`
+		}
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return err
+		}
+		return services.Webhook.Deliver(ctx, payload.DeliveryID, job.Attempts >= job.MaxAttempts)
+	})
+
+	w.Every("outbox relay", outboxRelayInterval, func(ctx context.Context) error {
+		_, err := services.Outbox.Relay()
+		return err
+	})
+
+	w.Every("notification dispatch", cfg.Notify.DispatchInterval, func(ctx context.Context) error {
+		_, err := services.Notification.Dispatch(ctx)
+		return err
+	})
+
+	w.Every("order expiry", cfg.Order.ExpiryInterval, func(ctx context.Context) error {
+		_, err := services.Order.ExpireUnconfirmed()
+		return err
+	})
+
+	w.Every("recurring orders", cfg.Order.RecurringInterval, func(ctx context.Context) error {
+		_, err := services.Recurring.PlaceDue()
+		return err
+	})
+
+	w.Every("subscription renewal", subscriptionSettleInterval, func(ctx context.Context) error {
+		_, err := services.Subscription.SettleDue()
+		return err
+	})
+
+	w.Every("courier trail pruning", trailPruneInterval, func(ctx context.Context) error {
+		_, err := services.Tracking.PruneTrail()
+		return err
+	})
+}
@@ -0,0 +1,199 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"laundry-go/internal/models"
+	"laundry-go/internal/repository"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// visibilityTimeout is how long a job may stay running before another
+	// worker assumes its worker died and runs it again. Handlers must be
+	// idempotent: jobs are delivered at least once.
+	visibilityTimeout = 5 * time.Minute
+	backoffBase       = 10 * time.Second
+	backoffMax        = time.Hour
+)
+
+// Handler runs one job. Returning an error schedules a retry.
+type Handler func(ctx context.Context, job *models.Job) error
+
+type periodicTask struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// Worker pulls jobs from the Postgres queue with a pool of goroutines and
+// runs periodic tasks such as relaying the outbox.
+type Worker struct {
+	jobRepo      repository.JobRepository
+	transactor   repository.Transactor
+	handlers     map[string]Handler
+	tasks        []periodicTask
+	concurrency  int
+	pollInterval time.Duration
+	id           string
+}
+
+func New(jobRepo repository.JobRepository, transactor repository.Transactor, concurrency int, pollInterval time.Duration) *Worker {
+	hostname, _ := os.Hostname()
+	return &Worker{
+		jobRepo:      jobRepo,
+		transactor:   transactor,
+		handlers:     make(map[string]Handler),
+		concurrency:  concurrency,
+		pollInterval: pollInterval,
+		id:           fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Handle registers the handler for a job type.
+func (w *Worker) Handle(jobType string, handler Handler) {
+	w.handlers[jobType] = handler
+}
+
+// Every runs fn on a fixed interval while the worker is running.
+func (w *Worker) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	w.tasks = append(w.tasks, periodicTask{name: name, interval: interval, run: fn})
+}
+
+// Run processes jobs until ctx is cancelled, then waits for in-flight jobs
+// to finish.
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.poll(ctx)
+		}()
+	}
+
+	for _, task := range w.tasks {
+		wg.Add(1)
+		go func(task periodicTask) {
+			defer wg.Done()
+			ticker := time.NewTicker(task.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := task.run(ctx); err != nil {
+						log.Printf("worker: %s failed: %v", task.name, err)
+					}
+				}
+			}
+		}(task)
+	}
+
+	log.Printf("worker: %s started with %d goroutines", w.id, w.concurrency)
+	wg.Wait()
+}
+
+// poll runs jobs back to back while there are any, and sleeps for
+// pollInterval when the queue is empty.
+func (w *Worker) poll(ctx context.Context) {
+	for {
+		job, err := w.claim()
+		if err != nil {
+			log.Printf("worker: failed to claim job: %v", err)
+		}
+		if job != nil {
+			w.execute(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+func (w *Worker) claim() (*models.Job, error) {
+	var job *models.Job
+	err := w.transactor.WithinTransaction(func(tx *gorm.DB) error {
+		jobRepo := w.jobRepo.WithTx(tx)
+
+		now := time.Now()
+		var err error
+		job, err = jobRepo.ClaimNext(now, now.Add(-visibilityTimeout))
+		if err != nil || job == nil {
+			return err
+		}
+
+		job.Status = models.JobStatusRunning
+		job.LockedAt = &now
+		job.LockedBy = w.id
+		job.Attempts++
+		return jobRepo.Update(job)
+	})
+	return job, err
+}
+
+func (w *Worker) execute(ctx context.Context, job *models.Job) {
+	err := w.runHandler(ctx, job)
+
+	now := time.Now()
+	job.LockedAt = nil
+	job.LockedBy = ""
+	switch {
+	case err == nil:
+		job.Status = models.JobStatusDone
+		job.CompletedAt = &now
+		job.LastError = ""
+	case job.Attempts >= job.MaxAttempts:
+		job.Status = models.JobStatusDead
+		job.LastError = err.Error()
+		log.Printf("worker: job %s (%s) dead after %d attempts: %v", job.ID, job.Type, job.Attempts, err)
+	default:
+		job.Status = models.JobStatusPending
+		job.LastError = err.Error()
+		job.RunAt = now.Add(backoff(job.Attempts))
+	}
+
+	if err := w.jobRepo.Update(job); err != nil {
+		log.Printf("worker: failed to save job %s: %v", job.ID, err)
+	}
+}
+
+func (w *Worker) runHandler(ctx context.Context, job *models.Job) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		// Nothing can run it; dead-letter straight away
+		job.Attempts = job.MaxAttempts
+		return fmt.Errorf("no handler for job type %s", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// backoff doubles the delay with every attempt, starting at backoffBase and
+// capped at backoffMax.
+func backoff(attempts int) time.Duration {
+	delay := backoffBase
+	for i := 1; i < attempts && delay < backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > backoffMax {
+		delay = backoffMax
+	}
+	return delay
+}
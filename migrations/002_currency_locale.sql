@@ -0,0 +1,4 @@
+-- Currency per laundry, carried on each order, and a saved locale per user
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(10);
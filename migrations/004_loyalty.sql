@@ -0,0 +1,20 @@
+-- Loyalty points ledger
+CREATE TABLE IF NOT EXISTS loyalty_ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    laundry_id UUID REFERENCES laundries(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL,
+    points BIGINT NOT NULL,
+    remaining_points BIGINT NOT NULL DEFAULT 0 CHECK (remaining_points >= 0),
+    expires_at TIMESTAMP,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_loyalty_ledger_entries_user_id ON loyalty_ledger_entries(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loyalty_order_type ON loyalty_ledger_entries(order_id, type);
+
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS loyalty_points_per_thousand DECIMAL(6, 2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS points_redeemed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS points_discount DECIMAL(12, 2) NOT NULL DEFAULT 0;
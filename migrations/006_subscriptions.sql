@@ -0,0 +1,50 @@
+-- Subscription packages (monthly kg quotas)
+CREATE TABLE IF NOT EXISTS subscription_packages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    quota_kg DECIMAL(10, 2) NOT NULL,
+    price DECIMAL(12, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    period_days INTEGER NOT NULL DEFAULT 30,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_packages_laundry_id ON subscription_packages(laundry_id);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    package_id UUID NOT NULL REFERENCES subscription_packages(id) ON DELETE CASCADE,
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    quota_total DECIMAL(10, 2) NOT NULL,
+    quota_remaining DECIMAL(10, 2) NOT NULL CHECK (quota_remaining >= 0),
+    auto_renew BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_laundry_id ON subscriptions(laundry_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_status ON subscriptions(status);
+
+CREATE TABLE IF NOT EXISTS subscription_usages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_service_id UUID NOT NULL REFERENCES order_services(id) ON DELETE CASCADE,
+    quantity DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_usages_subscription_id ON subscription_usages(subscription_id);
+CREATE INDEX IF NOT EXISTS idx_subscription_usages_order_id ON subscription_usages(order_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subscription_id UUID REFERENCES subscriptions(id) ON DELETE SET NULL;
+ALTER TABLE order_services ADD COLUMN IF NOT EXISTS quota_quantity DECIMAL(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+-- Confirmation deadline and status history for orders
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS confirm_window_minutes INTEGER;
+
+-- Orders placed before this migration keep a NULL deadline and never expire
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS confirm_by TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_orders_confirm_by ON orders(confirm_by) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    reason TEXT,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id);
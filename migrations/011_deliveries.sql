@@ -0,0 +1,20 @@
+-- Courier deliveries: one row per order leg (pickup or dropoff)
+CREATE TABLE IF NOT EXISTS deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    courier_id UUID NOT NULL REFERENCES users(id),
+    leg VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'assigned',
+    notes TEXT,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    arrived_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_deliveries_order_leg ON deliveries(order_id, leg);
+CREATE INDEX IF NOT EXISTS idx_deliveries_laundry_id ON deliveries(laundry_id);
+CREATE INDEX IF NOT EXISTS idx_deliveries_courier_id ON deliveries(courier_id);
@@ -0,0 +1,23 @@
+-- Delivery coordinates on orders and courier location tracking
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_latitude DECIMAL(10, 8);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_longitude DECIMAL(11, 8);
+
+CREATE TABLE IF NOT EXISTS courier_locations (
+    courier_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    latitude DECIMAL(10, 8) NOT NULL,
+    longitude DECIMAL(11, 8) NOT NULL,
+    accuracy DECIMAL(8, 2),
+    heading DECIMAL(5, 2),
+    recorded_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS courier_location_pings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    courier_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    latitude DECIMAL(10, 8) NOT NULL,
+    longitude DECIMAL(11, 8) NOT NULL,
+    recorded_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_courier_location_pings_courier_time ON courier_location_pings(courier_id, recorded_at);
@@ -0,0 +1,33 @@
+-- Courier route plans: ordered stops per courier for a laundry's time window
+CREATE TABLE IF NOT EXISTS route_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    window_start TIMESTAMP NOT NULL,
+    window_end TIMESTAMP NOT NULL,
+    capacity INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_route_plans_laundry_id ON route_plans(laundry_id);
+CREATE INDEX IF NOT EXISTS idx_route_plans_window_end ON route_plans(window_end);
+
+CREATE TABLE IF NOT EXISTS route_plan_stops (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    plan_id UUID NOT NULL REFERENCES route_plans(id) ON DELETE CASCADE,
+    courier_id UUID REFERENCES users(id),
+    sequence INTEGER NOT NULL DEFAULT 0,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    delivery_id UUID REFERENCES deliveries(id) ON DELETE SET NULL,
+    leg VARCHAR(20) NOT NULL,
+    latitude DECIMAL(10, 8),
+    longitude DECIMAL(11, 8),
+    window_start TIMESTAMP NOT NULL,
+    window_end TIMESTAMP NOT NULL,
+    planned_arrival TIMESTAMP,
+    distance_km DECIMAL(8, 2) NOT NULL DEFAULT 0,
+    note VARCHAR(255)
+);
+
+CREATE INDEX IF NOT EXISTS idx_route_plan_stops_plan_id ON route_plan_stops(plan_id);
+CREATE INDEX IF NOT EXISTS idx_route_plan_stops_courier_id ON route_plan_stops(courier_id);
@@ -0,0 +1,16 @@
+-- Proof of pickup and delivery: photos and signatures stored in the blob store
+CREATE TABLE IF NOT EXISTS order_proofs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    delivery_id UUID REFERENCES deliveries(id) ON DELETE SET NULL,
+    leg VARCHAR(20) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_proofs_order_id ON order_proofs(order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_proofs_signature ON order_proofs(order_id, leg) WHERE kind = 'signature';
@@ -0,0 +1,18 @@
+-- Uploaded laundry images (logo, cover, gallery) with resized variants
+CREATE TABLE IF NOT EXISTS laundry_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size BIGINT NOT NULL,
+    original_key VARCHAR(255) NOT NULL,
+    medium_key VARCHAR(255) NOT NULL,
+    thumb_key VARCHAR(255) NOT NULL,
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_laundry_images_laundry_id ON laundry_images(laundry_id);
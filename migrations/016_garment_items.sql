@@ -0,0 +1,31 @@
+-- Garment-level tracking: tagged items per order and their stage scans
+CREATE TABLE IF NOT EXISTS garment_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    order_service_id UUID REFERENCES order_services(id) ON DELETE SET NULL,
+    tag_code VARCHAR(20) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    colour VARCHAR(50),
+    brand VARCHAR(100),
+    notes TEXT,
+    damage_flags TEXT,
+    stage VARCHAR(20) NOT NULL DEFAULT 'received',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_garment_items_tag_code ON garment_items(tag_code);
+CREATE INDEX IF NOT EXISTS idx_garment_items_order_id ON garment_items(order_id);
+CREATE INDEX IF NOT EXISTS idx_garment_items_laundry_id ON garment_items(laundry_id);
+
+CREATE TABLE IF NOT EXISTS garment_scans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES garment_items(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL,
+    note TEXT,
+    scanned_by UUID NOT NULL REFERENCES users(id),
+    scanned_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_garment_scans_item_id ON garment_scans(item_id);
@@ -0,0 +1,39 @@
+-- Measured-quantity adjustments after pickup, with the totals before and after
+CREATE TABLE IF NOT EXISTS order_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    note TEXT,
+    subtotal_before DECIMAL(12,2) NOT NULL,
+    subtotal_after DECIMAL(12,2) NOT NULL,
+    discount_before DECIMAL(12,2) NOT NULL DEFAULT 0,
+    discount_after DECIMAL(12,2) NOT NULL DEFAULT 0,
+    total_before DECIMAL(12,2) NOT NULL,
+    total_after DECIMAL(12,2) NOT NULL,
+    settlement VARCHAR(20),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP,
+    rejection_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_adjustments_order_id ON order_adjustments(order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_adjustments_pending ON order_adjustments(order_id) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS order_adjustment_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    adjustment_id UUID NOT NULL REFERENCES order_adjustments(id) ON DELETE CASCADE,
+    order_service_id UUID NOT NULL REFERENCES order_services(id) ON DELETE CASCADE,
+    service_name VARCHAR(255) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    quantity_before DECIMAL(10,2) NOT NULL,
+    quantity_after DECIMAL(10,2) NOT NULL,
+    quota_before DECIMAL(10,2) NOT NULL DEFAULT 0,
+    quota_after DECIMAL(10,2) NOT NULL DEFAULT 0,
+    subtotal_before DECIMAL(12,2) NOT NULL,
+    subtotal_after DECIMAL(12,2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_adjustment_lines_adjustment_id ON order_adjustment_lines(adjustment_id);
@@ -0,0 +1,61 @@
+-- Damage and lost-item claims raised by customers after delivery
+CREATE TABLE IF NOT EXISTS claims (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    resolution VARCHAR(20),
+    resolution_note TEXT,
+    refunded_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP,
+    escalated_at TIMESTAMP,
+    escalation_reason TEXT,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_claims_order_id ON claims(order_id);
+CREATE INDEX IF NOT EXISTS idx_claims_user_id ON claims(user_id);
+CREATE INDEX IF NOT EXISTS idx_claims_laundry_id ON claims(laundry_id);
+CREATE INDEX IF NOT EXISTS idx_claims_status ON claims(status);
+CREATE INDEX IF NOT EXISTS idx_claims_escalated_at ON claims(escalated_at);
+-- One claim per order may be in progress at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_claims_active ON claims(order_id) WHERE status <> 'closed';
+
+CREATE TABLE IF NOT EXISTS claim_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    claim_id UUID NOT NULL REFERENCES claims(id) ON DELETE CASCADE,
+    garment_item_id UUID REFERENCES garment_items(id) ON DELETE SET NULL,
+    description TEXT NOT NULL,
+    claimed_amount DECIMAL(12,2) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_claim_items_claim_id ON claim_items(claim_id);
+
+CREATE TABLE IF NOT EXISTS claim_photos (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    claim_id UUID NOT NULL REFERENCES claims(id) ON DELETE CASCADE,
+    storage_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_claim_photos_claim_id ON claim_photos(claim_id);
+
+CREATE TABLE IF NOT EXISTS claim_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    claim_id UUID NOT NULL REFERENCES claims(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id),
+    author_role VARCHAR(20) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_claim_messages_claim_id ON claim_messages(claim_id);
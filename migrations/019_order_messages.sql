@@ -0,0 +1,23 @@
+-- Per-order chat between the customer, the laundry and its couriers
+CREATE TABLE IF NOT EXISTS order_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(id),
+    sender_role VARCHAR(20) NOT NULL,
+    body TEXT,
+    attachment_key VARCHAR(255),
+    attachment_content_type VARCHAR(50),
+    attachment_size BIGINT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_messages_order_created ON order_messages(order_id, created_at);
+
+-- Read receipts: each participant's position in the order's chat
+CREATE TABLE IF NOT EXISTS order_chat_reads (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    last_read_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_id, user_id)
+);
@@ -0,0 +1,9 @@
+-- Laundries customers saved as favourites
+CREATE TABLE IF NOT EXISTS favorite_laundries (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, laundry_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_favorite_laundries_laundry_id ON favorite_laundries(laundry_id);
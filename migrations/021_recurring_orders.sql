@@ -0,0 +1,39 @@
+-- Recurring order templates and the orders they place
+CREATE TABLE IF NOT EXISTS recurring_orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    laundry_id UUID NOT NULL REFERENCES laundries(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    frequency VARCHAR(20) NOT NULL,
+    weekday INTEGER NOT NULL,
+    pickup_time VARCHAR(5) NOT NULL,
+    end_date DATE,
+    delivery_address TEXT NOT NULL,
+    delivery_latitude DECIMAL(10,8),
+    delivery_longitude DECIMAL(11,8),
+    notes TEXT,
+    payment_method VARCHAR(20) NOT NULL DEFAULT 'cash',
+    next_pickup_at TIMESTAMP,
+    last_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    last_failure TEXT,
+    last_failed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_orders_user_id ON recurring_orders(user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_orders_laundry_id ON recurring_orders(laundry_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_orders_next_pickup_at ON recurring_orders(next_pickup_at) WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS recurring_order_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recurring_order_id UUID NOT NULL REFERENCES recurring_orders(id) ON DELETE CASCADE,
+    service_id UUID NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    quantity DECIMAL(10,2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_order_lines_recurring_order_id ON recurring_order_lines(recurring_order_id);
+
+-- Orders placed from a template; one per pickup
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS recurring_order_id UUID REFERENCES recurring_orders(id) ON DELETE SET NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_recurrence ON orders(recurring_order_id, estimated_pickup_at) WHERE recurring_order_id IS NOT NULL;
@@ -0,0 +1,68 @@
+-- Full-text laundry search over name, description, address and active
+-- services, with trigram matching for partial words and typos
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Postgres has no Indonesian stemmer, so words are only unaccented and
+-- lowercased
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'laundry_search') THEN
+        CREATE TEXT SEARCH CONFIGURATION laundry_search (COPY = simple);
+        ALTER TEXT SEARCH CONFIGURATION laundry_search
+            ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+    END IF;
+END $$;
+
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS search_text TEXT NOT NULL DEFAULT '';
+
+-- Recomputes a laundry's search columns on every write. Names weigh most,
+-- then service names and categories, description and address.
+CREATE OR REPLACE FUNCTION laundries_search_refresh() RETURNS TRIGGER AS $$
+DECLARE
+    services_text TEXT;
+BEGIN
+    SELECT COALESCE(string_agg(name || ' ' || replace(category, '_', ' '), ' '), '')
+    INTO services_text
+    FROM services
+    WHERE laundry_id = NEW.id AND is_active;
+
+    NEW.search_vector :=
+        setweight(to_tsvector('laundry_search', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('laundry_search', services_text), 'B') ||
+        setweight(to_tsvector('laundry_search', COALESCE(NEW.description, '')), 'C') ||
+        setweight(to_tsvector('laundry_search', COALESCE(NEW.address, '')), 'D');
+    NEW.search_text := lower(unaccent(
+        COALESCE(NEW.name, '') || ' ' || services_text || ' ' ||
+        COALESCE(NEW.description, '') || ' ' || COALESCE(NEW.address, '')));
+    RETURN NEW;
+END $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_laundries_search ON laundries;
+CREATE TRIGGER trg_laundries_search
+    BEFORE INSERT OR UPDATE ON laundries
+    FOR EACH ROW EXECUTE FUNCTION laundries_search_refresh();
+
+-- Service changes touch their laundry so its search columns are rebuilt
+CREATE OR REPLACE FUNCTION services_search_touch() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE laundries SET updated_at = updated_at WHERE id = OLD.laundry_id;
+    END IF;
+    IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR NEW.laundry_id <> OLD.laundry_id) THEN
+        UPDATE laundries SET updated_at = updated_at WHERE id = NEW.laundry_id;
+    END IF;
+    RETURN NULL;
+END $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_services_search ON services;
+CREATE TRIGGER trg_services_search
+    AFTER INSERT OR UPDATE OF name, category, is_active, laundry_id OR DELETE ON services
+    FOR EACH ROW EXECUTE FUNCTION services_search_touch();
+
+-- Backfill existing laundries
+UPDATE laundries SET updated_at = updated_at;
+
+CREATE INDEX IF NOT EXISTS idx_laundries_search_vector ON laundries USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_laundries_search_text ON laundries USING GIN (search_text gin_trgm_ops);
@@ -0,0 +1,6 @@
+-- Delivery availability and indexes for faceted laundry filtering
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS offers_delivery BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE laundries ADD COLUMN IF NOT EXISTS delivery_radius_km DECIMAL(6,2);
+
+CREATE INDEX IF NOT EXISTS idx_laundries_rating ON laundries(rating);
+CREATE INDEX IF NOT EXISTS idx_services_active_category ON services(laundry_id, category, price) WHERE is_active;